@@ -0,0 +1,1278 @@
+package gltf2
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/iamGreedy/glog"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+type encoder struct {
+	wr io.Writer
+	//
+	cause error
+	err   error
+	//
+	indent string
+	// destination of buffer, image data
+	// + sideFile : write as separated file, URI is relative path of file
+	// + dataURI : write as base64 data URI
+	// If both are not set, only unmodified URI can be written
+	sideFile func(name string, data []byte) error
+	dataURI  bool
+	// glb container, every buffer, image is written in BIN chunk
+	glb bool
+	// [progress]
+	// + logging task
+	logger *glog.Glogger
+}
+
+func (s *encoder) Writer(writer io.Writer) *encoder {
+	if writer == nil {
+		s.setCauseError(ErrorEncoderOption, errors.Errorf("nil writer"))
+		return s
+	}
+	s.wr = writer
+	if f, ok := s.wr.(*os.File); ok {
+		// ex) os.Stdout is not place for side file
+		if fi, err := f.Stat(); err == nil && fi.Mode().IsRegular() {
+			return s.Directory(filepath.Dir(f.Name()))
+		}
+	}
+	return s
+}
+func (s *encoder) Indent(indent string) *encoder {
+	s.indent = indent
+	return s
+}
+
+// Directory is where side files(buffer, image) are written
+func (s *encoder) Directory(dir string) *encoder {
+	fi, err := os.Stat(dir)
+	if err != nil {
+		s.setCauseError(ErrorEncoderOption, err)
+		return s
+	}
+	if !fi.IsDir() {
+		s.setCauseError(ErrorEncoderOption, errors.Errorf("Not directory '%s'", dir))
+		return s
+	}
+	return s.SideFile(func(name string, data []byte) error {
+		dst := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		return ioutil.WriteFile(dst, data, 0644)
+	})
+}
+
+// SideFile replace how side files are written, name is slash separated relative path
+func (s *encoder) SideFile(fn func(name string, data []byte) error) *encoder {
+	if fn == nil {
+		s.setCauseError(ErrorEncoderOption, errors.Errorf("Encoder().SideFile(<not nillable>)"))
+		return s
+	}
+	s.sideFile = fn
+	return s
+}
+
+// DataURI write every buffer, image as base64 data URI, it ignore side file setting
+func (s *encoder) DataURI() *encoder {
+	s.dataURI = true
+	return s
+}
+
+// GLB write glb container instead of json
+//
+// Every buffer is consolidated into one BIN chunk, URIImage is embedded as bufferView
+func (s *encoder) GLB() *encoder {
+	s.glb = true
+	return s
+}
+func (s *encoder) Logger(dst io.Writer) *encoder {
+	if dst != nil {
+		s.logger = glog.New(log.New(dst, "[ glTF 2.0 ] ", log.LstdFlags), "    ")
+	} else {
+		s.setCauseError(ErrorEncoderOption, errors.Errorf("Encoder().Logger(<not nillable>)"))
+	}
+	return s
+}
+func (s *encoder) GetLogger() *glog.Glogger {
+	return s.logger
+}
+
+//
+
+func (s *encoder) Error() error {
+	if s.cause != nil {
+		s.logger.Println(s.err)
+		return errors.WithMessage(s.cause, s.err.Error())
+	}
+	if s.err != nil {
+		s.logger.Println(s.err)
+		return errors.WithMessage(ErrorEncoder, s.err.Error())
+	}
+	return nil
+}
+func (s *encoder) setError(err error) {
+	s.cause = nil
+	s.err = err
+}
+func (s *encoder) setCauseError(cause error, err error) {
+	s.cause = cause
+	s.err = err
+}
+func (s *encoder) Encode(gltf *GLTF) error {
+	if err := s.Error(); err != nil {
+		return err
+	}
+	//====================================================//
+	// constraint
+	if s.wr == nil {
+		s.setCauseError(ErrorEncoder, errors.Errorf("No writer"))
+		return s.Error()
+	}
+	if gltf == nil {
+		s.setCauseError(ErrorEncoder, errors.Errorf("nil glTF"))
+		return s.Error()
+	}
+	//====================================================//
+	// glTFid indexing
+	s.logger.Println("specification build start...")
+	ctx := newEncoderContext(s, gltf)
+	spec, err := ctx.encode()
+	if err != nil {
+		s.setError(err)
+		return s.Error()
+	}
+	s.logger.Println("specification build complete")
+	//====================================================//
+	// json encode here
+	var bts []byte
+	if s.indent != "" {
+		bts, err = json.MarshalIndent(spec, "", s.indent)
+	} else {
+		bts, err = json.Marshal(spec)
+	}
+	if err != nil {
+		s.setCauseError(ErrorJSON, err)
+		return s.Error()
+	}
+	s.logger.Printf("json encode complete, %d bytes\n", len(bts))
+	//====================================================//
+	// write
+	if s.glb {
+		err = writeGLB(s.wr, bts, ctx.bin.chunk())
+		s.logger.Printf("glb write complete, bin = %d bytes\n", len(ctx.bin.data))
+	} else {
+		_, err = s.wr.Write(bts)
+	}
+	if err != nil {
+		s.setError(err)
+		return s.Error()
+	}
+	return nil
+}
+
+func Encoder(writer io.Writer) *encoder {
+	return new(encoder).Writer(writer)
+}
+
+// encodeIndex give glTFid to runtime object in order of first reference
+type encodeIndex struct {
+	ids   map[interface{}]SpecGLTFID
+	items []interface{}
+	// items[:done] is already written
+	done int
+}
+
+func (s *encodeIndex) id(item interface{}) SpecGLTFID {
+	if s.ids == nil {
+		s.ids = make(map[interface{}]SpecGLTFID)
+	}
+	if id, ok := s.ids[item]; ok {
+		return id
+	}
+	id := SpecGLTFID(len(s.items))
+	s.ids[item] = id
+	s.items = append(s.items, item)
+	return id
+}
+func (s *encodeIndex) has(item interface{}) bool {
+	_, ok := s.ids[item]
+	return ok
+}
+
+// encoderContext convert runtime object to specification
+//
+// Every pointer reached from GLTF get glTFid, even if it is not listed in GLTF root arrays
+type encoderContext struct {
+	ref *encoder
+	src *GLTF
+	dst *SpecGLTF
+	//
+	accessors   encodeIndex
+	buffers     encodeIndex
+	bufferViews encodeIndex
+	cameras     encodeIndex
+	images      encodeIndex
+	materials   encodeIndex
+	meshes      encodeIndex
+	nodes       encodeIndex
+	samplers    encodeIndex
+	scenes      encodeIndex
+	textures    encodeIndex
+	animations  encodeIndex
+	skins       encodeIndex
+	// written extension names
+	used map[string]bool
+	// written RawExtension names
+	raws map[string]bool
+	// written side file names
+	files map[string]bool
+	// glb BIN chunk, nil if not glb
+	bin *glbBuilder
+}
+
+func newEncoderContext(ref *encoder, src *GLTF) *encoderContext {
+	res := &encoderContext{
+		ref:   ref,
+		src:   src,
+		dst:   new(SpecGLTF),
+		used:  make(map[string]bool),
+		raws:  make(map[string]bool),
+		files: make(map[string]bool),
+	}
+	if ref.glb {
+		res.bin = &glbBuilder{
+			buffer:  new(Buffer),
+			offsets: make(map[*Buffer]int),
+		}
+	}
+	return res
+}
+
+func (s *encoderContext) Accessor(accessor *Accessor) *SpecGLTFID {
+	if accessor == nil {
+		return nil
+	}
+	id := s.accessors.id(accessor)
+	return &id
+}
+func (s *encoderContext) Buffer(buffer *Buffer) *SpecGLTFID {
+	if buffer == nil {
+		return nil
+	}
+	if s.bin != nil && !meshoptFallback(buffer) {
+		// every buffer is in BIN chunk
+		buffer = s.bin.buffer
+	}
+	id := s.buffers.id(buffer)
+	return &id
+}
+func (s *encoderContext) BufferView(bufferView *BufferView) *SpecGLTFID {
+	if bufferView == nil {
+		return nil
+	}
+	id := s.bufferViews.id(bufferView)
+	return &id
+}
+func (s *encoderContext) Camera(camera *Camera) *SpecGLTFID {
+	if camera == nil {
+		return nil
+	}
+	id := s.cameras.id(camera)
+	return &id
+}
+func (s *encoderContext) Image(img Image) *SpecGLTFID {
+	if img == nil {
+		return nil
+	}
+	id := s.images.id(img)
+	return &id
+}
+func (s *encoderContext) Material(material *Material) *SpecGLTFID {
+	if material == nil {
+		return nil
+	}
+	id := s.materials.id(material)
+	return &id
+}
+func (s *encoderContext) Mesh(mesh *Mesh) *SpecGLTFID {
+	if mesh == nil {
+		return nil
+	}
+	id := s.meshes.id(mesh)
+	return &id
+}
+func (s *encoderContext) Node(node *Node) *SpecGLTFID {
+	if node == nil {
+		return nil
+	}
+	id := s.nodes.id(node)
+	return &id
+}
+func (s *encoderContext) Sampler(sampler *Sampler) *SpecGLTFID {
+	if sampler == nil {
+		return nil
+	}
+	id := s.samplers.id(sampler)
+	return &id
+}
+func (s *encoderContext) Scene(scene *Scene) *SpecGLTFID {
+	if scene == nil {
+		return nil
+	}
+	id := s.scenes.id(scene)
+	return &id
+}
+func (s *encoderContext) Texture(texture *Texture) *SpecGLTFID {
+	if texture == nil {
+		return nil
+	}
+	id := s.textures.id(texture)
+	return &id
+}
+func (s *encoderContext) Skin(skin *Skin) *SpecGLTFID {
+	if skin == nil {
+		return nil
+	}
+	id := s.skins.id(skin)
+	return &id
+}
+func (s *encoderContext) GLTF() *GLTF {
+	return s.src
+}
+
+// Extensions convert runtime extensions, extension which is not ExtensionEncoder is dropped
+func (s *encoderContext) Extensions(exts *Extensions) (*SpecExtensions, error) {
+	if exts == nil || len(*exts) == 0 {
+		return nil, nil
+	}
+	names := make([]string, 0, len(*exts))
+	for k := range *exts {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	res := make(SpecExtensions)
+	for _, name := range names {
+		enc, ok := (*exts)[name].(ExtensionEncoder)
+		if !ok {
+			s.ref.logger.Printf("extension '%s' can't be written, dropped\n", name)
+			continue
+		}
+		v, err := enc.Encode(s)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("Extension '%s'", name))
+		}
+		bts, err := json.Marshal(v)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("Extension '%s'", name))
+		}
+		res[name] = &jsonRawString{src: bts}
+		s.used[name] = true
+		if _, ok := enc.(*RawExtension); ok {
+			s.raws[name] = true
+		}
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+	return &res, nil
+}
+func (s *encoderContext) TextureInfo(info *TextureInfo) (*SpecTextureInfo, error) {
+	if info == nil {
+		return nil, nil
+	}
+	exts, err := s.Extensions(info.Extensions)
+	if err != nil {
+		return nil, err
+	}
+	return &SpecTextureInfo{
+		Index:      s.Texture(info.Index),
+		TexCoord:   optTexCoord(info.TexCoord),
+		Extensions: exts,
+		Extras:     info.Extras,
+	}, nil
+}
+func (s *encoderContext) NormalTextureInfo(info *MaterialNormalTextureInfo) (*SpecMaterialNormalTextureInfo, error) {
+	if info == nil {
+		return nil, nil
+	}
+	exts, err := s.Extensions(info.Extensions)
+	if err != nil {
+		return nil, err
+	}
+	return &SpecMaterialNormalTextureInfo{
+		Index:      s.Texture(info.Index),
+		TexCoord:   optTexCoord(info.TexCoord),
+		Scale:      optFloat32(info.Scale, 1),
+		Extensions: exts,
+		Extras:     info.Extras,
+	}, nil
+}
+func (s *encoderContext) OcclusionTextureInfo(info *MaterialOcclusionTextureInfo) (*SpecMaterialOcclusionTextureInfo, error) {
+	if info == nil {
+		return nil, nil
+	}
+	exts, err := s.Extensions(info.Extensions)
+	if err != nil {
+		return nil, err
+	}
+	return &SpecMaterialOcclusionTextureInfo{
+		Index:      s.Texture(info.Index),
+		TexCoord:   optTexCoord(info.TexCoord),
+		Strength:   optFloat32(info.Strength, 1),
+		Extensions: exts,
+		Extras:     info.Extras,
+	}, nil
+}
+
+func (s *encoderContext) encode() (*SpecGLTF, error) {
+	var err error
+	// root arrays keep their order, objects only reached by reference follow them
+	for _, v := range s.src.Accessors {
+		s.Accessor(v)
+	}
+	if s.bin != nil {
+		// BIN chunk is buffers[0], even if fallback buffer comes first
+		for _, v := range s.src.Buffers {
+			if v != nil && !meshoptFallback(v) {
+				s.Buffer(v)
+				break
+			}
+		}
+	}
+	for _, v := range s.src.Buffers {
+		s.Buffer(v)
+		if s.bin != nil && v != nil && !meshoptFallback(v) {
+			// BIN chunk keep buffer order
+			if _, err = s.bin.offset(v); err != nil {
+				return nil, errors.WithMessage(err, "glTF.Buffers")
+			}
+		}
+	}
+	for _, v := range s.src.BufferViews {
+		s.BufferView(v)
+	}
+	for _, v := range s.src.Cameras {
+		s.Camera(v)
+	}
+	for _, v := range s.src.Images {
+		s.Image(v)
+	}
+	for _, v := range s.src.Materials {
+		s.Material(v)
+	}
+	for _, v := range s.src.Meshes {
+		s.Mesh(v)
+	}
+	for _, v := range s.src.Nodes {
+		s.Node(v)
+	}
+	for _, v := range s.src.Samplers {
+		s.Sampler(v)
+	}
+	for _, v := range s.src.Scenes {
+		s.Scene(v)
+	}
+	for _, v := range s.src.Textures {
+		s.Texture(v)
+	}
+	for _, v := range s.src.Animations {
+		if v != nil {
+			s.animations.id(v)
+		}
+	}
+	for _, v := range s.src.Skins {
+		s.Skin(v)
+	}
+	//
+	if s.dst.Asset, err = s.encodeAsset(&s.src.Asset); err != nil {
+		return nil, errors.WithMessage(err, "glTF.Asset")
+	}
+	s.dst.Scene = s.Scene(s.src.Scene)
+	if s.dst.Extensions, err = s.Extensions(s.src.Extensions); err != nil {
+		return nil, errors.WithMessage(err, "glTF")
+	}
+	s.dst.Extras = s.src.Extras
+	// writing object can give glTFid to new object, repeat until nothing left
+	steps := []struct {
+		name  string
+		index *encodeIndex
+		write func(i int, item interface{}) error
+	}{
+		{"Accessors", &s.accessors, func(i int, item interface{}) error {
+			res, err := s.encodeAccessor(item.(*Accessor))
+			if err == nil {
+				s.dst.Accessors = append(s.dst.Accessors, *res)
+			}
+			return err
+		}},
+		{"Buffers", &s.buffers, func(i int, item interface{}) error {
+			res, err := s.encodeBuffer(i, item.(*Buffer))
+			if err == nil {
+				s.dst.Buffers = append(s.dst.Buffers, *res)
+			}
+			return err
+		}},
+		{"BufferViews", &s.bufferViews, func(i int, item interface{}) error {
+			res, err := s.encodeBufferView(item.(*BufferView))
+			if err == nil {
+				s.dst.BufferViews = append(s.dst.BufferViews, *res)
+			}
+			return err
+		}},
+		{"Cameras", &s.cameras, func(i int, item interface{}) error {
+			res, err := s.encodeCamera(item.(*Camera))
+			if err == nil {
+				s.dst.Cameras = append(s.dst.Cameras, *res)
+			}
+			return err
+		}},
+		{"Images", &s.images, func(i int, item interface{}) error {
+			res, err := s.encodeImage(i, item.(Image))
+			if err == nil {
+				s.dst.Images = append(s.dst.Images, *res)
+			}
+			return err
+		}},
+		{"Materials", &s.materials, func(i int, item interface{}) error {
+			res, err := s.encodeMaterial(item.(*Material))
+			if err == nil {
+				s.dst.Materials = append(s.dst.Materials, *res)
+			}
+			return err
+		}},
+		{"Meshes", &s.meshes, func(i int, item interface{}) error {
+			res, err := s.encodeMesh(item.(*Mesh))
+			if err == nil {
+				s.dst.Meshes = append(s.dst.Meshes, *res)
+			}
+			return err
+		}},
+		{"Nodes", &s.nodes, func(i int, item interface{}) error {
+			res, err := s.encodeNode(item.(*Node))
+			if err == nil {
+				s.dst.Nodes = append(s.dst.Nodes, *res)
+			}
+			return err
+		}},
+		{"Samplers", &s.samplers, func(i int, item interface{}) error {
+			res, err := s.encodeSampler(item.(*Sampler))
+			if err == nil {
+				s.dst.Samplers = append(s.dst.Samplers, *res)
+			}
+			return err
+		}},
+		{"Scenes", &s.scenes, func(i int, item interface{}) error {
+			res, err := s.encodeScene(item.(*Scene))
+			if err == nil {
+				s.dst.Scenes = append(s.dst.Scenes, *res)
+			}
+			return err
+		}},
+		{"Textures", &s.textures, func(i int, item interface{}) error {
+			res, err := s.encodeTexture(item.(*Texture))
+			if err == nil {
+				s.dst.Textures = append(s.dst.Textures, *res)
+			}
+			return err
+		}},
+		{"Animations", &s.animations, func(i int, item interface{}) error {
+			res, err := s.encodeAnimation(item.(*Animation))
+			if err == nil {
+				s.dst.Animations = append(s.dst.Animations, *res)
+			}
+			return err
+		}},
+		{"Skins", &s.skins, func(i int, item interface{}) error {
+			res, err := s.encodeSkin(item.(*Skin))
+			if err == nil {
+				s.dst.Skins = append(s.dst.Skins, *res)
+			}
+			return err
+		}},
+	}
+	for remain := true; remain; {
+		remain = false
+		for _, step := range steps {
+			for ; step.index.done < len(step.index.items); step.index.done++ {
+				remain = true
+				if err := step.write(step.index.done, step.index.items[step.index.done]); err != nil {
+					return nil, errors.WithMessage(err, fmt.Sprintf("glTF.%s[%d]", step.name, step.index.done))
+				}
+			}
+		}
+	}
+	// extension required, only written extension is kept, ex) KHR_draco_mesh_compression after UnpackDraco
+	for _, v := range s.src.ExtensionsRequired {
+		if s.used[v] {
+			s.dst.ExtensionsRequired = append(s.dst.ExtensionsRequired, v)
+		}
+	}
+	// extension used
+	for _, v := range s.src.ExtensionsUsed {
+		s.used[v] = true
+	}
+	if s.bin != nil && len(s.dst.Buffers) > 0 {
+		s.dst.Buffers[0].ByteLength = intPtr(len(s.bin.data))
+	}
+	for k := range s.used {
+		s.dst.ExtensionsUsed = append(s.dst.ExtensionsUsed, k)
+	}
+	sort.Strings(s.dst.ExtensionsUsed)
+	// RawExtension is written as it is, its buffer glTFid and offset is not updated
+	if s.bin != nil && len(s.src.Buffers) > 1 && len(s.raws) > 0 {
+		names := make([]string, 0, len(s.raws))
+		for k := range s.raws {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		s.ref.logger.Printf("Warning : buffers are merged to glb BIN chunk, RawExtension %v may refer wrong buffer\n", names)
+	}
+	return s.dst, nil
+}
+
+func (s *encoderContext) encodeAsset(asset *Asset) (res *SpecAsset, err error) {
+	res = &SpecAsset{
+		Copyright:  optString(asset.Copyright),
+		Generator:  optString(asset.Generator),
+		MinVersion: asset.MinVersion,
+		Extras:     asset.Extras,
+	}
+	version := asset.Version
+	res.Version = &version
+	if res.Extensions, err = s.Extensions(asset.Extensions); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *encoderContext) encodeAccessor(accessor *Accessor) (res *SpecAccessor, err error) {
+	res = &SpecAccessor{
+		BufferView: s.BufferView(accessor.BufferView),
+		Normalized: optBool(accessor.Normalized, false),
+		Count:      intPtr(accessor.Count),
+		Max:        accessor.Max,
+		Min:        accessor.Min,
+		Name:       optString(accessor.Name),
+		Extras:     accessor.Extras,
+	}
+	if accessor.BufferView != nil {
+		res.ByteOffset = optInt(accessor.ByteOffset, 0)
+	}
+	componentType := accessor.ComponentType
+	res.ComponentType = &componentType
+	accessorType := accessor.Type
+	res.Type = &accessorType
+	if accessor.Sparse != nil {
+		if res.Sparse, err = s.encodeAccessorSparse(accessor.Sparse); err != nil {
+			return nil, err
+		}
+	}
+	if res.Extensions, err = s.Extensions(accessor.Extensions); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *encoderContext) encodeAccessorSparse(sparse *AccessorSparse) (res *SpecAccessorSparse, err error) {
+	if sparse.Indices == nil || sparse.Values == nil {
+		return nil, errors.New("AccessorSparse.Indices, AccessorSparse.Values required")
+	}
+	componentType := sparse.Indices.ComponentType
+	res = &SpecAccessorSparse{
+		Count: intPtr(sparse.Count),
+		Indices: &SpecAccessorSparseIndices{
+			BufferView:    s.BufferView(sparse.Indices.BufferView),
+			ByteOffset:    optInt(sparse.Indices.ByteOffset, 0),
+			ComponentType: &componentType,
+			Extras:        sparse.Indices.Extras,
+		},
+		Values: &SpecAccessorSparseValues{
+			BufferView: s.BufferView(sparse.Values.BufferView),
+			ByteOffset: optInt(sparse.Values.ByteOffset, 0),
+			Extras:     sparse.Values.Extras,
+		},
+		Extras: sparse.Extras,
+	}
+	if res.Indices.Extensions, err = s.Extensions(sparse.Indices.Extensions); err != nil {
+		return nil, err
+	}
+	if res.Values.Extensions, err = s.Extensions(sparse.Values.Extensions); err != nil {
+		return nil, err
+	}
+	if res.Extensions, err = s.Extensions(sparse.Extensions); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *encoderContext) encodeBuffer(i int, buffer *Buffer) (res *SpecBuffer, err error) {
+	if meshoptFallback(buffer) {
+		// no data, only byteLength is written
+		res = &SpecBuffer{
+			ByteLength: intPtr(*buffer.ByteLength),
+			Name:       optString(buffer.Name),
+			Extras:     buffer.Extras,
+		}
+		if res.Extensions, err = s.Extensions(buffer.Extensions); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+	if s.bin != nil {
+		// BIN chunk, byteLength is decided after every bufferView, image is written
+		res = &SpecBuffer{}
+		if len(s.src.Buffers) == 1 && s.src.Buffers[0] != nil {
+			res.Name = optString(s.src.Buffers[0].Name)
+			res.Extras = s.src.Buffers[0].Extras
+		}
+		return res, nil
+	}
+	res = &SpecBuffer{
+		Name:   optString(buffer.Name),
+		Extras: buffer.Extras,
+	}
+	if res.Extensions, err = s.Extensions(buffer.Extensions); err != nil {
+		return nil, err
+	}
+	if buffer.URI != nil && !buffer.unavailableURI && (isRemoteURI(buffer.URI) || s.keepURI()) {
+		res.URI = buffer.URI
+		if buffer.ByteLength != nil {
+			res.ByteLength = intPtr(*buffer.ByteLength)
+			return res, nil
+		}
+	}
+	bts, err := buffer.Load(false)
+	if err != nil {
+		return nil, err
+	}
+	res.ByteLength = intPtr(len(bts))
+	if res.URI != nil {
+		return res, nil
+	}
+	if res.URI, err = s.resource(buffer.URI, fmt.Sprintf("buffer%d.bin", i), "application/octet-stream", bts); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// bufferOffset is where buffer start in written buffer, it is not 0 only for glb
+func (s *encoderContext) bufferOffset(buffer *Buffer) (int, error) {
+	if s.bin == nil || buffer == nil || meshoptFallback(buffer) {
+		return 0, nil
+	}
+	return s.bin.offset(buffer)
+}
+func (s *encoderContext) encodeBufferView(bufferView *BufferView) (res *SpecBufferView, err error) {
+	offset, err := s.bufferOffset(bufferView.Buffer)
+	if err != nil {
+		return nil, err
+	}
+	offset += bufferView.ByteOffset
+	res = &SpecBufferView{
+		Buffer:     s.Buffer(bufferView.Buffer),
+		ByteOffset: optInt(offset, 0),
+		ByteLength: intPtr(bufferView.ByteLength),
+		ByteStride: optInt(bufferView.ByteStride, 0),
+		Name:       optString(bufferView.Name),
+		Extras:     bufferView.Extras,
+	}
+	if bufferView.Target != NEED_TO_DEFINE_BUFFER {
+		target := bufferView.Target
+		res.Target = &target
+	}
+	if res.Extensions, err = s.Extensions(bufferView.Extensions); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *encoderContext) encodeCamera(camera *Camera) (res *SpecCamera, err error) {
+	res = &SpecCamera{
+		Extras: camera.Extras,
+	}
+	if res.Extensions, err = s.Extensions(camera.Extensions); err != nil {
+		return nil, err
+	}
+	var cameraType CameraType
+	switch setting := camera.Setting.(type) {
+	case *OrthographicCamera:
+		cameraType = Orthographic
+		res.Orthographic = &SpecCameraOrthographic{
+			Xmag:   float32Ptr(setting.Xmag),
+			Ymag:   float32Ptr(setting.Ymag),
+			Znear:  float32Ptr(setting.Znear),
+			Zfar:   float32Ptr(setting.Zfar),
+			Extras: setting.Extras,
+		}
+		if res.Orthographic.Extensions, err = s.Extensions(setting.Extensions); err != nil {
+			return nil, err
+		}
+	case *PerspectiveCamera:
+		cameraType = Perspective
+		res.Perspective = &SpecCameraPerspective{
+			Yfov:   float32Ptr(setting.Yfov),
+			Znear:  float32Ptr(setting.Znear),
+			Extras: setting.Extras,
+		}
+		if setting.AspectRatio != nil {
+			res.Perspective.AspectRatio = float32Ptr(*setting.AspectRatio)
+		}
+		// infinite projection
+		if !math.IsInf(float64(setting.Zfar), 1) {
+			res.Perspective.Zfar = float32Ptr(setting.Zfar)
+		}
+		if res.Perspective.Extensions, err = s.Extensions(setting.Extensions); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.Errorf("Camera.Setting '%T' not supported", camera.Setting)
+	}
+	res.Type = &cameraType
+	return res, nil
+}
+func (s *encoderContext) encodeImage(i int, img Image) (res *SpecImage, err error) {
+	res = &SpecImage{
+		Name:   optString(img.Name()),
+		Extras: img.Extras(),
+	}
+	if res.Extensions, err = s.Extensions(img.GetExtension()); err != nil {
+		return nil, err
+	}
+	switch src := img.(type) {
+	case *BufferImage:
+		mime := src.Mime
+		res.MimeType = &mime
+		res.BufferView = s.BufferView(src.BufferView)
+	case *URIImage:
+		if s.bin != nil {
+			data, err := src.LoadRaw()
+			if err != nil {
+				return nil, err
+			}
+			mime, err := detectMimeType(data)
+			if err != nil {
+				return nil, err
+			}
+			res.MimeType = &mime
+			res.BufferView = s.BufferView(&BufferView{
+				Buffer:     s.bin.buffer,
+				ByteOffset: s.bin.append(data),
+				ByteLength: len(data),
+			})
+			return res, nil
+		}
+		if src.URI != nil && (isRemoteURI(src.URI) || s.keepURI()) {
+			res.URI = src.URI
+			return res, nil
+		}
+		data, err := src.LoadRaw()
+		if err != nil {
+			return nil, err
+		}
+		mime, err := detectMimeType(data)
+		if err != nil {
+			return nil, err
+		}
+		if res.URI, err = s.resource(src.URI, fmt.Sprintf("image%d.%s", i, mime.Format()), mime.String(), data); err != nil {
+			return nil, err
+		}
+		res.MimeType = &mime
+	default:
+		return nil, errors.Errorf("Image '%T' not supported", img)
+	}
+	return res, nil
+}
+func (s *encoderContext) encodeMaterial(material *Material) (res *SpecMaterial, err error) {
+	res = &SpecMaterial{
+		Name:        optString(material.Name),
+		Extras:      material.Extras,
+		AlphaCutoff: optFloat32(material.AlphaCutoff, 0.5),
+		DoubleSided: optBool(material.DoubleSided, false),
+	}
+	if res.Extensions, err = s.Extensions(material.Extensions); err != nil {
+		return nil, err
+	}
+	if pbr := material.PBRMetallicRoughness; pbr != nil {
+		res.PBRMetallicRoughness = &SpecMaterialPBRMetallicRoughness{
+			MetallicFactor:  optFloat32(pbr.MetallicFactor, 1),
+			RoughnessFactor: optFloat32(pbr.RoughnessFactor, 1),
+			Extras:          pbr.Extras,
+		}
+		if pbr.BaseColorFactor != (mgl32.Vec4{1, 1, 1, 1}) {
+			factor := pbr.BaseColorFactor
+			res.PBRMetallicRoughness.BaseColorFactor = &factor
+		}
+		if res.PBRMetallicRoughness.BaseColorTexture, err = s.TextureInfo(pbr.BaseColorTexture); err != nil {
+			return nil, err
+		}
+		if res.PBRMetallicRoughness.MetallicRoughnessTexture, err = s.TextureInfo(pbr.MetallicRoughnessTexture); err != nil {
+			return nil, err
+		}
+		if res.PBRMetallicRoughness.Extensions, err = s.Extensions(pbr.Extensions); err != nil {
+			return nil, err
+		}
+	}
+	if res.NormalTexture, err = s.NormalTextureInfo(material.NormalTexture); err != nil {
+		return nil, err
+	}
+	if res.OcclusionTexture, err = s.OcclusionTextureInfo(material.OcclusionTexture); err != nil {
+		return nil, err
+	}
+	if material.EmissiveFactor != (mgl32.Vec3{}) {
+		factor := material.EmissiveFactor
+		res.EmissiveFactor = &factor
+	}
+	if res.EmissiveTexture, err = s.TextureInfo(material.EmissiveTexture); err != nil {
+		return nil, err
+	}
+	if material.AlphaMode != OPAQUE {
+		mode := material.AlphaMode
+		res.AlphaMode = &mode
+	}
+	return res, nil
+}
+func (s *encoderContext) encodeMesh(mesh *Mesh) (res *SpecMesh, err error) {
+	res = &SpecMesh{
+		Weights: mesh.Weights,
+		Name:    optString(mesh.Name),
+		Extras:  mesh.Extras,
+	}
+	if res.Extensions, err = s.Extensions(mesh.Extensions); err != nil {
+		return nil, err
+	}
+	for i, primitive := range mesh.Primitives {
+		p, err := s.encodeMeshPrimitive(primitive)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("Mesh.Primitives[%d]", i))
+		}
+		res.Primitives = append(res.Primitives, *p)
+	}
+	return res, nil
+}
+func (s *encoderContext) encodeMeshPrimitive(primitive *MeshPrimitive) (res *SpecMeshPrimitive, err error) {
+	res = &SpecMeshPrimitive{
+		Attributes: s.attributes(primitive.Attributes),
+		Indices:    s.Accessor(primitive.Indices),
+		Material:   s.Material(variantDefaultMaterial(primitive)),
+		Extras:     primitive.Extras,
+	}
+	if primitive.Mode != TRIANGLES {
+		mode := primitive.Mode
+		res.Mode = &mode
+	}
+	for _, target := range primitive.Targets {
+		res.Targets = append(res.Targets, s.attributes(target))
+	}
+	if res.Extensions, err = s.Extensions(primitive.Extensions); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// attributes give glTFid in order of attribute name, for stable output
+func (s *encoderContext) attributes(attrs map[AttributeKey]*Accessor) map[AttributeKey]SpecGLTFID {
+	keys := make([]string, 0, len(attrs))
+	for k, v := range attrs {
+		if v != nil {
+			keys = append(keys, string(k))
+		}
+	}
+	sort.Strings(keys)
+	res := make(map[AttributeKey]SpecGLTFID, len(keys))
+	for _, k := range keys {
+		res[AttributeKey(k)] = *s.Accessor(attrs[AttributeKey(k)])
+	}
+	return res
+}
+func (s *encoderContext) encodeNode(node *Node) (res *SpecNode, err error) {
+	res = &SpecNode{
+		Camera:  s.Camera(node.Camera),
+		Skin:    s.Skin(node.Skin),
+		Weights: node.Weights,
+		Mesh:    s.Mesh(node.Mesh),
+		Name:    optString(node.Name),
+		Extras:  node.Extras,
+	}
+	for _, child := range node.Children {
+		if child != nil {
+			res.Children = append(res.Children, *s.Node(child))
+		}
+	}
+	// matrix and TRS are exclusive, Node.Transform use matrix first
+	if node.Matrix != mgl32.Ident4() {
+		matrix := node.Matrix
+		res.Matrix = &matrix
+	} else {
+		if node.Translation != (mgl32.Vec3{0, 0, 0}) {
+			translation := node.Translation
+			res.Translation = &translation
+		}
+		if node.Rotation != mgl32.QuatIdent() {
+			// unit quaternion (x, y, z, w)
+			rotation := node.Rotation.V.Vec4(node.Rotation.W)
+			res.Rotation = &rotation
+		}
+		if node.Scale != (mgl32.Vec3{1, 1, 1}) {
+			scale := node.Scale
+			res.Scale = &scale
+		}
+	}
+	if res.Extensions, err = s.Extensions(node.Extensions); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *encoderContext) encodeSampler(sampler *Sampler) (res *SpecSampler, err error) {
+	res = &SpecSampler{
+		Name:   optString(sampler.Name),
+		Extras: sampler.Extras,
+	}
+	if sampler.MagFilter != MAG_LINEAR {
+		filter := sampler.MagFilter
+		res.MagFilter = &filter
+	}
+	if sampler.MinFilter != MIN_NEAREST_MIPMAP_LINEAR {
+		filter := sampler.MinFilter
+		res.MinFilter = &filter
+	}
+	if sampler.WrapS != REPEAT {
+		wrap := sampler.WrapS
+		res.WrapS = &wrap
+	}
+	if sampler.WrapT != REPEAT {
+		wrap := sampler.WrapT
+		res.WrapT = &wrap
+	}
+	if res.Extensions, err = s.Extensions(sampler.Extensions); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *encoderContext) encodeScene(scene *Scene) (res *SpecScene, err error) {
+	res = &SpecScene{
+		Name:   optString(scene.Name),
+		Extras: scene.Extras,
+	}
+	for _, node := range scene.Nodes {
+		if node != nil {
+			res.Nodes = append(res.Nodes, *s.Node(node))
+		}
+	}
+	if res.Extensions, err = s.Extensions(scene.Extensions); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *encoderContext) encodeTexture(texture *Texture) (res *SpecTexture, err error) {
+	res = &SpecTexture{
+		Source: s.Image(texture.Source),
+		Name:   optString(texture.Name),
+		Extras: texture.Extras,
+	}
+	// undefined sampler is linked to DefaultSampler
+	if texture.Sampler != nil && (s.samplers.has(texture.Sampler) || *texture.Sampler != *DefaultSampler()) {
+		res.Sampler = s.Sampler(texture.Sampler)
+	}
+	if res.Extensions, err = s.Extensions(texture.Extensions); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *encoderContext) encodeAnimation(animation *Animation) (res *SpecAnimation, err error) {
+	res = &SpecAnimation{
+		Name:   optString(animation.Name),
+		Extras: animation.Extras,
+	}
+	if res.Extensions, err = s.Extensions(animation.Extensions); err != nil {
+		return nil, err
+	}
+	// AnimationChannel.Sampler is index of Animation.Samplers
+	samplers := make(map[*AnimationSampler]SpecGLTFID, len(animation.Samplers))
+	for i, sampler := range animation.Samplers {
+		samplers[sampler] = SpecGLTFID(i)
+		res.Samplers = append(res.Samplers, SpecAnimationSampler{
+			Input:  s.Accessor(sampler.Input),
+			Output: s.Accessor(sampler.Output),
+			Extras: sampler.Extras,
+		})
+		if sampler.Interpolation != LINEAR {
+			interpolation := sampler.Interpolation
+			res.Samplers[i].Interpolation = &interpolation
+		}
+		if res.Samplers[i].Extensions, err = s.Extensions(sampler.Extensions); err != nil {
+			return nil, err
+		}
+	}
+	for i, channel := range animation.Channels {
+		id, ok := samplers[channel.Sampler]
+		if !ok {
+			return nil, errors.Errorf("Animation.Channels[%d].Sampler not in Animation.Samplers", i)
+		}
+		res.Channels = append(res.Channels, SpecAnimationChannel{
+			Sampler: &id,
+			Extras:  channel.Extras,
+		})
+		if res.Channels[i].Extensions, err = s.Extensions(channel.Extensions); err != nil {
+			return nil, err
+		}
+		if target := channel.Target; target != nil {
+			path := target.Path
+			res.Channels[i].Target = &SpecAnimationChannelTarget{
+				Node:   s.Node(target.Node),
+				Path:   &path,
+				Extras: target.Extras,
+			}
+			if res.Channels[i].Target.Extensions, err = s.Extensions(target.Extensions); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return res, nil
+}
+func (s *encoderContext) encodeSkin(skin *Skin) (res *SpecSkin, err error) {
+	res = &SpecSkin{
+		InverseBindMatrices: s.Accessor(skin.InverseBindMatrices),
+		Skeleton:            s.Node(skin.Skeleton),
+		Name:                optString(skin.Name),
+		Extras:              skin.Extras,
+	}
+	for _, joint := range skin.Joints {
+		if joint != nil {
+			res.Joints = append(res.Joints, *s.Node(joint))
+		}
+	}
+	if res.Extensions, err = s.Extensions(skin.Extensions); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// keepURI report there is no place to write data, so URI must be written as it is
+func (s *encoderContext) keepURI() bool {
+	return !s.ref.dataURI && s.ref.sideFile == nil
+}
+
+// resource write data as data URI or side file, return URI for it
+func (s *encoderContext) resource(origin *URI, name string, mime string, data []byte) (*URI, error) {
+	if s.ref.dataURI {
+		return newDataURI(mime, data), nil
+	}
+	if s.ref.sideFile == nil {
+		if origin != nil {
+			name = uriLabel(origin)
+		}
+		return nil, errors.Errorf("'%s' need Encoder().Directory, SideFile or DataURI", name)
+	}
+	// relative file keep its name
+	if origin != nil && origin.Scheme == "" {
+		name = relativeURIPath(origin)
+	}
+	name = s.sideFileName(name)
+	if err := s.ref.sideFile(name, data); err != nil {
+		return nil, err
+	}
+	s.ref.logger.Printf("side file '%s' written, %d bytes\n", name, len(data))
+	return &URI{Path: name}, nil
+}
+
+// sideFileName avoid name collision between side files
+func (s *encoderContext) sideFileName(name string) string {
+	res := name
+	ext := path.Ext(name)
+	for i := 1; s.files[res]; i++ {
+		res = fmt.Sprintf("%s_%d%s", name[:len(name)-len(ext)], i, ext)
+	}
+	s.files[res] = true
+	return res
+}
+
+// glbBuilder consolidate every buffer into one BIN chunk
+type glbBuilder struct {
+	// placeholder of BIN chunk buffer
+	buffer *Buffer
+	data   []byte
+	// where each buffer start in BIN chunk
+	offsets map[*Buffer]int
+}
+
+// append data at 4 bytes boundary, return offset of data
+func (s *glbBuilder) append(data []byte) int {
+	s.data = append(s.data, make([]byte, glbPadding(len(s.data)))...)
+	offset := len(s.data)
+	s.data = append(s.data, data...)
+	return offset
+}
+
+// offset return where buffer start in BIN chunk, buffer is appended when it is first reached
+func (s *glbBuilder) offset(buffer *Buffer) (int, error) {
+	if buffer == s.buffer {
+		return 0, nil
+	}
+	if offset, ok := s.offsets[buffer]; ok {
+		return offset, nil
+	}
+	bts, err := buffer.Load(false)
+	if err != nil {
+		return 0, err
+	}
+	offset := s.append(bts)
+	s.offsets[buffer] = offset
+	return offset, nil
+}
+
+// chunk is nil when there is no buffer
+func (s *glbBuilder) chunk() []byte {
+	if s == nil || (len(s.offsets) == 0 && s.data == nil) {
+		return nil
+	}
+	if s.data == nil {
+		return []byte{}
+	}
+	return s.data
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+func float32Ptr(v float32) *float32 {
+	return &v
+}
+func optInt(v, def int) *int {
+	if v == def {
+		return nil
+	}
+	return &v
+}
+func optFloat32(v, def float32) *float32 {
+	if v == def {
+		return nil
+	}
+	return &v
+}
+func optBool(v, def bool) *bool {
+	if v == def {
+		return nil
+	}
+	return &v
+}
+func optString(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+func optTexCoord(v IndexTexCoord) *IndexTexCoord {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
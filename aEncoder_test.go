@@ -0,0 +1,281 @@
+package gltf2
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+var (
+	encodeTestPositions = []mgl32.Vec3{{0, 0, 0}, {1, 0, 0}, {0, 1, 0.5}}
+	encodeTestIndices   = []uint32{0, 1, 2}
+)
+
+// encodeTestBuffer is 4 byte padding, positions at 4, indices at 40
+func encodeTestBuffer() []byte {
+	var b bytes.Buffer
+	b.Write(make([]byte, 4))
+	binary.Write(&b, binary.LittleEndian, encodeTestPositions)
+	for _, v := range encodeTestIndices {
+		binary.Write(&b, binary.LittleEndian, uint16(v))
+	}
+	b.Write(make([]byte, 2))
+	return b.Bytes()
+}
+
+func encodeTestPNG(t *testing.T) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.RGBA{255, 0, 0, 255})
+	img.Set(1, 0, color.RGBA{0, 255, 0, 255})
+	img.Set(0, 1, color.RGBA{0, 0, 255, 255})
+	img.Set(1, 1, color.RGBA{255, 255, 255, 128})
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+// encodeTestJSON is glTF document, buffer and image are replaced to each input form
+func encodeTestJSON(buffer, img string, extra string) string {
+	return fmt.Sprintf(`{
+	"asset":{"version":"2.0"},
+	"scene":0,
+	"scenes":[{"nodes":[0]}],
+	"nodes":[{"mesh":0}],
+	"meshes":[{"primitives":[{"attributes":{"POSITION":0},"indices":1,"material":0}]}],
+	"materials":[{"pbrMetallicRoughness":{"baseColorTexture":{"index":0}}}],
+	"textures":[{"source":0}],
+	"images":[%s],
+	"buffers":[%s],
+	"bufferViews":[
+		{"buffer":0,"byteOffset":4,"byteLength":36,"target":34962},
+		{"buffer":0,"byteOffset":40,"byteLength":6,"target":34963}%s
+	],
+	"accessors":[
+		{"bufferView":0,"componentType":5126,"count":3,"type":"VEC3","min":[0,0,0],"max":[1,1,0.5]},
+		{"bufferView":1,"componentType":5123,"count":3,"type":"SCALAR"}
+	]
+}`, img, buffer, extra)
+}
+
+// encodeTestInputs is same scene from external files, data URIs and glb
+func encodeTestInputs(t *testing.T) map[string]func() (*GLTF, error) {
+	bin, pngData := encodeTestBuffer(), encodeTestPNG(t)
+	external := fstest.MapFS{
+		"data.bin": {Data: bin},
+		"a.png":    {Data: pngData},
+	}
+	gltfJSON := encodeTestJSON(
+		fmt.Sprintf(`{"uri":"data.bin","byteLength":%d}`, len(bin)),
+		`{"uri":"a.png"}`,
+		"",
+	)
+	dataJSON := encodeTestJSON(
+		fmt.Sprintf(`{"uri":"data:application/octet-stream;base64,%s","byteLength":%d}`, base64.StdEncoding.EncodeToString(bin), len(bin)),
+		fmt.Sprintf(`{"uri":"data:image/png;base64,%s"}`, base64.StdEncoding.EncodeToString(pngData)),
+		"",
+	)
+	glbBin := append(append([]byte{}, bin...), pngData...)
+	glbJSON := encodeTestJSON(
+		fmt.Sprintf(`{"byteLength":%d}`, len(glbBin)),
+		`{"bufferView":2,"mimeType":"image/png"}`,
+		fmt.Sprintf(`,{"buffer":0,"byteOffset":%d,"byteLength":%d}`, len(bin), len(pngData)),
+	)
+	return map[string]func() (*GLTF, error){
+		"gltf": func() (*GLTF, error) {
+			return Parser().Reader(strings.NewReader(gltfJSON)).FileSystem(external).Parse()
+		},
+		"data-uri": func() (*GLTF, error) {
+			return Parser().Reader(strings.NewReader(dataJSON)).Parse()
+		},
+		"glb": func() (*GLTF, error) {
+			return Parser().Reader(bytes.NewReader(encodeTestGLB(glbJSON, glbBin))).Parse()
+		},
+	}
+}
+
+func encodeTestGLB(js string, bin []byte) []byte {
+	for len(js)%4 != 0 {
+		js += " "
+	}
+	for len(bin)%4 != 0 {
+		bin = append(bin, 0)
+	}
+	var b bytes.Buffer
+	binary.Write(&b, binary.LittleEndian, []uint32{glbMagic, 2, uint32(12 + 8 + len(js) + 8 + len(bin))})
+	binary.Write(&b, binary.LittleEndian, []uint32{uint32(len(js)), glbChunkTypeJSON})
+	b.WriteString(js)
+	binary.Write(&b, binary.LittleEndian, []uint32{uint32(len(bin)), glbChunkTypeBIN})
+	b.Write(bin)
+	return b.Bytes()
+}
+
+// encodeTestOutputs encode gltf and parse it again
+var encodeTestOutputs = map[string]func(g *GLTF) (*GLTF, error){
+	"gltf": func(g *GLTF) (*GLTF, error) {
+		var (
+			out   bytes.Buffer
+			files = fstest.MapFS{}
+		)
+		err := Encoder(&out).SideFile(func(name string, data []byte) error {
+			files[name] = &fstest.MapFile{Data: data}
+			return nil
+		}).Encode(g)
+		if err != nil {
+			return nil, err
+		}
+		return Parser().Reader(&out).FileSystem(fs.FS(files)).Parse()
+	},
+	"data-uri": func(g *GLTF) (*GLTF, error) {
+		var out bytes.Buffer
+		if err := Encoder(&out).DataURI().Encode(g); err != nil {
+			return nil, err
+		}
+		if bytes.Contains(out.Bytes(), []byte(`.bin"`)) || bytes.Contains(out.Bytes(), []byte(`.png"`)) {
+			return nil, fmt.Errorf("side file is referenced in data URI output")
+		}
+		return Parser().Reader(&out).Parse()
+	},
+	"glb": func(g *GLTF) (*GLTF, error) {
+		var out bytes.Buffer
+		if err := Encoder(&out).GLB().Encode(g); err != nil {
+			return nil, err
+		}
+		if out.Len()%4 != 0 {
+			return nil, fmt.Errorf("glb length %d is not aligned to 4", out.Len())
+		}
+		return Parser().Reader(&out).Parse()
+	},
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	for inName, input := range encodeTestInputs(t) {
+		for outName, output := range encodeTestOutputs {
+			t.Run(inName+"->"+outName, func(t *testing.T) {
+				src, err := input()
+				if err != nil {
+					t.Fatal(err)
+				}
+				dst, err := output(src)
+				if err != nil {
+					t.Fatal(err)
+				}
+				encodeTestCompare(t, src, dst, outName == "glb", 0)
+			})
+		}
+	}
+}
+
+// encodeTestCompare check dst is same as src, bufferView of dst is moved by offset
+func encodeTestCompare(t *testing.T, src, dst *GLTF, glb bool, offset int) {
+	if glb && len(dst.Buffers) != 1 {
+		t.Fatalf("glb must have 1 buffer, but got %d", len(dst.Buffers))
+	}
+	// accessor data
+	prim := dst.Meshes[0].Primitives[0]
+	positions, err := ReadVec3f(prim.Attributes[POSITION])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(positions) != fmt.Sprint(encodeTestPositions) {
+		t.Errorf("positions %v, expected %v", positions, encodeTestPositions)
+	}
+	indices, err := ReadIndices(prim.Indices)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(indices) != fmt.Sprint(encodeTestIndices) {
+		t.Errorf("indices %v, expected %v", indices, encodeTestIndices)
+	}
+	// bufferView offsets
+	for i := 0; i < 2; i++ {
+		if expected := src.BufferViews[i].ByteOffset + offset; dst.BufferViews[i].ByteOffset != expected {
+			t.Errorf("BufferViews[%d].ByteOffset %d, expected %d", i, dst.BufferViews[i].ByteOffset, expected)
+		}
+		if dst.BufferViews[i].ByteLength != src.BufferViews[i].ByteLength {
+			t.Errorf("BufferViews[%d].ByteLength %d, expected %d", i, dst.BufferViews[i].ByteLength, src.BufferViews[i].ByteLength)
+		}
+	}
+	// image
+	expected, err := src.Images[0].Load(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := dst.Images[0].Load(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Rect != expected.Rect || !bytes.Equal(got.Pix, expected.Pix) {
+		t.Errorf("image %v %v, expected %v %v", got.Rect, got.Pix, expected.Rect, expected.Pix)
+	}
+	if dst.Materials[0].PBRMetallicRoughness.BaseColorTexture.Index.Source != dst.Images[0] {
+		t.Error("texture source is not linked to image")
+	}
+}
+
+func TestEncodeExtensionsRequired(t *testing.T) {
+	src := `{
+	"asset":{"version":"2.0"},
+	"materials":[{"extensions":{"KHR_materials_unlit":{}}}, {}],
+	"extensionsUsed":["KHR_materials_unlit"],
+	"extensionsRequired":["KHR_materials_unlit"]
+}`
+	encode := func(g *GLTF) *SpecGLTF {
+		var out bytes.Buffer
+		if err := Encoder(&out).Encode(g); err != nil {
+			t.Fatal(err)
+		}
+		res := new(SpecGLTF)
+		if err := json.Unmarshal(out.Bytes(), res); err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+	g, err := Parser().Reader(strings.NewReader(src)).Extensions(new(KHRMaterialsUnlit)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := encode(g); fmt.Sprint(res.ExtensionsRequired) != "[KHR_materials_unlit]" {
+		t.Errorf("extensionsRequired %v, expected [KHR_materials_unlit]", res.ExtensionsRequired)
+	}
+	// extension is not written anymore
+	g.Materials[0].Extensions = nil
+	if res := encode(g); len(res.ExtensionsRequired) != 0 {
+		t.Errorf("extensionsRequired %v, expected empty", res.ExtensionsRequired)
+	}
+}
+
+func TestEncodeGLBMergeBuffers(t *testing.T) {
+	bin := encodeTestBuffer()
+	src := strings.Replace(encodeTestJSON(
+		fmt.Sprintf(`{"uri":"data:application/octet-stream;base64,AAEC","byteLength":3},{"uri":"data.bin","byteLength":%d}`, len(bin)),
+		`{"uri":"a.png"}`,
+		"",
+	), `"buffer":0`, `"buffer":1`, -1)
+	fsys := fstest.MapFS{
+		"data.bin": {Data: bin},
+		"a.png":    {Data: encodeTestPNG(t)},
+	}
+	g, err := Parser().Reader(strings.NewReader(src)).FileSystem(fsys).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst, err := encodeTestOutputs["glb"](g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// second buffer starts at 4, after 3 bytes of first buffer and padding
+	encodeTestCompare(t, g, dst, true, 4)
+}
@@ -1,9 +1,200 @@
 package gltf2
 
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
 type ExtensionType interface {
 	ExtensionName() string
 	Constructor(src []byte) (Specifier, error)
 }
+
+// ExtensionEncoder is ExtensionType which can be written back to glTF
+//
+// Encode return json marshalable value of extension object
+type ExtensionEncoder interface {
+	ExtensionType
+	Encode(ctx *encoderContext) (interface{}, error)
+}
+
+// ExtensionSchemer is ExtensionType which know objects it can be attached to
+//
+// ExtensionSchemes is SCHEME_* of parent object, empty if extension has no object, ex) KHR_mesh_quantization
+type ExtensionSchemer interface {
+	ExtensionType
+	ExtensionSchemes() []string
+}
+
+// ExtensionCapability is metadata of ExtensionType
+type ExtensionCapability struct {
+	Name string
+	// nil if ExtensionType is not ExtensionSchemer
+	Schemes []string
+	// true if ExtensionType is ExtensionEncoder
+	Writable bool
+}
+
+// Capability is ExtensionCapability of ext
+func Capability(ext ExtensionType) ExtensionCapability {
+	res := ExtensionCapability{Name: ext.ExtensionName()}
+	if sc, ok := ext.(ExtensionSchemer); ok {
+		res.Schemes = sc.ExtensionSchemes()
+	}
+	_, res.Writable = ext.(ExtensionEncoder)
+	return res
+}
+
+// CanAttach is true if extension can be attached to object of scheme, unknown if Schemes is nil so it is true
+//
+// Parser report LEVEL2 issue for extension which can't be attached to its object, and it is not checked more
+func (s ExtensionCapability) CanAttach(scheme string) bool {
+	if s.Schemes == nil {
+		return true
+	}
+	for _, v := range s.Schemes {
+		if v == scheme {
+			return true
+		}
+	}
+	return false
+}
+
+// registry is global extension registry, every built-in extension is registered in its init
+var registry = struct {
+	sync.RWMutex
+	exts map[string]ExtensionType
+}{exts: make(map[string]ExtensionType)}
+
+// RegisterExtension add ext to global registry, it is used by Parser().AllKnownExtensions()
+//
+// Extension of same name is replaced
+func RegisterExtension(ext ExtensionType) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.exts[ext.ExtensionName()] = ext
+}
+
+// KnownExtensions is every extension in global registry, sorted by name
+func KnownExtensions() []ExtensionType {
+	registry.RLock()
+	defer registry.RUnlock()
+	res := make([]ExtensionType, 0, len(registry.exts))
+	for _, v := range registry.exts {
+		res = append(res, v)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ExtensionName() < res[j].ExtensionName() })
+	return res
+}
+
+// KnownExtension is extension of name in global registry, nil if not registered
+func KnownExtension(name string) ExtensionType {
+	registry.RLock()
+	defer registry.RUnlock()
+	return registry.exts[name]
+}
+
+// RawExtension is extension kept as json, it is used for extension which is not registered to Parser
+// or every extension in Parser().RawExtensions()
+//
+// It is written back as it is, ex) raw := node.Extensions.GetByName("VENDOR_ext").(*RawExtension)
+//
+// Data is not linked, glTFid in Data is id of source file. It is invalid if glTFid is changed,
+// ex) Tasks.UnpackDraco remove accessors, Encoder GLB merge buffers
+type RawExtension struct {
+	Name string
+	Data json.RawMessage
+}
+
+func (s *RawExtension) ExtensionName() string {
+	return s.Name
+}
+func (s *RawExtension) Constructor(src []byte) (Specifier, error) {
+	return &SpecRawExtension{
+		name: s.Name,
+		data: append(json.RawMessage(nil), src...),
+	}, nil
+}
+func (s *RawExtension) Encode(ctx *encoderContext) (interface{}, error) {
+	if s.Data == nil {
+		return json.RawMessage("{}"), nil
+	}
+	return s.Data, nil
+}
+
+// Unmarshal decode Data to v
+func (s *RawExtension) Unmarshal(v interface{}) error {
+	return json.Unmarshal(s.Data, v)
+}
+
+type SpecRawExtension struct {
+	name string
+	data json.RawMessage
+}
+
+func (s *SpecRawExtension) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecRawExtension) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	return nil
+}
+func (s *SpecRawExtension) To(ctx *parserContext) interface{} {
+	return &RawExtension{
+		Name: s.name,
+		Data: s.data,
+	}
+}
+
+// specTextureChildren is Parents implementation for extension which children are textureInfo only, ex) KHR_materials_clearcoat
+//
+// Extension build it every call from non nil textureInfo, then delegate Parents methods to it
+type specTextureChildren []specTextureChild
+type specTextureChild struct {
+	pointer string
+	spec    Specifier
+	// field return pointer of runtime field, **TextureInfo or **MaterialNormalTextureInfo
+	field func(dst interface{}) interface{}
+}
+
+func (s specTextureChildren) textureInfo(pointer string, spec *SpecTextureInfo, field func(dst interface{}) interface{}) specTextureChildren {
+	if spec == nil {
+		return s
+	}
+	return append(s, specTextureChild{pointer: pointer, spec: spec, field: field})
+}
+func (s specTextureChildren) normalTextureInfo(pointer string, spec *SpecMaterialNormalTextureInfo, field func(dst interface{}) interface{}) specTextureChildren {
+	if spec == nil {
+		return s
+	}
+	return append(s, specTextureChild{pointer: pointer, spec: spec, field: field})
+}
+func (s specTextureChildren) GetChild(i int) Specifier {
+	return s[i].spec
+}
+func (s specTextureChildren) SetChild(i int, dst, object interface{}) {
+	switch f := s[i].field(dst).(type) {
+	case **TextureInfo:
+		*f = object.(*TextureInfo)
+	case **MaterialNormalTextureInfo:
+		*f = object.(*MaterialNormalTextureInfo)
+	}
+}
+func (s specTextureChildren) ChildPointer(i int) string {
+	return s[i].pointer
+}
+func (s specTextureChildren) LenChild() int {
+	return len(s)
+}
+func (s specTextureChildren) ImpleGetChild(i int, dst interface{}) interface{} {
+	switch f := s[i].field(dst).(type) {
+	case **TextureInfo:
+		return *f
+	case **MaterialNormalTextureInfo:
+		return *f
+	}
+	return nil
+}
 //
 //func (s *ExtensionKey) String() string {
 //	return s.Name
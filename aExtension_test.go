@@ -0,0 +1,71 @@
+package gltf2
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRawExtensionMergeWarning(t *testing.T) {
+	js := `{"asset":{"version":"2.0"},
+	"extensionsUsed":["VENDOR_buffer_ref"],
+	"extensions":{"VENDOR_buffer_ref":{"buffer":1,"byteOffset":0}},
+	"buffers":[
+		{"uri":"data:application/octet-stream;base64,AAEC","byteLength":3},
+		{"uri":"data:application/octet-stream;base64,AwQF","byteLength":3}
+	]}`
+	g, err := Parser().Reader(strings.NewReader(js)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := g.Extensions.GetByName("VENDOR_buffer_ref").(*RawExtension); !ok {
+		t.Fatal("unknown extension is not RawExtension")
+	}
+	encode := func(glb bool) string {
+		var log bytes.Buffer
+		enc := Encoder(io.Discard).Logger(&log)
+		if glb {
+			enc = enc.GLB()
+		} else {
+			enc = enc.DataURI()
+		}
+		if err := enc.Encode(g); err != nil {
+			t.Fatal(err)
+		}
+		return log.String()
+	}
+	if log := encode(true); !strings.Contains(log, "RawExtension [VENDOR_buffer_ref]") {
+		t.Errorf("merging buffers with RawExtension must be warned, log :\n%s", log)
+	}
+	if log := encode(false); strings.Contains(log, "Warning") {
+		t.Errorf("buffers are not merged, but warned, log :\n%s", log)
+	}
+}
+
+func TestExtensionCanAttach(t *testing.T) {
+	js := `{"asset":{"version":"2.0"},
+	"extensionsUsed":["KHR_materials_unlit","KHR_draco_mesh_compression","VENDOR_any"],
+	"materials":[{"extensions":{"KHR_materials_unlit":{}}}],
+	"nodes":[{"extensions":{"KHR_materials_unlit":{},"VENDOR_any":{}}},{"extensions":{"KHR_draco_mesh_compression":{}}}]}`
+	parser := func() *parser {
+		return Parser().Reader(strings.NewReader(js)).Extensions(new(KHRMaterialsUnlit), new(KHRDracoMeshCompression))
+	}
+	issues, err := parser().Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// draco body is not checked, its required properties are not reported
+	expected := []string{"/nodes/0/extensions/KHR_materials_unlit", "/nodes/1/extensions/KHR_draco_mesh_compression"}
+	if len(issues) != len(expected) {
+		t.Fatalf("issues %v, expected %v", issues, expected)
+	}
+	for i, v := range issues {
+		if v.Pointer != expected[i] || v.Severity != SeverityWarning || v.Scheme != SCHEME_NODE || v.Index != i {
+			t.Errorf("issues[%d] %v, expected warning of %s", i, v, expected[i])
+		}
+	}
+	if _, err := parser().Strictness(LEVEL2).Parse(); err == nil {
+		t.Error("LEVEL2 parse must fail")
+	}
+}
@@ -0,0 +1,129 @@
+package gltf2
+
+import (
+	"bufio"
+	"encoding/binary"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/specification/2.0#glb-file-format-specification
+const (
+	glbMagic         = 0x46546C67 // "glTF"
+	glbVersion       = 2
+	glbHeaderLength  = 12
+	glbChunkHeader   = 8
+	glbChunkTypeJSON = 0x4E4F534A // "JSON"
+	glbChunkTypeBIN  = 0x004E4942 // "BIN\x00"
+)
+
+// isGLB peek first 4 bytes, json document can't start with "glTF"
+func isGLB(rd *bufio.Reader) bool {
+	magic, err := rd.Peek(4)
+	if err != nil {
+		return false
+	}
+	return binary.LittleEndian.Uint32(magic) == glbMagic
+}
+
+// readGLB split glb container to JSON chunk and BIN chunk
+//
+// BIN chunk is optional, if not exist, bin is nil
+func readGLB(rd io.Reader) (jsonChunk []byte, bin []byte, err error) {
+	var header [glbHeaderLength]byte
+	if _, err = io.ReadFull(rd, header[:]); err != nil {
+		return nil, nil, errors.WithMessage(ErrorGLB, err.Error())
+	}
+	if magic := binary.LittleEndian.Uint32(header[0:]); magic != glbMagic {
+		return nil, nil, errors.WithMessage(ErrorGLB, "Invalid magic")
+	}
+	if version := binary.LittleEndian.Uint32(header[4:]); version != glbVersion {
+		return nil, nil, errors.WithMessage(ErrorGLB, "Unsupported version")
+	}
+	length := int64(binary.LittleEndian.Uint32(header[8:]))
+	if length < glbHeaderLength {
+		return nil, nil, errors.WithMessage(ErrorGLB, "Invalid length")
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(rd, length-glbHeaderLength))
+	if err != nil {
+		return nil, nil, errors.WithMessage(ErrorGLB, err.Error())
+	}
+	if int64(len(body)) != length-glbHeaderLength {
+		return nil, nil, errors.WithMessage(ErrorGLB, "Unexpected end of container")
+	}
+	for i := 0; len(body) > 0; i++ {
+		if len(body) < glbChunkHeader {
+			return nil, nil, errors.WithMessage(ErrorGLB, "Truncated chunk header")
+		}
+		chunkLength := int(binary.LittleEndian.Uint32(body[0:]))
+		chunkType := binary.LittleEndian.Uint32(body[4:])
+		body = body[glbChunkHeader:]
+		if chunkLength > len(body) {
+			return nil, nil, errors.WithMessage(ErrorGLB, "Truncated chunk data")
+		}
+		chunk := body[:chunkLength]
+		body = body[chunkLength:]
+		switch {
+		case i == 0:
+			// first chunk must be JSON
+			if chunkType != glbChunkTypeJSON {
+				return nil, nil, errors.WithMessage(ErrorGLB, "First chunk must be JSON")
+			}
+			jsonChunk = chunk
+		case i == 1 && chunkType == glbChunkTypeBIN:
+			bin = chunk
+		default:
+			// Client implementations must ignore chunks with unknown types
+		}
+	}
+	if jsonChunk == nil {
+		return nil, nil, errors.WithMessage(ErrorGLB, "No JSON chunk")
+	}
+	return jsonChunk, bin, nil
+}
+
+// writeGLB write glb container, JSON chunk is padded with space, BIN chunk is padded with zero
+//
+// If bin is nil, BIN chunk is not written
+func writeGLB(wr io.Writer, jsonChunk []byte, bin []byte) error {
+	jsonPad := glbPadding(len(jsonChunk))
+	binPad := glbPadding(len(bin))
+	length := glbHeaderLength + glbChunkHeader + len(jsonChunk) + jsonPad
+	if bin != nil {
+		length += glbChunkHeader + len(bin) + binPad
+	}
+	var header [glbHeaderLength + glbChunkHeader]byte
+	binary.LittleEndian.PutUint32(header[0:], glbMagic)
+	binary.LittleEndian.PutUint32(header[4:], glbVersion)
+	binary.LittleEndian.PutUint32(header[8:], uint32(length))
+	binary.LittleEndian.PutUint32(header[12:], uint32(len(jsonChunk)+jsonPad))
+	binary.LittleEndian.PutUint32(header[16:], glbChunkTypeJSON)
+	if _, err := wr.Write(header[:]); err != nil {
+		return err
+	}
+	if _, err := wr.Write(jsonChunk); err != nil {
+		return err
+	}
+	if _, err := wr.Write([]byte("   ")[:jsonPad]); err != nil {
+		return err
+	}
+	if bin == nil {
+		return nil
+	}
+	binary.LittleEndian.PutUint32(header[0:], uint32(len(bin)+binPad))
+	binary.LittleEndian.PutUint32(header[4:], glbChunkTypeBIN)
+	if _, err := wr.Write(header[:glbChunkHeader]); err != nil {
+		return err
+	}
+	if _, err := wr.Write(bin); err != nil {
+		return err
+	}
+	_, err := wr.Write(make([]byte, binPad))
+	return err
+}
+
+// glbPadding is required bytes to align 4 bytes boundary
+func glbPadding(length int) int {
+	return (4 - length%4) % 4
+}
@@ -0,0 +1,236 @@
+package gltf2
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Severity of Issue, it follows Strictness which report the Issue
+type Severity uint8
+
+const (
+	// LEVEL1, glTF can't be used
+	SeverityError Severity = iota
+	// LEVEL2, potential problem
+	SeverityWarning
+	// LEVEL3, not follow Specifier strictly
+	SeverityInformation
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "Error"
+	case SeverityWarning:
+		return "Warning"
+	case SeverityInformation:
+		return "Information"
+	}
+	return "nil"
+}
+func severityOf(level Strictness) Severity {
+	switch level {
+	case LEVEL2:
+		return SeverityWarning
+	case LEVEL3:
+		return SeverityInformation
+	}
+	return SeverityError
+}
+
+// Issue.Code, it never changed between versions
+const (
+	// required field is not defined
+	CODE_UNDEFINED_PROPERTY = "UNDEFINED_PROPERTY"
+	// field defined without field it depend on
+	CODE_UNSATISFIED_DEPENDENCY = "UNSATISFIED_DEPENDENCY"
+	// exclusive fields are defined together, or none of them is defined
+	CODE_ONE_OF_MISMATCH           = "ONE_OF_MISMATCH"
+	CODE_VALUE_NOT_IN_RANGE        = "VALUE_NOT_IN_RANGE"
+	CODE_VALUE_NOT_IN_LIST         = "VALUE_NOT_IN_LIST"
+	CODE_ARRAY_LENGTH_NOT_IN_RANGE = "ARRAY_LENGTH_NOT_IN_RANGE"
+	CODE_DUPLICATE_ELEMENTS        = "DUPLICATE_ELEMENTS"
+	// glTFid is out of range
+	CODE_UNRESOLVED_REFERENCE = "UNRESOLVED_REFERENCE"
+	// extensionsRequired is not in Parser().Extensions
+	CODE_UNSUPPORTED_EXTENSION = "UNSUPPORTED_EXTENSION"
+	// Syntax returned error which is not Issues, ex) third party extension
+	CODE_SYNTAX_ERROR = "SYNTAX_ERROR"
+)
+
+type Issue struct {
+	Severity Severity
+	Code     string
+	// Scheme of object reporting issue, ex) SCHEME_ACCESSOR
+	Scheme string
+	// Index of object in its array, -1 if object is not array item
+	Index int
+	// JSON pointer of issue, ex) /meshes/3/primitives/0/indices
+	Pointer string
+	Message string
+}
+
+func (s Issue) String() string {
+	return fmt.Sprintf("%s %s '%s' : %s", s.Severity, s.Code, s.Pointer, s.Message)
+}
+
+// Issues is Syntax result, it is also error
+type Issues []Issue
+
+func (s Issues) Error() string {
+	strs := make([]string, len(s))
+	for i, v := range s {
+		strs[i] = v.String()
+	}
+	return strings.Join(strs, "; ")
+}
+
+// HasError report there is SeverityError issue
+func (s Issues) HasError() bool {
+	for _, v := range s {
+		if v.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter return issues of severity
+func (s Issues) Filter(severity Severity) (res Issues) {
+	for _, v := range s {
+		if v.Severity == severity {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// add issue found in Syntax, pointer is relative from the object
+func (s *Issues) add(level Strictness, code string, pointer string, format string, args ...interface{}) {
+	*s = append(*s, Issue{
+		Severity: severityOf(level),
+		Code:     code,
+		Index:    -1,
+		Pointer:  pointer,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// reference add issue if glTFid is not in range of root array
+func (s *Issues) reference(id *SpecGLTFID, length int, pointer string, name string) {
+	if id != nil && !inRange(*id, length) {
+		s.add(LEVEL1, CODE_UNRESOLVED_REFERENCE, pointer, "%s '%d' not found", name, *id)
+	}
+}
+
+// materialExtension add issue if extension is not in material, name is extension type name
+func (s *Issues) materialExtension(parent Specifier, name string) {
+	if _, ok := parent.(*SpecMaterial); !ok {
+		s.add(LEVEL2, CODE_UNDEFINED_PROPERTY, "", "%s is extension of material, but it is in '%s'", name, parent.Scheme())
+	}
+}
+
+// textureExtension add issue if extension is not in texture, name is extension type name
+func (s *Issues) textureExtension(parent Specifier, name string) {
+	if _, ok := parent.(*SpecTexture); !ok {
+		s.add(LEVEL2, CODE_UNDEFINED_PROPERTY, "", "%s is extension of texture, but it is in '%s'", name, parent.Scheme())
+	}
+}
+
+// nodeExtension add issue if extension is not in node, name is extension type name
+func (s *Issues) nodeExtension(parent Specifier, name string) {
+	if _, ok := parent.(*SpecNode); !ok {
+		s.add(LEVEL2, CODE_UNDEFINED_PROPERTY, "", "%s is extension of node, but it is in '%s'", name, parent.Scheme())
+	}
+}
+
+// imageMimeType add issue if mimeType of image is not mime, data URI mime type is used if mimeType is undefined
+func (s *Issues) imageMimeType(root Specifier, id *SpecGLTFID, mime MimeType, pointer string, name string) {
+	g, ok := root.(*SpecGLTF)
+	if !ok || id == nil || !inRange(*id, len(g.Images)) {
+		return
+	}
+	img := g.Images[*id]
+	declared := mime.String()
+	if img.MimeType != nil {
+		declared = img.MimeType.String()
+	} else if img.URI != nil && img.URI.IsDataURI() {
+		if v, _, err := img.URI.DataURI(); err == nil {
+			declared = v
+		}
+	}
+	if declared != mime.String() {
+		s.add(LEVEL2, CODE_VALUE_NOT_IN_LIST, pointer, "%s allow(%s), but got '%s'", name, mime, declared)
+	}
+}
+
+// Err is nil if there is no issue, Syntax return it
+func (s Issues) Err() error {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
+
+// ChildPointer is optional for Parents, it gives JSON pointer of child relative from parent
+//
+// ex) SpecMesh.ChildPointer(0) = "/primitives/0"
+type ChildPointer interface {
+	ChildPointer(i int) string
+}
+
+func childPointer(parent Parents, i int) string {
+	if cp, ok := parent.(ChildPointer); ok {
+		return cp.ChildPointer(i)
+	}
+	return ""
+}
+
+// escapePointer escape JSON pointer reference token, RFC 6901
+func escapePointer(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+// pointerIndex is array index of pointer's last token, -1 if not array item
+func pointerIndex(pointer string) int {
+	i, err := strconv.Atoi(pointer[strings.LastIndexByte(pointer, '/')+1:])
+	if err != nil || pointer == "" {
+		return -1
+	}
+	return i
+}
+
+// syntaxIssues run Syntax and place issues on pointer
+//
+// If Syntax return plain error, it become CODE_SYNTAX_ERROR issue of lowest strictness which the error is found
+func syntaxIssues(root, parent, target Specifier, strictness Strictness, pointer string) Issues {
+	err := target.Syntax(strictness, root, parent)
+	if err == nil {
+		return nil
+	}
+	issues, ok := err.(Issues)
+	if !ok {
+		level := strictness
+		for probe := LEVEL1; probe < strictness; probe++ {
+			if target.Syntax(probe, root, parent) != nil {
+				level = probe
+				break
+			}
+		}
+		issues = nil
+		issues.add(level, CODE_SYNTAX_ERROR, "", "%s", err.Error())
+	}
+	res := make(Issues, len(issues))
+	for i, v := range issues {
+		v.Pointer = pointer + v.Pointer
+		if v.Scheme == "" {
+			v.Scheme = target.Scheme()
+		}
+		if v.Index < 0 {
+			v.Index = pointerIndex(pointer)
+		}
+		res[i] = v
+	}
+	return res
+}
@@ -0,0 +1,100 @@
+package gltf2
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestValidateIssues(t *testing.T) {
+	src := `{"asset":{},"scene":3,"scenes":[{"nodes":[0,0]}],
+	"meshes":[{"primitives":[{"attributes":{"POSITION":9},"indices":7}]}],
+	"materials":[{"emissiveFactor":[2,0,0],"pbrMetallicRoughness":{"baseColorFactor":[1,1,1,1],"baseColorTexture":{"index":4}},"occlusionTexture":{"index":0}}],
+	"cameras":[{"type":"perspective"}],
+	"nodes":[{"skin":0}],
+	"extensionsRequired":["FOO_bar"]}`
+	issues, err := Parser().Reader(strings.NewReader(src)).Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reported := make(map[string]Issue)
+	for _, v := range issues {
+		reported[v.Pointer] = v
+	}
+	// every problem is reported, not only first one
+	expected := []string{
+		"/asset/version",
+		"/scene",
+		"/scenes/0/nodes",
+		"/meshes/0/primitives/0/attributes/POSITION",
+		"/meshes/0/primitives/0/indices",
+		"/materials/0/emissiveFactor",
+		"/materials/0/pbrMetallicRoughness/baseColorTexture/index",
+		"/materials/0/occlusionTexture/index",
+		"/cameras/0/perspective",
+		"/nodes/0/skin",
+		"/extensionsRequired/0",
+	}
+	for _, v := range expected {
+		if _, ok := reported[v]; !ok {
+			t.Errorf("issue of '%s' is not reported", v)
+		}
+	}
+	if v := reported["/meshes/0/primitives/0/indices"]; v.Scheme != SCHEME_MESH_PRIMITIVE || v.Index != 0 || v.Code != CODE_UNRESOLVED_REFERENCE || v.Severity != SeverityError {
+		t.Errorf("indices issue %+v", v)
+	}
+	if !issues.HasError() {
+		t.Error("HasError must be true")
+	}
+	_, err = Parser().Reader(strings.NewReader(src)).Parse()
+	if _, ok := err.(interface{ Cause() error }); err == nil || !ok {
+		t.Fatalf("parse must fail with cause, but got %v", err)
+	}
+}
+
+func TestValidateWarning(t *testing.T) {
+	p := Parser().Reader(strings.NewReader(`{"asset":{"version":"2.0"},"materials":[{"emissiveFactor":[2,0,0]}]}`))
+	issues, err := p.Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Severity != SeverityWarning || issues[0].Index != 0 || issues[0].Scheme != SCHEME_MATERIAL {
+		t.Fatalf("issues %v, expected one warning of material 0", issues)
+	}
+	// LEVEL1 parse after Validate ignore warning
+	g, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := Encoder(&out).Encode(g); err != nil {
+		t.Fatal(err)
+	}
+	issues, err = Parser().Reader(&out).Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Pointer != "/materials/0/emissiveFactor" {
+		t.Errorf("issues of encoded glTF %v, expected same warning", issues)
+	}
+}
+
+func TestValidateEncoded(t *testing.T) {
+	for name, input := range encodeTestInputs(t) {
+		src, err := input()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		if err := Encoder(&out).DataURI().Encode(src); err != nil {
+			t.Fatal(err)
+		}
+		issues, err := Parser().Reader(&out).Validate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(issues) != 0 {
+			t.Errorf("%s : issues of encoded glTF %v", name, issues)
+		}
+	}
+}
@@ -1,30 +1,41 @@
 package gltf2
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/iamGreedy/glog"
 	"github.com/pkg/errors"
 	"io"
+	"io/fs"
 	"log"
-	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 )
 
 type parser struct {
-	dst    *GLTF
-	src    *SpecGLTF
-	parsed bool
+	dst     *GLTF
+	src     *SpecGLTF
+	decoded bool
+	parsed  bool
 	// extension support
 	exts []ExtensionType
+	// every extension is RawExtension
+	raw bool
+	// name of RawExtension in source
+	raws map[string]bool
 	//
 	cause error
 	err   error
 	//
 	strictness Strictness
 	rd         io.Reader
-	dir        http.Dir
+	dir        string
+	resolver   Resolver
+	// glb BIN chunk, nil if not glb or glb without BIN chunk
+	bin []byte
 	// [progress]
 	// + logging task
 	logger *glog.Glogger
@@ -69,18 +80,63 @@ func (s *parser) Extensions(exts ...ExtensionType) *parser {
 	s.exts = append(s.exts, exts...)
 	return s
 }
+
+// AllKnownExtensions add every extension in global registry, see RegisterExtension
+//
+// Extension added by Extensions before is not replaced
+func (s *parser) AllKnownExtensions() *parser {
+	for _, ext := range KnownExtensions() {
+		if !inExtension(ext.ExtensionName(), s.exts) {
+			s.exts = append(s.exts, ext)
+		}
+	}
+	return s
+}
+
+// RawExtensions keep every extension as RawExtension, even if it is registered by Extensions
+//
+// Registered extension is still needed for ExtensionsRequired, but it is not parsed and validated
+// glTFid in RawExtension is not linked, see RawExtension
+func (s *parser) RawExtensions() *parser {
+	s.raw = true
+	return s
+}
+
+// Directory is base directory for relative URI, it is used when there is no Resolver
 func (s *parser) Directory(path string) *parser {
 	fi, err := os.Stat(path)
 	if err != nil {
 		s.setCauseError(ErrorParserOption, err)
+		return s
 	}
 	if !fi.IsDir() {
 		s.setCauseError(ErrorParserOption, errors.Errorf("Not directory '%s'", path))
 	} else {
-		s.dir = http.Dir(path)
+		s.dir = path
 	}
 	return s
 }
+
+// Resolver replace how Buffer, Image load external resources
+//
+// If not set, DirectoryResolver(Directory) is used
+func (s *parser) Resolver(resolver Resolver) *parser {
+	if resolver == nil {
+		s.setCauseError(ErrorParserOption, errors.Errorf("Parser().Resolver(<not nillable>)"))
+		return s
+	}
+	s.resolver = resolver
+	return s
+}
+
+// FileSystem is shorthand of Resolver(FSResolver(fsys))
+func (s *parser) FileSystem(fsys fs.FS) *parser {
+	if fsys == nil {
+		s.setCauseError(ErrorParserOption, errors.Errorf("Parser().FileSystem(<not nillable>)"))
+		return s
+	}
+	return s.Resolver(FSResolver(fsys))
+}
 func (s *parser) Logger(dst io.Writer) *parser {
 	if dst != nil {
 		s.logger = glog.New(log.New(dst, "[ glTF 2.0 ] ", log.LstdFlags), "    ")
@@ -127,26 +183,14 @@ func (s *parser) Parse() (*GLTF, error) {
 	if s.parsed {
 		return s.dst, nil
 	}
-	//====================================================//
-	// constraint
-	if s.rd == nil {
-		s.setCauseError(ErrorParser, errors.Errorf("No reader"))
-		return nil, s.Error()
+	if err := s.decode(); err != nil {
+		return nil, err
 	}
 	//
 	ctx := &parserContext{
 		ref: s,
 	}
 	//====================================================//
-	// json parse here
-	dec := json.NewDecoder(s.rd)
-	s.logger.Println("json decode start...")
-	if err := dec.Decode(s.src); err != nil {
-		s.setCauseError(ErrorJSON, err)
-		return nil, s.Error()
-	}
-	s.logger.Println("json decode complete")
-	//====================================================//
 	// extension used
 	for _, v := range s.src.ExtensionsRequired {
 		if !inExtension(v, s.exts) {
@@ -155,14 +199,6 @@ func (s *parser) Parse() (*GLTF, error) {
 		}
 	}
 	//====================================================//
-	// extension parse here
-	s.logger.Println("extension json decode start...")
-	if err := recurPostJson(s.src, s.exts); err != nil {
-		s.setCauseError(ErrorExtension, err)
-		return nil, s.Error()
-	}
-	s.logger.Println("extension json decode complete")
-	//====================================================//
 	// pre Task
 	if s.pres != nil {
 		s.logger.Println("PreTasks start")
@@ -176,8 +212,8 @@ func (s *parser) Parse() (*GLTF, error) {
 	// Syntax check
 	s.logger.Println("syntax check")
 	s.logger.Printf("syntax strictness : %v \n", s.strictness)
-	if err := recurSyntax(s.src, nil, s.src, s.strictness); err != nil {
-		s.setCauseError(ErrorGLTFSpec, err)
+	if issues := append(s.binaryChunkIssues(s.strictness), recurSyntax(s.src, nil, s.src, s.strictness, "")...); len(issues) > 0 {
+		s.setCauseError(ErrorGLTFSpec, issues)
 		return nil, s.Error()
 	}
 	s.logger.Println("syntax valid")
@@ -211,6 +247,100 @@ func (s *parser) Parse() (*GLTF, error) {
 	s.logger.Println("complete.")
 	return s.dst, nil
 }
+
+// Validate check Syntax of every object in LEVEL3, it report all issues found instead of first one
+//
+// Issue.Severity follows Strictness, LEVEL1 is SeverityError, LEVEL2 is SeverityWarning, LEVEL3 is SeverityInformation
+// Parse can be called after Validate, reader is not read again
+func (s *parser) Validate() (Issues, error) {
+	if err := s.Error(); err != nil {
+		return nil, err
+	}
+	if err := s.decode(); err != nil {
+		return nil, err
+	}
+	var issues Issues
+	for i, v := range s.src.ExtensionsRequired {
+		if !inExtension(v, s.exts) {
+			issues = append(issues, Issue{
+				Severity: SeverityError,
+				Code:     CODE_UNSUPPORTED_EXTENSION,
+				Scheme:   SCHEME_GLTF,
+				Index:    -1,
+				Pointer:  fmt.Sprintf("/extensionsRequired/%d", i),
+				Message:  fmt.Sprintf("ExtensionKey : '%s' not support", v),
+			})
+		}
+	}
+	issues = append(issues, s.binaryChunkIssues(LEVEL3)...)
+	issues = append(issues, recurSyntax(s.src, nil, s.src, LEVEL3, "")...)
+	s.logger.Printf("validate complete, %d issues\n", len(issues))
+	return issues, nil
+}
+
+// decode read glb container, json and extensions only once
+func (s *parser) decode() error {
+	if s.decoded {
+		return nil
+	}
+	//====================================================//
+	// constraint
+	if s.rd == nil {
+		s.setCauseError(ErrorParser, errors.Errorf("No reader"))
+		return s.Error()
+	}
+	//====================================================//
+	// glb container
+	brd := bufio.NewReader(s.rd)
+	var rd io.Reader = brd
+	if isGLB(brd) {
+		s.logger.Println("glb container detected")
+		jsonChunk, bin, err := readGLB(rd)
+		if err != nil {
+			s.setCauseError(ErrorGLB, err)
+			return s.Error()
+		}
+		s.bin = bin
+		rd = bytes.NewReader(jsonChunk)
+		s.logger.Printf("glb split complete, json = %d bytes, bin = %d bytes\n", len(jsonChunk), len(bin))
+	}
+	//====================================================//
+	// json parse here
+	dec := json.NewDecoder(rd)
+	s.logger.Println("json decode start...")
+	if err := dec.Decode(s.src); err != nil {
+		s.setCauseError(ErrorJSON, err)
+		return s.Error()
+	}
+	s.logger.Println("json decode complete")
+	//====================================================//
+	// extension parse here
+	s.logger.Println("extension json decode start...")
+	s.raws = make(map[string]bool)
+	if err := recurPostJson(s.src, s.exts, s.raw, s.raws); err != nil {
+		s.setCauseError(ErrorExtension, err)
+		return s.Error()
+	}
+	s.logger.Println("extension json decode complete")
+	s.decoded = true
+	return nil
+}
+
+// binaryChunkIssues check glb BIN chunk can be bound, BIN chunk is always buffers[0] which uri is undefined
+func (s *parser) binaryChunkIssues(strictness Strictness) (issues Issues) {
+	if s.bin == nil || strictness < LEVEL2 {
+		return nil
+	}
+	switch {
+	case len(s.src.Buffers) == 0:
+		issues.add(LEVEL2, CODE_UNDEFINED_PROPERTY, "/buffers", "glb BIN chunk exist, but there is no buffers[0]")
+		issues[0].Scheme = SCHEME_GLTF
+	case s.src.Buffers[0].URI != nil:
+		issues.add(LEVEL2, CODE_UNDEFINED_PROPERTY, "/buffers/0/uri", "glb BIN chunk is buffers[0], its uri must be undefined")
+		issues[0].Scheme, issues[0].Index = SCHEME_BUFFER, 0
+	}
+	return issues
+}
 func (s *parser) Close() error {
 	s.src = nil
 	return nil
@@ -221,81 +351,106 @@ func Parser() *parser {
 		src: new(SpecGLTF),
 
 		strictness: LEVEL1,
-		dir:        http.Dir("."),
+		dir:        ".",
 	}
 	return res
 }
 
-func recurPostJson(target Specifier, exts []ExtensionType) error {
+// recurPostJson construct extension specifiers, raws is filled with name of RawExtension
+func recurPostJson(target Specifier, exts []ExtensionType, raw bool, raws map[string]bool) error {
 	if target == nil {
 		return nil
 	}
 	//// extension
 	if g, ok := target.(ExtensionSpecifier); ok {
 		if ext := g.SpecExtension(); ext != nil {
-			var delList []string
 			for k, v := range *ext {
-				if exk := extFindByName(k, exts...); exk != nil {
-					var err error
-					v.data, err = exk.Constructor(v.src)
-					if err != nil {
-						return err
-					}
-				} else {
-					delList = append(delList, k)
+				exk := extFindByName(k, exts...)
+				if exk == nil || raw {
+					// not registered extension is kept as json
+					exk = &RawExtension{Name: k}
+					raws[k] = true
+				}
+				var err error
+				v.ext = exk
+				v.data, err = exk.Constructor(v.src)
+				if err != nil {
+					return err
+				}
+				// extension object can have its own extensions, ex) KHR_lights_punctual light
+				if err = recurPostJson(v.data, exts, raw, raws); err != nil {
+					return err
 				}
-			}
-			for _, v := range delList {
-				delete(*ext, v)
 			}
 		}
 	}
 	//
 	if tc, ok := target.(Parents); ok {
 		for i := 0; i < tc.LenChild(); i++ {
-			if err := recurPostJson(tc.GetChild(i), exts); err != nil {
+			if err := recurPostJson(tc.GetChild(i), exts, raw, raws); err != nil {
 				return err
 			}
 		}
 	}
 	return nil
 }
-func recurSyntax(root, parent, target Specifier, strictness Strictness) error {
+func recurSyntax(root, parent, target Specifier, strictness Strictness, pointer string) (issues Issues) {
 	if target == nil {
 		return nil
 	}
-	if err := target.Syntax(strictness, root, parent); err != nil {
-		return err
-	}
-	// extension
+	issues = append(issues, syntaxIssues(root, parent, target, strictness, pointer)...)
+	// extension, its parent is owner object
 	if g, ok := target.(ExtensionSpecifier); ok {
 		if ext := g.SpecExtension(); ext != nil {
-			for _, v := range *ext {
-				if err := v.data.Syntax(strictness, root, parent); err != nil {
-					return err
+			names := make([]string, 0, len(*ext))
+			for k := range *ext {
+				names = append(names, k)
+			}
+			sort.Strings(names)
+			for _, k := range names {
+				v := (*ext)[k]
+				if v.data == nil {
+					continue
 				}
+				if wrong := attachIssues(target, v.ext, strictness, pointer, k); wrong != nil {
+					// extension in wrong object is not checked more
+					issues = append(issues, wrong...)
+					continue
+				}
+				issues = append(issues, recurSyntax(root, target, v.data, strictness, pointer+"/extensions/"+escapePointer(k))...)
 			}
 		}
 	}
 	//
 	if tc, ok := target.(Parents); ok {
 		for i := 0; i < tc.LenChild(); i++ {
-			if err := recurSyntax(root, target, tc.GetChild(i), strictness); err != nil {
-				return err
-			}
+			issues = append(issues, recurSyntax(root, target, tc.GetChild(i), strictness, pointer+childPointer(tc, i))...)
 		}
 	}
-	return nil
+	return issues
+}
+
+// attachIssues add LEVEL2 issue if extension of name can't be attached to target, see ExtensionCapability.CanAttach
+func attachIssues(target Specifier, ext ExtensionType, strictness Strictness, pointer string, name string) (issues Issues) {
+	if ext == nil || strictness < LEVEL2 {
+		return nil
+	}
+	capability := Capability(ext)
+	if capability.CanAttach(target.Scheme()) {
+		return nil
+	}
+	issues.add(LEVEL2, CODE_UNDEFINED_PROPERTY, pointer+"/extensions/"+escapePointer(name), "%s is extension of %v, but it is in '%s'", name, capability.Schemes, target.Scheme())
+	issues[0].Scheme, issues[0].Index = target.Scheme(), pointerIndex(pointer)
+	return issues
 }
 func recurTo(data interface{}, target Specifier, ctx *parserContext) {
 	if g, ok := target.(ExtensionSpecifier); ok {
 		var dataext = make(Extensions)
 		if specext := g.SpecExtension(); specext != nil {
 			for k, v := range *specext {
-				extk := extFindByName(k, ctx.ref.exts...)
 				extData := v.data.To(ctx)
 				recurTo(extData, v.data, ctx)
-				dataext[extk.ExtensionName()] = extData.(ExtensionType)
+				dataext[k] = extData.(ExtensionType)
 			}
 		}
 		data.(ExtensionStructure).SetExtension(&dataext)
@@ -346,7 +501,39 @@ func (s *parserContext) Strictness() Strictness {
 	return s.ref.strictness
 }
 func (s *parserContext) Directory() string {
-	return string(s.ref.dir)
+	return s.ref.dir
+}
+func (s *parserContext) Resolver() Resolver {
+	if s.ref.resolver != nil {
+		return s.ref.resolver
+	}
+	return DirectoryResolver(s.ref.dir)
+}
+// BinaryChunk return glb BIN chunk, nil if not exist
+func (s *parserContext) BinaryChunk() []byte {
+	return s.ref.bin
+}
+
+// binaryChunkOf return glb BIN chunk if buffer is buffers[0] without uri, otherwise nil
+func (s *parserContext) binaryChunkOf(buffer *SpecBuffer) []byte {
+	if bufs := s.ref.src.Buffers; len(bufs) == 0 || &bufs[0] != buffer || buffer.URI != nil {
+		return nil
+	}
+	return s.ref.bin
+}
+// RawExtensionNames is sorted name of RawExtension in source, nil if there is not
+//
+// Task which change glTFid should warn it, glTFid in RawExtension is not updated
+func (s *parserContext) RawExtensionNames() []string {
+	if s == nil || len(s.ref.raws) == 0 {
+		return nil
+	}
+	res := make([]string, 0, len(s.ref.raws))
+	for k := range s.ref.raws {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
 }
 func (s *parserContext) Specification() *SpecGLTF {
 	return s.ref.src
@@ -0,0 +1,40 @@
+package gltf2
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseGLBBinaryChunk(t *testing.T) {
+	bin := []byte{1, 2, 3, 4}
+	// BIN chunk is buffers[0]
+	g, err := Parser().Reader(bytes.NewReader(encodeTestGLB(`{"asset":{"version":"2.0"},"buffers":[{"byteLength":4},{"byteLength":4}]}`, bin))).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := g.Buffers[0].Load(false); err != nil || !bytes.Equal(got, bin) {
+		t.Errorf("buffers[0] %v %v, expected %v", got, err, bin)
+	}
+	if got, err := g.Buffers[1].Load(false); err != nil || !bytes.Equal(got, make([]byte, 4)) {
+		t.Errorf("buffers[1] %v %v, expected zero filled", got, err)
+	}
+	// buffers[0] has uri, BIN chunk is not bound to other buffer
+	src := encodeTestGLB(`{"asset":{"version":"2.0"},"buffers":[{"byteLength":1,"uri":"data:application/octet-stream;base64,AA=="},{"byteLength":4}]}`, bin)
+	issues, err := Parser().Reader(bytes.NewReader(src)).Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Pointer != "/buffers/0/uri" || issues[0].Severity != SeverityWarning || issues[0].Index != 0 {
+		t.Fatalf("issues %v, expected warning of /buffers/0/uri", issues)
+	}
+	g, err = Parser().Reader(bytes.NewReader(src)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := g.Buffers[1].Load(false); err != nil || bytes.Equal(got, bin) {
+		t.Errorf("buffers[1] %v %v, BIN chunk must not be bound", got, err)
+	}
+	if _, err = Parser().Reader(bytes.NewReader(src)).Strictness(LEVEL2).Parse(); err == nil {
+		t.Error("LEVEL2 parse must fail")
+	}
+}
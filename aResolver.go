@@ -0,0 +1,77 @@
+package gltf2
+
+import (
+	"github.com/iamGreedy/essence/req"
+	"github.com/pkg/errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// Resolver open the resource referred by URI, ex) Buffer.URI, Image.URI
+//
+// data URI never reach to Resolver, it is decoded in-process
+type Resolver interface {
+	Resolve(uri *URI) (io.ReadCloser, error)
+}
+
+// ResolverFunc is function adapter for Resolver
+type ResolverFunc func(uri *URI) (io.ReadCloser, error)
+
+func (s ResolverFunc) Resolve(uri *URI) (io.ReadCloser, error) {
+	return s(uri)
+}
+
+// DirectoryResolver resolve relative URI and file URI from dir
+//
+// other scheme(http, https, ...) is requested by 'req.Standard'
+func DirectoryResolver(dir string) Resolver {
+	if dir == "" {
+		dir = "."
+	}
+	return ResolverFunc(func(uri *URI) (io.ReadCloser, error) {
+		switch uri.Scheme {
+		case "":
+			fallthrough
+		case "file":
+			return os.Open(filepath.Join(dir, filepath.FromSlash(relativeURIPath(uri))))
+		}
+		return req.Standard.Request(uri.Data())
+	})
+}
+
+// FSResolver resolve relative URI and file URI from fsys, ex) embed.FS, zip.Reader, fstest.MapFS
+//
+// other scheme(http, https, ...) is not supported
+func FSResolver(fsys fs.FS) Resolver {
+	return ResolverFunc(func(uri *URI) (io.ReadCloser, error) {
+		switch uri.Scheme {
+		case "":
+			fallthrough
+		case "file":
+			return fsys.Open(relativeURIPath(uri))
+		}
+		return nil, errors.Errorf("FSResolver : URI scheme '%s' not supported", uri.Scheme)
+	})
+}
+
+// relativeURIPath is unescaped, slash separated path, it can't escape root by '..'
+func relativeURIPath(uri *URI) string {
+	res := path.Clean("/" + uri.Path)[1:]
+	if res == "" {
+		return "."
+	}
+	return res
+}
+
+var defaultResolver = DirectoryResolver(".")
+
+// resolve open uri by resolver, if resolver is nil, use working directory
+func resolve(resolver Resolver, uri *URI) (io.ReadCloser, error) {
+	if resolver == nil {
+		resolver = defaultResolver
+	}
+	return resolver.Resolve(uri)
+}
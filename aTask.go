@@ -10,7 +10,6 @@ import (
 	"github.com/labstack/gommon/bytes"
 	"github.com/pkg/errors"
 	"math"
-	"net/url"
 )
 
 type (
@@ -78,7 +77,12 @@ func _BufferCaching(parser *parserContext, gltf *GLTF, logger *glog.Glogger) err
 			inner.Println("Already cached")
 			continue
 		}
-		uesc, _ := url.PathUnescape(buf.URI.Data().String())
+		var uesc string
+		if buf.URI == nil {
+			uesc = "<glb binary chunk>"
+		} else {
+			uesc = uriLabel(buf.URI)
+		}
 		if buf.ByteLength == nil {
 			inner.Printf("Caching : %s\n", uesc)
 		} else {
@@ -108,8 +112,7 @@ func _ImageCaching(parser *parserContext, gltf *GLTF, logger *glog.Glogger) erro
 		case *BufferImage:
 			inner.Printf("Caching : BufferView(%v)", base.BufferView)
 		case *URIImage:
-			uesc, _ := url.PathUnescape(base.URI.Data().String())
-			inner.Printf("Caching : %s", uesc)
+			inner.Printf("Caching : %s", uriLabel(base.URI))
 		}
 		if _, err := img.Load(true); err != nil {
 			return err
@@ -137,7 +140,12 @@ var Tasks = struct {
 	AutoBufferTarget Task
 	// make accessor no stride
 	TightPacking Task
-	// TODO UnpackDracoMesh
+	// rewrite KHR_mesh_quantization attributes to FLOAT accessors
+	Dequantize Task
+	// apply KHR_texture_transform to TEXCOORD accessor if texture is used by only one material
+	BakeTextureTransform Task
+	// decode KHR_draco_mesh_compression to new accessors, undecodable primitive is kept with warning
+	UnpackDraco Task
 	// TODO Clean Task
 	// - Dangling Node
 	// - Unreferenced Buffer, Image
@@ -180,140 +188,13 @@ var Tasks = struct {
 			if len(accessor.Min) > 0 && len(accessor.Max) > 0 {
 				continue
 			}
-			if accessor.BufferView == nil {
+			if accessor.BufferView == nil && accessor.Sparse == nil || accessor.Count < 1 {
 				continue
 			}
-			var (
-				min = make([]float32, accessor.Type.Count())
-				max = make([]float32, accessor.Type.Count())
-			)
-			switch accessor.ComponentType {
-			case BYTE:
-				var (
-					tempmin = make([]int8, accessor.Type.Count())
-					tempmax = make([]int8, accessor.Type.Count())
-				)
-				slice := accessor.MustSliceMapping(new([]int8), false, true).([]int8)
-				for i := 0; i < len(slice); i += accessor.Type.Count() {
-					for j := 0; j < accessor.Type.Count(); j++ {
-						if slice[i+j] < tempmin[j] {
-							tempmin[j] = slice[i+j]
-						}
-						if slice[i+j] > tempmax[j] {
-							tempmax[j] = slice[i+j]
-						}
-					}
-				}
-				for i, v := range tempmin {
-					min[i] = float32(v)
-				}
-				for i, v := range tempmax {
-					max[i] = float32(v)
-				}
-			case UNSIGNED_BYTE:
-				var (
-					tempmin = make([]uint8, accessor.Type.Count())
-					tempmax = make([]uint8, accessor.Type.Count())
-				)
-				slice := accessor.MustSliceMapping(new([]uint8), false, true).([]uint8)
-				for i := 0; i < len(slice); i += accessor.Type.Count() {
-					for j := 0; j < accessor.Type.Count(); j++ {
-						if slice[i+j] < tempmin[j] {
-							tempmin[j] = slice[i+j]
-						}
-						if slice[i+j] > tempmax[j] {
-							tempmax[j] = slice[i+j]
-						}
-					}
-				}
-				for i, v := range tempmin {
-					min[i] = float32(v)
-				}
-				for i, v := range tempmax {
-					max[i] = float32(v)
-				}
-			case SHORT:
-				var (
-					tempmin = make([]int16, accessor.Type.Count())
-					tempmax = make([]int16, accessor.Type.Count())
-				)
-				slice := accessor.MustSliceMapping(new([]int16), false, true).([]int16)
-				for i := 0; i < len(slice); i += accessor.Type.Count() {
-					for j := 0; j < accessor.Type.Count(); j++ {
-						if slice[i+j] < tempmin[j] {
-							tempmin[j] = slice[i+j]
-						}
-						if slice[i+j] > tempmax[j] {
-							tempmax[j] = slice[i+j]
-						}
-					}
-				}
-				for i, v := range tempmin {
-					min[i] = float32(v)
-				}
-				for i, v := range tempmax {
-					max[i] = float32(v)
-				}
-			case UNSIGNED_SHORT:
-				var (
-					tempmin = make([]uint16, accessor.Type.Count())
-					tempmax = make([]uint16, accessor.Type.Count())
-				)
-				slice := accessor.MustSliceMapping(new([]uint16), false, true).([]uint16)
-				for i := 0; i < len(slice); i += accessor.Type.Count() {
-					for j := 0; j < accessor.Type.Count(); j++ {
-						if slice[i+j] < tempmin[j] {
-							tempmin[j] = slice[i+j]
-						}
-						if slice[i+j] > tempmax[j] {
-							tempmax[j] = slice[i+j]
-						}
-					}
-				}
-				for i, v := range tempmin {
-					min[i] = float32(v)
-				}
-				for i, v := range tempmax {
-					max[i] = float32(v)
-				}
-			case UNSIGNED_INT:
-				var (
-					tempmin = make([]uint32, accessor.Type.Count())
-					tempmax = make([]uint32, accessor.Type.Count())
-				)
-				slice := accessor.MustSliceMapping(new([]uint32), false, true).([]uint32)
-				for i := 0; i < len(slice); i += accessor.Type.Count() {
-					for j := 0; j < accessor.Type.Count(); j++ {
-						if slice[i+j] < tempmin[j] {
-							tempmin[j] = slice[i+j]
-						}
-						if slice[i+j] > tempmax[j] {
-							tempmax[j] = slice[i+j]
-						}
-					}
-				}
-				for i, v := range tempmin {
-					min[i] = float32(v)
-				}
-				for i, v := range tempmax {
-					max[i] = float32(v)
-				}
-			case FLOAT:
-				slice := accessor.MustSliceMapping(new([]float32), false, true).([]float32)
-				for i := 0; i < len(slice); i += accessor.Type.Count() {
-					for j := 0; j < accessor.Type.Count(); j++ {
-						if slice[i+j] < min[j] {
-							min[j] = slice[i+j]
-						}
-						if slice[i+j] > max[j] {
-							max[j] = slice[i+j]
-						}
-					}
-				}
-			default:
-				logger.Panic("Unreachable")
+			min, max, err := accessorMinMax(accessor)
+			if err != nil {
+				return err
 			}
-
 			if len(accessor.Name) > 0 {
 				logger.Printf("glTF.Accessors['%s'] ", accessor.Name)
 			} else {
@@ -331,7 +212,12 @@ var Tasks = struct {
 		return nil
 	}),
 
+	// Material Task
+	BakeTextureTransform: FnPostTask("Bake Texture Transform", _BakeTextureTransform),
+	// Mesh Task
+	UnpackDraco: FnPostTask("Unpack Draco", _UnpackDraco),
 	// Accessor Task
+	Dequantize: FnPostTask("Dequantize", _Dequantize),
 	TightPacking: FnPostTask("TightPacking", func(parser *parserContext, gltf *GLTF, logger *glog.Glogger) error {
 		// TODO not yet tested
 		// Condition check
@@ -369,7 +255,7 @@ var Tasks = struct {
 		}
 		//
 		for i, v := range gltf.Accessors {
-			if v.BufferView.ByteStride != 0{
+			if v.BufferView != nil && v.BufferView.ByteStride != 0{
 				if len(v.Name) > 0{
 					logger.Println("gltf.Accessor['%s']", v.Name)
 				}else {
@@ -618,36 +504,56 @@ func recurMinMax(node *Node, mtx mgl32.Mat4, min, max *mgl32.Vec3) {
 	mtx = mtx.Mul4(node.Transform())
 	//
 	if node.Mesh != nil {
-		for _, prim := range node.Mesh.Primitives {
-			posattr := prim.Attributes[POSITION]
-			var (
-				tempmin mgl32.Vec3
-				tempmax mgl32.Vec3
-			)
-			if len(posattr.Min) < 0 || len(posattr.Max) < 0 {
-				//TODO
-				panic("TODO")
-			} else {
-				tempmin = mtx.Mul4x1(mgl32.Vec3{posattr.Min[0], posattr.Min[1], posattr.Min[2]}.Vec4(1)).Vec3()
-				tempmax = mtx.Mul4x1(mgl32.Vec3{posattr.Max[0], posattr.Max[1], posattr.Max[2]}.Vec4(1)).Vec3()
+		mtxs := []mgl32.Mat4{mtx}
+		// EXTMeshGPUInstancing, mesh is drawn for every instance
+		if instances, err := instanceTransforms(node); err == nil && instances != nil {
+			mtxs = mtxs[:0]
+			for _, instance := range instances {
+				mtxs = append(mtxs, mtx.Mul4(instance))
 			}
-			if tempmin[0] < min[0] {
-				min[0] = tempmin[0]
-			}
-			if tempmin[1] < min[1] {
-				min[1] = tempmin[1]
-			}
-			if tempmin[2] < min[2] {
-				min[2] = tempmin[2]
-			}
-			if tempmax[0] > max[0] {
-				max[0] = tempmax[0]
+		}
+		for _, prim := range node.Mesh.Primitives {
+			posattr, ok := prim.Attributes[POSITION]
+			if !ok || posattr == nil {
+				continue
 			}
-			if tempmax[1] > max[1] {
-				max[1] = tempmax[1]
+			var points []mgl32.Vec3
+			if len(posattr.Min) < 3 || len(posattr.Max) < 3 {
+				// no bounds, every position is transformed
+				positions, err := ReadVec3f(posattr)
+				if err != nil {
+					continue
+				}
+				points = positions
+			} else {
+				// corners of bounds, bounds of normalized accessor is raw integer
+				for i := 0; i < 8; i++ {
+					var p mgl32.Vec3
+					for j := range p {
+						if i>>uint(j)&1 == 0 {
+							p[j] = posattr.Min[j]
+						} else {
+							p[j] = posattr.Max[j]
+						}
+						if posattr.Normalized {
+							p[j] = normalizeComponent(posattr.ComponentType, float64(p[j]))
+						}
+					}
+					points = append(points, p)
+				}
 			}
-			if tempmax[2] > max[2] {
-				max[2] = tempmax[2]
+			for _, m := range mtxs {
+				for _, p := range points {
+					p = m.Mul4x1(p.Vec4(1)).Vec3()
+					for j := range p {
+						if p[j] < min[j] {
+							min[j] = p[j]
+						}
+						if p[j] > max[j] {
+							max[j] = p[j]
+						}
+					}
+				}
 			}
 		}
 	}
@@ -0,0 +1,43 @@
+package gltf2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestMinMaxNormalizedPosition(t *testing.T) {
+	g, err := Parser().Reader(strings.NewReader(`{"asset":{"version":"2.0"}}`)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prim := &MeshPrimitive{}
+	accessor, err := Builder(g, nil).Normalized().Attribute(prim, POSITION, [][3]int16{{0, 0, 0}, {32767, 0, 0}, {0, -32767, 16384}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accessor.Min) != 3 || accessor.Max[0] != 32767 {
+		t.Fatalf("Accessor.Min %v, Accessor.Max %v must be raw integer", accessor.Min, accessor.Max)
+	}
+	node := &Node{
+		Mesh:        &Mesh{Primitives: []*MeshPrimitive{prim}},
+		Matrix:      mgl32.Ident4(),
+		Scale:       mgl32.Vec3{2, 2, 2},
+		Rotation:    mgl32.QuatIdent(),
+		Translation: mgl32.Vec3{1, 0, 0},
+	}
+	min, max, ok := uMinMax(node)
+	if !ok {
+		t.Fatal("bounds not found")
+	}
+	expectedMin, expectedMax := mgl32.Vec3{1, -2, 0}, mgl32.Vec3{3, 0, 2 * 16384. / 32767.}
+	if !min.ApproxEqualThreshold(expectedMin, 1e-6) || !max.ApproxEqualThreshold(expectedMax, 1e-6) {
+		t.Errorf("bounds %v %v, expected %v %v", min, max, expectedMin, expectedMax)
+	}
+	// bounds from every position is same
+	accessor.Min, accessor.Max = nil, nil
+	if min2, max2, _ := uMinMax(node); min2 != min || max2 != max {
+		t.Errorf("bounds without Accessor.Min, Accessor.Max %v %v, expected %v %v", min2, max2, min, max)
+	}
+}
@@ -0,0 +1,220 @@
+package gltf2
+
+import (
+	"encoding/json"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/pkg/errors"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Vendor/EXT_mesh_gpu_instancing
+//
+// It is extension of Node, Node.Mesh is drawn once per instance with transform of TRANSLATION, ROTATION, SCALE
+// Instance transform is applied before Node transform, see Transforms
+// Custom attribute starts with '_', ex) _ID
+type EXTMeshGPUInstancing struct {
+	Attributes map[AttributeKey]*Accessor
+}
+
+const (
+	INSTANCE_TRANSLATION AttributeKey = "TRANSLATION"
+	INSTANCE_ROTATION    AttributeKey = "ROTATION"
+	INSTANCE_SCALE       AttributeKey = "SCALE"
+)
+
+func (s *EXTMeshGPUInstancing) ExtensionName() string {
+	return "EXT_mesh_gpu_instancing"
+}
+func (s *EXTMeshGPUInstancing) ExtensionSchemes() []string {
+	return []string{SCHEME_NODE}
+}
+func (s *EXTMeshGPUInstancing) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecEXTMeshGPUInstancing)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *EXTMeshGPUInstancing) Encode(ctx *encoderContext) (interface{}, error) {
+	return &SpecEXTMeshGPUInstancing{
+		Attributes: ctx.attributes(s.Attributes),
+	}, nil
+}
+
+// Count is number of instance, every attribute has same count
+func (s *EXTMeshGPUInstancing) Count() int {
+	for _, accessor := range s.Attributes {
+		if accessor != nil {
+			return accessor.Count
+		}
+	}
+	return 0
+}
+
+// Transforms is local matrix of every instance, T * R * S
+//
+// Undefined attribute is identity, world matrix of instance is (node world matrix) * Transforms()[i]
+func (s *EXTMeshGPUInstancing) Transforms() ([]mgl32.Mat4, error) {
+	var (
+		count                = s.Count()
+		translations, scales []mgl32.Vec3
+		rotations            []mgl32.Vec4
+		err                  error
+	)
+	if accessor := s.Attributes[INSTANCE_TRANSLATION]; accessor != nil {
+		if translations, err = ReadVec3f(accessor); err != nil {
+			return nil, errors.WithMessage(err, "EXTMeshGPUInstancing TRANSLATION")
+		}
+	}
+	if accessor := s.Attributes[INSTANCE_ROTATION]; accessor != nil {
+		if rotations, err = ReadVec4f(accessor); err != nil {
+			return nil, errors.WithMessage(err, "EXTMeshGPUInstancing ROTATION")
+		}
+	}
+	if accessor := s.Attributes[INSTANCE_SCALE]; accessor != nil {
+		if scales, err = ReadVec3f(accessor); err != nil {
+			return nil, errors.WithMessage(err, "EXTMeshGPUInstancing SCALE")
+		}
+	}
+	res := make([]mgl32.Mat4, count)
+	for i := range res {
+		res[i] = mgl32.Ident4()
+		if i < len(translations) {
+			res[i] = mgl32.Translate3D(translations[i][0], translations[i][1], translations[i][2])
+		}
+		if i < len(rotations) {
+			r := rotations[i]
+			res[i] = res[i].Mul4(mgl32.Quat{W: r[3], V: r.Vec3()}.Normalize().Mat4())
+		}
+		if i < len(scales) {
+			res[i] = res[i].Mul4(mgl32.Scale3D(scales[i][0], scales[i][1], scales[i][2]))
+		}
+	}
+	return res, nil
+}
+
+type SpecEXTMeshGPUInstancing struct {
+	Attributes map[AttributeKey]SpecGLTFID `json:"attributes"` // required, minProperties(1)
+}
+
+func (s *SpecEXTMeshGPUInstancing) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecEXTMeshGPUInstancing) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	g, _ := root.(*SpecGLTF)
+	switch strictness {
+	case LEVEL3:
+		fallthrough
+	case LEVEL2:
+		issues.nodeExtension(parent, "EXTMeshGPUInstancing")
+		if node, ok := parent.(*SpecNode); ok && node.Mesh == nil {
+			issues.add(LEVEL2, CODE_UNSATISFIED_DEPENDENCY, "", "EXTMeshGPUInstancing need Node.Mesh")
+		}
+		if g != nil {
+			quantized := g.usesExtension(new(KHRMeshQuantization).ExtensionName())
+			count := -1
+			for _, k := range sortedAttributeKeys(s.Attributes) {
+				id := s.Attributes[k]
+				pointer := "/attributes/" + escapePointer(string(k))
+				if !inRange(id, len(g.Accessors)) {
+					continue
+				}
+				issues.instanceFormat(g.Accessors[id], k, quantized, pointer)
+				if g.Accessors[id].Count != nil {
+					if count >= 0 && *g.Accessors[id].Count != count {
+						issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, pointer, "EXTMeshGPUInstancing.Attributes must have same count, %d != %d", *g.Accessors[id].Count, count)
+					}
+					count = *g.Accessors[id].Count
+				}
+			}
+		}
+		fallthrough
+	case LEVEL1:
+		if len(s.Attributes) == 0 {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/attributes", "EXTMeshGPUInstancing.Attributes required")
+		}
+		if g != nil {
+			for _, k := range sortedAttributeKeys(s.Attributes) {
+				id := s.Attributes[k]
+				issues.reference(&id, len(g.Accessors), "/attributes/"+escapePointer(string(k)), "EXTMeshGPUInstancing.Attributes")
+			}
+		}
+	}
+	return issues.Err()
+}
+
+// instanceFormat add issue if TRANSLATION, ROTATION, SCALE accessor has not allowed type or component type
+func (s *Issues) instanceFormat(accessor SpecAccessor, key AttributeKey, quantized bool, pointer string) {
+	if accessor.Type == nil || accessor.ComponentType == nil {
+		return
+	}
+	var (
+		types   []AccessorType
+		formats = []AttributeFormat{{ComponentType: FLOAT}}
+	)
+	switch key {
+	case INSTANCE_TRANSLATION, INSTANCE_SCALE:
+		types = []AccessorType{VEC3}
+		if quantized {
+			formats = append(formats,
+				AttributeFormat{ComponentType: BYTE}, AttributeFormat{ComponentType: BYTE, Normalized: true},
+				AttributeFormat{ComponentType: SHORT}, AttributeFormat{ComponentType: SHORT, Normalized: true},
+			)
+		}
+	case INSTANCE_ROTATION:
+		types = []AccessorType{VEC4}
+		formats = append(formats, AttributeFormat{ComponentType: BYTE, Normalized: true}, AttributeFormat{ComponentType: SHORT, Normalized: true})
+	default:
+		if !key.IsCustom() {
+			s.add(LEVEL2, CODE_VALUE_NOT_IN_LIST, pointer, "EXTMeshGPUInstancing attribute '%s' allow(TRANSLATION, ROTATION, SCALE, _*)", key)
+		}
+		return
+	}
+	format := AttributeFormat{ComponentType: *accessor.ComponentType}
+	if accessor.Normalized != nil {
+		format.Normalized = *accessor.Normalized
+	}
+	var typeOk, formatOk bool
+	for _, v := range types {
+		typeOk = typeOk || v == *accessor.Type
+	}
+	for _, v := range formats {
+		formatOk = formatOk || v == format
+	}
+	if !typeOk || !formatOk {
+		s.add(LEVEL2, CODE_VALUE_NOT_IN_LIST, pointer, "EXTMeshGPUInstancing attribute '%s' allow(%v of %v), but got '%s of %s'", key, types, formats, *accessor.Type, format)
+	}
+}
+func (s *SpecEXTMeshGPUInstancing) To(ctx *parserContext) interface{} {
+	res := new(EXTMeshGPUInstancing)
+	if s.Attributes != nil {
+		res.Attributes = make(map[AttributeKey]*Accessor)
+	}
+	return res
+}
+func (s *SpecEXTMeshGPUInstancing) Link(Root *GLTF, parent interface{}, dst interface{}) error {
+	res := dst.(*EXTMeshGPUInstancing)
+	for k, v := range s.Attributes {
+		if !inRange(v, len(Root.Accessors)) {
+			return errors.Errorf("EXTMeshGPUInstancing.Attributes['%s'] linking fail, %s", k, v)
+		}
+		res.Attributes[k] = Root.Accessors[v]
+	}
+	return nil
+}
+
+// instanceTransforms is instance matrices of node, nil if node has no EXTMeshGPUInstancing
+func instanceTransforms(node *Node) ([]mgl32.Mat4, error) {
+	if node.Extensions == nil {
+		return nil, nil
+	}
+	ext, ok := node.Extensions.Get(new(EXTMeshGPUInstancing)).(*EXTMeshGPUInstancing)
+	if !ok {
+		return nil, nil
+	}
+	return ext.Transforms()
+}
+
+func init() {
+	RegisterExtension(new(EXTMeshGPUInstancing))
+}
@@ -0,0 +1,108 @@
+package gltf2
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// meshGPUInstancingTestGLTF is one node of 2 instances, second one is translated by 10 and rotated 90 degree around Y
+func meshGPUInstancingTestGLTF(t *testing.T) *GLTF {
+	g, err := Parser().Reader(strings.NewReader(`{"asset":{"version":"2.0"}}`)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prim := &MeshPrimitive{Mode: TRIANGLES}
+	g.Meshes = append(g.Meshes, &Mesh{Primitives: []*MeshPrimitive{prim}})
+	b := Builder(g, nil)
+	if _, err := b.Attribute(prim, POSITION, []mgl32.Vec3{{0, 0, 0}, {1, 1, 1}}); err != nil {
+		t.Fatal(err)
+	}
+	translation, err := b.Accessor([]mgl32.Vec3{{0, 0, 0}, {10, 0, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// normalized SHORT quaternion
+	rotation, err := b.Normalized().Accessor([][4]int16{{0, 0, 0, 32767}, {0, 23170, 0, 23170}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ext := &EXTMeshGPUInstancing{Attributes: map[AttributeKey]*Accessor{INSTANCE_TRANSLATION: translation, INSTANCE_ROTATION: rotation}}
+	node := &Node{Mesh: g.Meshes[0], Scale: mgl32.Vec3{1, 1, 1}, Rotation: mgl32.QuatIdent(), Matrix: mgl32.Ident4(), Extensions: &Extensions{ext.ExtensionName(): ext}}
+	g.Nodes = append(g.Nodes, node)
+	g.Scenes = append(g.Scenes, &Scene{Nodes: []*Node{node}})
+	g.ExtensionsUsed = append(g.ExtensionsUsed, ext.ExtensionName())
+	return g
+}
+
+// meshGPUInstancingTestCheck check instances of meshGPUInstancingTestGLTF
+func meshGPUInstancingTestCheck(t *testing.T, g *GLTF) {
+	node := g.Nodes[0]
+	ext, ok := node.Extensions.Get(new(EXTMeshGPUInstancing)).(*EXTMeshGPUInstancing)
+	if !ok || ext.Count() != 2 {
+		t.Fatalf("EXT_mesh_gpu_instancing %v", ext)
+	}
+	if _, ok := ext.Attributes[INSTANCE_SCALE]; ok {
+		t.Error("undefined SCALE attribute exists")
+	}
+	mtxs, err := ext.Transforms()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mtxs) != 2 || !mtxs[0].ApproxEqualThreshold(mgl32.Ident4(), 1e-3) {
+		t.Fatalf("transforms %v", mtxs)
+	}
+	if p := mtxs[1].Mul4x1(mgl32.Vec4{1, 0, 0, 1}); !p.ApproxEqualThreshold(mgl32.Vec4{10, 0, -1, 1}, 1e-3) {
+		t.Errorf("second instance transform (1, 0, 0) to %v, expected (10, 0, -1)", p)
+	}
+	// bounds include every instance
+	min, max, ok := uMinMax(node)
+	if !ok || !min.ApproxEqualThreshold(mgl32.Vec3{0, 0, -1}, 1e-3) || !max.ApproxEqualThreshold(mgl32.Vec3{11, 1, 1}, 1e-3) {
+		t.Errorf("bounds %v %v, expected (0, 0, -1) (11, 1, 1)", min, max)
+	}
+}
+
+func TestEXTMeshGPUInstancing(t *testing.T) {
+	g := meshGPUInstancingTestGLTF(t)
+	meshGPUInstancingTestCheck(t, g)
+	var out bytes.Buffer
+	if err := Encoder(&out).DataURI().Encode(g); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := Parser().Extensions(new(EXTMeshGPUInstancing)).Reader(bytes.NewReader(out.Bytes())).Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("issues %v", issues)
+	}
+	g2, err := Parser().Extensions(new(EXTMeshGPUInstancing)).Reader(&out).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	meshGPUInstancingTestCheck(t, g2)
+}
+
+func TestEXTMeshGPUInstancingIssues(t *testing.T) {
+	var out bytes.Buffer
+	if err := Encoder(&out).DataURI().Encode(meshGPUInstancingTestGLTF(t)); err != nil {
+		t.Fatal(err)
+	}
+	js := out.String()
+	for suffix, bad := range map[string]string{
+		// unknown attribute
+		"/extensions/EXT_mesh_gpu_instancing/attributes/FOO": strings.Replace(js, `"TRANSLATION":`, `"FOO":`, 1),
+		// node without mesh
+		"/nodes/0/extensions/EXT_mesh_gpu_instancing": strings.Replace(js, `"mesh":0,`, ``, 1),
+	} {
+		issues, err := Parser().Extensions(new(EXTMeshGPUInstancing)).Reader(strings.NewReader(bad)).Validate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(issues) != 1 || !strings.HasSuffix(issues[0].Pointer, suffix) {
+			t.Errorf("issues %v, expected one issue of '%s'", issues, suffix)
+		}
+	}
+}
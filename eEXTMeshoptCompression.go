@@ -0,0 +1,325 @@
+package gltf2
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Vendor/EXT_meshopt_compression
+//
+// BufferView level extension has compressed data, BufferView.Load return decoded data of it.
+// Buffer level extension only has Fallback, fallback buffer may not have data.
+type EXTMeshoptCompression struct {
+	Buffer     *Buffer // nil for buffer level
+	ByteOffset int     // default 0
+	ByteLength int
+	ByteStride int
+	Count      int
+	Mode       EXTMeshoptMode
+	Filter     EXTMeshoptFilter // default EXTMeshoptFilterNone
+	// buffer level
+	Fallback bool
+	// decoded data
+	cache []byte
+}
+
+func (s *EXTMeshoptCompression) ExtensionName() string {
+	return "EXT_meshopt_compression"
+}
+func (s *EXTMeshoptCompression) ExtensionSchemes() []string {
+	return []string{SCHEME_BUFFER, SCHEME_BUFFERVIEW}
+}
+func (s *EXTMeshoptCompression) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecEXTMeshoptCompression)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *EXTMeshoptCompression) Encode(ctx *encoderContext) (interface{}, error) {
+	if s.Buffer == nil {
+		res := new(SpecEXTMeshoptCompression)
+		if s.Fallback {
+			res.Fallback = &s.Fallback
+		}
+		return res, nil
+	}
+	offset, err := ctx.bufferOffset(s.Buffer)
+	if err != nil {
+		return nil, err
+	}
+	mode := s.Mode
+	res := &SpecEXTMeshoptCompression{
+		Buffer:     ctx.Buffer(s.Buffer),
+		ByteOffset: optInt(offset+s.ByteOffset, 0),
+		ByteLength: intPtr(s.ByteLength),
+		ByteStride: intPtr(s.ByteStride),
+		Count:      intPtr(s.Count),
+		Mode:       &mode,
+	}
+	if s.Filter != EXTMeshoptFilterNone {
+		filter := s.Filter
+		res.Filter = &filter
+	}
+	return res, nil
+}
+
+// Load decode compressed data, decoded data is cached
+func (s *EXTMeshoptCompression) Load() ([]byte, error) {
+	if s.cache != nil {
+		return s.cache, nil
+	}
+	if s.Buffer == nil {
+		return nil, errors.New("EXTMeshoptCompression.Buffer nil, it is buffer level extension")
+	}
+	bts, err := s.Buffer.Load(false)
+	if err != nil {
+		return nil, err
+	}
+	if s.ByteOffset < 0 || s.ByteLength < 0 || s.ByteOffset+s.ByteLength > len(bts) {
+		return nil, errors.Errorf("EXTMeshoptCompression need %d bytes from Buffer, but Buffer is %d bytes", s.ByteOffset+s.ByteLength, len(bts))
+	}
+	if s.cache, err = decodeMeshopt(bts[s.ByteOffset:s.ByteOffset+s.ByteLength], s.Count, s.ByteStride, s.Mode, s.Filter); err != nil {
+		return nil, err
+	}
+	return s.cache, nil
+}
+
+// meshoptFallback report buffer is fallback buffer which has no data
+//
+// Encoder write only its byteLength, and it is not merged to glb BIN chunk.
+func meshoptFallback(buffer *Buffer) bool {
+	if buffer == nil || buffer.URI != nil || buffer.embedded != nil || buffer.IsCached() || buffer.ByteLength == nil || buffer.Extensions == nil {
+		return false
+	}
+	ext, ok := buffer.Extensions.Get(new(EXTMeshoptCompression)).(*EXTMeshoptCompression)
+	return ok && ext.Fallback
+}
+
+type EXTMeshoptMode uint8
+
+const (
+	EXTMeshoptModeAttributes EXTMeshoptMode = iota
+	EXTMeshoptModeTriangles  EXTMeshoptMode = iota
+	EXTMeshoptModeIndices    EXTMeshoptMode = iota
+)
+
+func (s EXTMeshoptMode) String() string {
+	switch s {
+	case EXTMeshoptModeAttributes:
+		return "ATTRIBUTES"
+	case EXTMeshoptModeTriangles:
+		return "TRIANGLES"
+	case EXTMeshoptModeIndices:
+		return "INDICES"
+	}
+	return "nil"
+}
+func (s *EXTMeshoptMode) MarshalJSON() ([]byte, error) {
+	if s.String() == "nil" {
+		return nil, errors.New("EXTMeshoptMode invalid")
+	}
+	return json.Marshal(s.String())
+}
+func (s *EXTMeshoptMode) UnmarshalJSON(data []byte) error {
+	var v string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return errors.WithMessage(ErrorJSON, err.Error())
+	}
+	switch v {
+	case "ATTRIBUTES":
+		*s = EXTMeshoptModeAttributes
+	case "TRIANGLES":
+		*s = EXTMeshoptModeTriangles
+	case "INDICES":
+		*s = EXTMeshoptModeIndices
+	default:
+		return errors.WithMessage(ErrorEnum, fmt.Sprintf("'%s' is invalid EXTMeshoptMode", v))
+	}
+	return nil
+}
+
+type EXTMeshoptFilter uint8
+
+const (
+	EXTMeshoptFilterNone        EXTMeshoptFilter = iota
+	EXTMeshoptFilterOctahedral  EXTMeshoptFilter = iota
+	EXTMeshoptFilterQuaternion  EXTMeshoptFilter = iota
+	EXTMeshoptFilterExponential EXTMeshoptFilter = iota
+)
+
+func (s EXTMeshoptFilter) String() string {
+	switch s {
+	case EXTMeshoptFilterNone:
+		return "NONE"
+	case EXTMeshoptFilterOctahedral:
+		return "OCTAHEDRAL"
+	case EXTMeshoptFilterQuaternion:
+		return "QUATERNION"
+	case EXTMeshoptFilterExponential:
+		return "EXPONENTIAL"
+	}
+	return "nil"
+}
+func (s *EXTMeshoptFilter) MarshalJSON() ([]byte, error) {
+	if s.String() == "nil" {
+		return nil, errors.New("EXTMeshoptFilter invalid")
+	}
+	return json.Marshal(s.String())
+}
+func (s *EXTMeshoptFilter) UnmarshalJSON(data []byte) error {
+	var v string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return errors.WithMessage(ErrorJSON, err.Error())
+	}
+	switch v {
+	case "NONE":
+		*s = EXTMeshoptFilterNone
+	case "OCTAHEDRAL":
+		*s = EXTMeshoptFilterOctahedral
+	case "QUATERNION":
+		*s = EXTMeshoptFilterQuaternion
+	case "EXPONENTIAL":
+		*s = EXTMeshoptFilterExponential
+	default:
+		return errors.WithMessage(ErrorEnum, fmt.Sprintf("'%s' is invalid EXTMeshoptFilter", v))
+	}
+	return nil
+}
+
+type SpecEXTMeshoptCompression struct {
+	Buffer     *SpecGLTFID       `json:"buffer,omitempty"`     // bufferView level, required
+	ByteOffset *int              `json:"byteOffset,omitempty"` // default 0, minimum(0)
+	ByteLength *int              `json:"byteLength,omitempty"` // bufferView level, required, minimum(1)
+	ByteStride *int              `json:"byteStride,omitempty"` // bufferView level, required, range(1, 256)
+	Count      *int              `json:"count,omitempty"`      // bufferView level, required, minimum(1)
+	Mode       *EXTMeshoptMode   `json:"mode,omitempty"`       // bufferView level, required
+	Filter     *EXTMeshoptFilter `json:"filter,omitempty"`     // default NONE
+	Fallback   *bool             `json:"fallback,omitempty"`   // buffer level, default false
+}
+
+func (s *SpecEXTMeshoptCompression) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecEXTMeshoptCompression) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	view, isView := parent.(*SpecBufferView)
+	switch strictness {
+	case LEVEL3:
+		if s.ByteOffset != nil && *s.ByteOffset < 0 {
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/byteOffset", "EXTMeshoptCompression.ByteOffset minimum(0), but got '%d'", *s.ByteOffset)
+		}
+		if s.ByteLength != nil && *s.ByteLength < 1 {
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/byteLength", "EXTMeshoptCompression.ByteLength minimum(1), but got '%d'", *s.ByteLength)
+		}
+		if s.ByteStride != nil && (*s.ByteStride < 1 || *s.ByteStride > 256) {
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/byteStride", "EXTMeshoptCompression.ByteStride range(1, 256), but got '%d'", *s.ByteStride)
+		}
+		if s.Count != nil && *s.Count < 1 {
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/count", "EXTMeshoptCompression.Count minimum(1), but got '%d'", *s.Count)
+		}
+		fallthrough
+	case LEVEL2:
+		switch parent.(type) {
+		case *SpecBuffer:
+			if s.Buffer != nil || s.Mode != nil {
+				issues.add(LEVEL2, CODE_UNDEFINED_PROPERTY, "", "EXTMeshoptCompression of buffer only has fallback")
+			}
+		case *SpecBufferView:
+		default:
+			issues.add(LEVEL2, CODE_UNDEFINED_PROPERTY, "", "EXTMeshoptCompression is extension of buffer, bufferView, but it is in '%s'", parent.Scheme())
+		}
+		if isView && s.ByteStride != nil && s.Mode != nil {
+			stride := *s.ByteStride
+			switch *s.Mode {
+			case EXTMeshoptModeAttributes:
+				if stride%4 != 0 {
+					issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/byteStride", "EXTMeshoptCompression.ByteStride of ATTRIBUTES is multiple of 4, but got '%d'", stride)
+				}
+			case EXTMeshoptModeTriangles, EXTMeshoptModeIndices:
+				if stride != 2 && stride != 4 {
+					issues.add(LEVEL2, CODE_VALUE_NOT_IN_LIST, "/byteStride", "EXTMeshoptCompression.ByteStride of %s is 2 or 4, but got '%d'", *s.Mode, stride)
+				}
+				if *s.Mode == EXTMeshoptModeTriangles && s.Count != nil && *s.Count%3 != 0 {
+					issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/count", "EXTMeshoptCompression.Count of TRIANGLES is multiple of 3, but got '%d'", *s.Count)
+				}
+			}
+			if s.Filter != nil && *s.Filter != EXTMeshoptFilterNone {
+				switch {
+				case *s.Mode != EXTMeshoptModeAttributes:
+					issues.add(LEVEL2, CODE_VALUE_NOT_IN_LIST, "/filter", "EXTMeshoptCompression.Filter is only for ATTRIBUTES")
+				case *s.Filter == EXTMeshoptFilterOctahedral && stride != 4 && stride != 8:
+					issues.add(LEVEL2, CODE_VALUE_NOT_IN_LIST, "/filter", "EXTMeshoptCompression.Filter OCTAHEDRAL need byteStride 4 or 8, but got '%d'", stride)
+				case *s.Filter == EXTMeshoptFilterQuaternion && stride != 8:
+					issues.add(LEVEL2, CODE_VALUE_NOT_IN_LIST, "/filter", "EXTMeshoptCompression.Filter QUATERNION need byteStride 8, but got '%d'", stride)
+				}
+			}
+			if s.Count != nil && view.ByteLength != nil && *view.ByteLength != *s.Count*stride {
+				issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/count", "EXTMeshoptCompression count * byteStride is %d, but BufferView.ByteLength is '%d'", *s.Count*stride, *view.ByteLength)
+			}
+		}
+		fallthrough
+	case LEVEL1:
+		if isView {
+			if s.Buffer == nil {
+				issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/buffer", "EXTMeshoptCompression.Buffer required")
+			}
+			if s.ByteLength == nil {
+				issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/byteLength", "EXTMeshoptCompression.ByteLength required")
+			}
+			if s.ByteStride == nil {
+				issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/byteStride", "EXTMeshoptCompression.ByteStride required")
+			}
+			if s.Count == nil {
+				issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/count", "EXTMeshoptCompression.Count required")
+			}
+			if s.Mode == nil {
+				issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/mode", "EXTMeshoptCompression.Mode required")
+			}
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.Buffer, len(g.Buffers), "/buffer", "EXTMeshoptCompression.Buffer")
+		}
+	}
+	return issues.Err()
+}
+func (s *SpecEXTMeshoptCompression) To(ctx *parserContext) interface{} {
+	res := new(EXTMeshoptCompression)
+	if s.ByteOffset != nil {
+		res.ByteOffset = *s.ByteOffset
+	}
+	if s.ByteLength != nil {
+		res.ByteLength = *s.ByteLength
+	}
+	if s.ByteStride != nil {
+		res.ByteStride = *s.ByteStride
+	}
+	if s.Count != nil {
+		res.Count = *s.Count
+	}
+	if s.Mode != nil {
+		res.Mode = *s.Mode
+	}
+	if s.Filter != nil {
+		res.Filter = *s.Filter
+	}
+	if s.Fallback != nil {
+		res.Fallback = *s.Fallback
+	}
+	return res
+}
+func (s *SpecEXTMeshoptCompression) Link(Root *GLTF, parent interface{}, dst interface{}) error {
+	if s.Buffer == nil {
+		return nil
+	}
+	if !inRange(*s.Buffer, len(Root.Buffers)) {
+		return errors.Errorf("EXTMeshoptCompression.Buffer linking fail, %s", *s.Buffer)
+	}
+	dst.(*EXTMeshoptCompression).Buffer = Root.Buffers[*s.Buffer]
+	return nil
+}
+
+func init() {
+	RegisterExtension(new(EXTMeshoptCompression))
+}
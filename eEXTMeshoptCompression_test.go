@@ -0,0 +1,53 @@
+package gltf2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMeshoptEncodeFallback(t *testing.T) {
+	compressed, _ := meshoptTestDocuments()
+	parse := func(r *bytes.Reader) *GLTF {
+		g, err := Parser().Reader(r).Extensions(new(EXTMeshoptCompression)).Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return g
+	}
+	src := parse(bytes.NewReader([]byte(compressed)))
+	fallback := *src.Buffers[1].ByteLength
+	for name, glb := range map[string]bool{"data-uri": false, "glb": true} {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			enc := Encoder(&out).DataURI()
+			if glb {
+				enc = Encoder(&out).GLB()
+			}
+			if err := enc.Encode(src); err != nil {
+				t.Fatal(err)
+			}
+			js := out.Bytes()
+			if glb {
+				js = js[20 : 20+binary.LittleEndian.Uint32(js[12:])]
+			}
+			res := new(SpecGLTF)
+			if err := json.Unmarshal(js, res); err != nil {
+				t.Fatal(err)
+			}
+			// BIN chunk or compressed data is buffers[0], fallback is written without data
+			if len(res.Buffers) != 2 || res.Buffers[1].URI != nil || *res.Buffers[1].ByteLength != fallback {
+				t.Fatalf("buffers %s, expected fallback buffer without uri", js)
+			}
+			if !strings.Contains(string(js), `"fallback":true`) {
+				t.Errorf("fallback extension is not written, %s", js)
+			}
+			if out.Len() > len(compressed) {
+				t.Errorf("output %d bytes is larger than source %d bytes", out.Len(), len(compressed))
+			}
+			meshoptTestCompare(t, parse(bytes.NewReader(out.Bytes())))
+		})
+	}
+}
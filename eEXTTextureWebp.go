@@ -0,0 +1,76 @@
+package gltf2
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Vendor/EXT_texture_webp
+//
+// It is extension of Texture, Source is WebP image used instead of Texture.Source
+type EXTTextureWebp struct {
+	Source Image
+}
+
+func (s *EXTTextureWebp) ExtensionName() string {
+	return "EXT_texture_webp"
+}
+func (s *EXTTextureWebp) ExtensionSchemes() []string {
+	return []string{SCHEME_TEXTURE}
+}
+func (s *EXTTextureWebp) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecEXTTextureWebp)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *EXTTextureWebp) Encode(ctx *encoderContext) (interface{}, error) {
+	return &SpecEXTTextureWebp{
+		Source: ctx.Image(s.Source),
+	}, nil
+}
+func (s *EXTTextureWebp) source() Image {
+	return s.Source
+}
+
+type SpecEXTTextureWebp struct {
+	Source *SpecGLTFID `json:"source"` // required
+}
+
+func (s *SpecEXTTextureWebp) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecEXTTextureWebp) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		fallthrough
+	case LEVEL2:
+		issues.textureExtension(parent, "EXTTextureWebp")
+		issues.imageMimeType(root, s.Source, ImageWebP, "/source", "EXTTextureWebp.Source")
+		fallthrough
+	case LEVEL1:
+		if s.Source == nil {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/source", "EXTTextureWebp.Source required")
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.Source, len(g.Images), "/source", "EXTTextureWebp.Source")
+		}
+	}
+	return issues.Err()
+}
+func (s *SpecEXTTextureWebp) To(ctx *parserContext) interface{} {
+	return new(EXTTextureWebp)
+}
+func (s *SpecEXTTextureWebp) Link(Root *GLTF, parent interface{}, dst interface{}) error {
+	if !inRange(*s.Source, len(Root.Images)) {
+		return errors.Errorf("EXTTextureWebp.Source linking fail, %s", *s.Source)
+	}
+	dst.(*EXTTextureWebp).Source = Root.Images[*s.Source]
+	return nil
+}
+
+func init() {
+	RegisterExtension(new(EXTTextureWebp))
+}
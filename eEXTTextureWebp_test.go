@@ -0,0 +1,105 @@
+package gltf2
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+// textureWebpTestData is 1x1 lossy WebP
+const textureWebpTestData = "UklGRiIAAABXRUJQVlA4IBYAAAAwAQCdASoBAAEADsD+JaQAA3AAAAAA"
+
+// textureSourceTestJSON is textures of alternate sources
+//
+// texture 0 : broken png, webp
+// texture 1 : dds only
+// texture 2 : dds, broken png as webp
+// texture 3 : no image
+func textureSourceTestJSON() string {
+	uri := func(mime string, data string) string {
+		return "data:" + mime + ";base64," + data
+	}
+	return `{"asset":{"version":"2.0"},"extensionsUsed":["EXT_texture_webp","MSFT_texture_dds"],
+	"images":[
+		{"uri":"` + uri("image/webp", textureWebpTestData) + `"},
+		{"uri":"` + uri("image/vnd-ms.dds", base64.StdEncoding.EncodeToString(ddsTestDXT1)) + `"},
+		{"uri":"` + uri("image/png", base64.StdEncoding.EncodeToString([]byte("broken"))) + `"}
+	],
+	"textures":[
+		{"source":2,"extensions":{"EXT_texture_webp":{"source":0}}},
+		{"extensions":{"MSFT_texture_dds":{"source":1}}},
+		{"source":1,"extensions":{"EXT_texture_webp":{"source":2}}},
+		{}
+	]}`
+}
+
+func textureSourceTestParser(src string) *parser {
+	return Parser().Extensions(new(EXTTextureWebp), new(MSFTTextureDDS)).Reader(strings.NewReader(src))
+}
+
+// textureSourceTestCheck check textures of textureSourceTestJSON
+func textureSourceTestCheck(t *testing.T, g *GLTF) {
+	webp, ok := g.Textures[0].Extensions.Get(new(EXTTextureWebp)).(*EXTTextureWebp)
+	if !ok || webp.Source != g.Images[0] {
+		t.Errorf("texture 0 EXT_texture_webp source is not images[0]")
+	}
+	dds, ok := g.Textures[1].Extensions.Get(new(MSFTTextureDDS)).(*MSFTTextureDDS)
+	if !ok || dds.Source != g.Images[1] || g.Textures[1].Source != nil {
+		t.Errorf("texture 1 MSFT_texture_dds source is not images[1]")
+	}
+	if images := g.Textures[0].Images(); len(images) != 2 || images[0] != g.Images[0] || images[1] != g.Images[2] {
+		t.Errorf("texture 0 images %v, alternate source must be first", images)
+	}
+	// alternate source is preferred
+	if img, err := g.Textures[0].Load(false); err != nil || img.Rect.Dx() != 1 {
+		t.Errorf("texture 0 %v, expected 1x1 webp", err)
+	}
+	if img, err := g.Textures[1].Load(false); err != nil || img.Rect.Dx() != 4 {
+		t.Errorf("texture 1 %v, expected 4x4 dds", err)
+	}
+	// alternate source fail, fallback to Texture.Source
+	if img, err := g.Textures[2].Load(false); err != nil || img.Rect.Dx() != 4 {
+		t.Errorf("texture 2 %v, expected 4x4 dds", err)
+	}
+	if _, err := g.Textures[3].Load(false); err == nil {
+		t.Error("texture 3 has no image, but loaded")
+	}
+}
+
+func TestTextureAlternateSource(t *testing.T) {
+	g, err := textureSourceTestParser(textureSourceTestJSON()).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	textureSourceTestCheck(t, g)
+	var out bytes.Buffer
+	if err := Encoder(&out).Encode(g); err != nil {
+		t.Fatal(err)
+	}
+	g2, err := textureSourceTestParser(out.String()).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	textureSourceTestCheck(t, g2)
+}
+
+func TestTextureAlternateSourceIssues(t *testing.T) {
+	js := textureSourceTestJSON()
+	for pointer, bad := range map[string]string{
+		"/textures/0/extensions/EXT_texture_webp/source": strings.Replace(js, `"EXT_texture_webp":{"source":0}`, `"EXT_texture_webp":{"source":1}`, 1),
+		"/textures/1/extensions/MSFT_texture_dds/source": strings.Replace(js, `"MSFT_texture_dds":{"source":1}`, `"MSFT_texture_dds":{"source":7}`, 1),
+	} {
+		issues, err := textureSourceTestParser(bad).Validate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var found bool
+		for _, v := range issues {
+			found = found || v.Pointer == pointer
+		}
+		if !found {
+			t.Errorf("issue of '%s' is not reported, %v", pointer, issues)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package gltf2
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/pkg/errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/main/extensions/2.0/Khronos/KHR_animation_pointer
+//
+// It is extension of AnimationChannelTarget which Path is Pointer, Pointer is JSON pointer of animated property
+// Target is resolved when it is linked, nil if property of Pointer is not supported, see GLTF.ResolvePointer
+//
+// ex) channel.Target.AnimationPointer().Target.Set(output)
+type KHRAnimationPointer struct {
+	Pointer string
+	Target  *AnimationPointerTarget
+}
+
+func (s *KHRAnimationPointer) ExtensionName() string {
+	return "KHR_animation_pointer"
+}
+func (s *KHRAnimationPointer) ExtensionSchemes() []string {
+	return []string{SCHEME_ANIMATION_CHANNEL_TARGET}
+}
+func (s *KHRAnimationPointer) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecKHRAnimationPointer)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *KHRAnimationPointer) Encode(ctx *encoderContext) (interface{}, error) {
+	return &SpecKHRAnimationPointer{
+		Pointer: &s.Pointer,
+	}, nil
+}
+
+// AnimationPointer is KHRAnimationPointer of target, nil if there is not
+func (s *AnimationChannelTarget) AnimationPointer() *KHRAnimationPointer {
+	if s.Extensions == nil {
+		return nil
+	}
+	res, _ := s.Extensions.Get(new(KHRAnimationPointer)).(*KHRAnimationPointer)
+	return res
+}
+
+// AnimationPointerTarget is runtime property of KHR_animation_pointer
+//
+// Object is owner of property, ex) *Material, *MaterialPBRMetallicRoughness, *Node, *KHRLight, *PerspectiveCamera
+// Value is pointer of property, one of *float32, *mgl32.Vec2, *mgl32.Vec3, *mgl32.Vec4, *mgl32.Quat, *[]float32
+type AnimationPointerTarget struct {
+	Object   interface{}
+	Property string
+	Value    interface{}
+}
+
+// Components is number of float of Value, length of slice for *[]float32
+func (s *AnimationPointerTarget) Components() int {
+	switch v := s.Value.(type) {
+	case *float32:
+		return 1
+	case *mgl32.Vec2:
+		return 2
+	case *mgl32.Vec3:
+		return 3
+	case *mgl32.Vec4, *mgl32.Quat:
+		return 4
+	case *[]float32:
+		return len(*v)
+	}
+	return -1
+}
+
+// Set write animated value to property, quaternion is (x, y, z, w) like glTF
+func (s *AnimationPointerTarget) Set(v []float32) error {
+	if n := s.Components(); n < 0 || len(v) < n {
+		return errors.Errorf("AnimationPointerTarget '%s' need %d values, but got %d", s.Property, n, len(v))
+	}
+	switch dst := s.Value.(type) {
+	case *float32:
+		*dst = v[0]
+	case *mgl32.Vec2:
+		copy(dst[:], v)
+	case *mgl32.Vec3:
+		copy(dst[:], v)
+	case *mgl32.Vec4:
+		copy(dst[:], v)
+	case *mgl32.Quat:
+		*dst = mgl32.Quat{W: v[3], V: mgl32.Vec3{v[0], v[1], v[2]}}
+	case *[]float32:
+		copy(*dst, v)
+	}
+	return nil
+}
+
+// ResolvePointer find property of JSON pointer in runtime structure, it is used for KHR_animation_pointer
+//
+// Property is found by json name of Spec object, ex) /materials/0/pbrMetallicRoughness/baseColorFactor
+// '/extensions/<name>' is extension in Extensions, camera '/perspective', '/orthographic' is Camera.Setting
+func (s *GLTF) ResolvePointer(pointer string) (*AnimationPointerTarget, error) {
+	tokens, err := pointerTokens(pointer)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		cur  = reflect.ValueOf(s)
+		spec = reflect.TypeOf(SpecGLTF{})
+		// owner of property
+		object interface{}
+	)
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		for cur.Kind() == reflect.Ptr || cur.Kind() == reflect.Interface {
+			if cur.IsNil() {
+				return nil, errors.Errorf("'%s' not found, '%s' is undefined", pointer, strings.Join(tokens[:i], "/"))
+			}
+			if cur.Kind() == reflect.Ptr && cur.Elem().Kind() == reflect.Struct {
+				object = cur.Interface()
+			}
+			cur = cur.Elem()
+		}
+		for spec.Kind() == reflect.Ptr {
+			spec = spec.Elem()
+		}
+		switch {
+		case cur.Kind() == reflect.Slice && spec.Kind() == reflect.Slice:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= cur.Len() {
+				return nil, errors.Errorf("'%s' not found, index '%s' out of range", pointer, token)
+			}
+			cur, spec = cur.Index(idx), spec.Elem()
+			continue
+		case cur.Kind() == reflect.Struct && spec.Kind() == reflect.Struct:
+		default:
+			return nil, errors.Errorf("'%s' not found, '%s' is not object", pointer, token)
+		}
+		if token == "extensions" && i+1 < len(tokens) {
+			var exts *Extensions
+			if f := cur.FieldByName("Extensions"); f.IsValid() {
+				exts, _ = f.Interface().(*Extensions)
+			}
+			if exts == nil || exts.GetByName(tokens[i+1]) == nil {
+				return nil, errors.Errorf("'%s' not found, extension '%s' is undefined", pointer, tokens[i+1])
+			}
+			i++
+			ext := exts.GetByName(tokens[i])
+			// Specifier of extension has json name of its properties
+			specifier, err := ext.Constructor([]byte("{}"))
+			if err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("'%s' not found, extension '%s'", pointer, tokens[i]))
+			}
+			cur, spec = reflect.ValueOf(ext), reflect.TypeOf(specifier)
+			continue
+		}
+		field, ok := jsonField(spec, token)
+		if !ok {
+			return nil, errors.Errorf("'%s' not found, '%s' is unknown property", pointer, token)
+		}
+		spec = field.Type
+		if camera, ok := object.(*Camera); ok && cur.Type() == reflect.TypeOf(Camera{}) && (token == Perspective.String() || token == Orthographic.String()) {
+			if camera.Setting == nil || camera.Setting.CameraType().String() != token {
+				return nil, errors.Errorf("'%s' not found, Camera is not %s", pointer, token)
+			}
+			cur = reflect.ValueOf(camera.Setting)
+			continue
+		}
+		// runtime object has field of same name as Spec object
+		if cur = cur.FieldByName(field.Name); !cur.IsValid() {
+			return nil, errors.Errorf("'%s' not found, '%s' is not runtime property", pointer, token)
+		}
+	}
+	res := &AnimationPointerTarget{
+		Object:   object,
+		Property: tokens[len(tokens)-1],
+	}
+	if cur.Kind() == reflect.Ptr && !cur.IsNil() && cur.Elem().Kind() == reflect.Float32 {
+		// optional property, ex) PerspectiveCamera.AspectRatio
+		cur = cur.Elem()
+	}
+	if !cur.CanAddr() {
+		return nil, errors.Errorf("'%s' can't be animated", pointer)
+	}
+	switch v := cur.Addr().Interface().(type) {
+	case *float32, *mgl32.Vec2, *mgl32.Vec3, *mgl32.Vec4, *mgl32.Quat, *[]float32:
+		res.Value = v
+	default:
+		return nil, errors.Errorf("'%s' can't be animated, type '%s' not supported", pointer, cur.Type())
+	}
+	return res, nil
+}
+
+// pointerTokens split JSON pointer to unescaped tokens
+func pointerTokens(pointer string) ([]string, error) {
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, errors.Errorf("'%s' is not JSON pointer", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, v := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(v)
+	}
+	return tokens, nil
+}
+
+// jsonField is field of struct type which json name is name
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if tag := strings.Split(f.Tag.Get("json"), ",")[0]; tag == name && f.PkgPath == "" {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// resolveSpecPointer check JSON pointer is float property of root, undefined optional property is also valid
+func resolveSpecPointer(root *SpecGLTF, pointer string) error {
+	tokens, err := pointerTokens(pointer)
+	if err != nil {
+		return err
+	}
+	cur := reflect.ValueOf(root)
+	for i, token := range tokens {
+		for cur.Kind() == reflect.Ptr || cur.Kind() == reflect.Interface {
+			if cur.IsNil() {
+				if cur.Kind() == reflect.Interface {
+					return errors.Errorf("'%s' is undefined", strings.Join(tokens[:i], "/"))
+				}
+				// undefined object has default properties
+				cur = reflect.New(cur.Type().Elem())
+			}
+			cur = cur.Elem()
+		}
+		switch cur.Kind() {
+		case reflect.Slice:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= cur.Len() {
+				return errors.Errorf("index '%s' out of range", token)
+			}
+			cur = cur.Index(idx)
+		case reflect.Map:
+			exts, ok := cur.Interface().(SpecExtensions)
+			if !ok {
+				return errors.Errorf("'%s' is not object", token)
+			}
+			v, ok := exts[token]
+			if !ok || v.data == nil {
+				return errors.Errorf("extension '%s' is undefined", token)
+			}
+			if _, raw := v.data.(*SpecRawExtension); raw {
+				return errors.Errorf("extension '%s' is not registered", token)
+			}
+			cur = reflect.ValueOf(v.data)
+		case reflect.Struct:
+			field, ok := jsonField(cur.Type(), token)
+			if !ok {
+				return errors.Errorf("'%s' is unknown property", token)
+			}
+			// camera has only one of perspective, orthographic
+			if camera, ok := cur.Addr().Interface().(*SpecCamera); ok && field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct {
+				if camera.Type == nil || camera.Type.String() != token {
+					return errors.Errorf("camera is not %s", token)
+				}
+			}
+			cur = cur.FieldByIndex(field.Index)
+		default:
+			return errors.Errorf("'%s' is not object", token)
+		}
+	}
+	t := cur.Type()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t.Kind() == reflect.Float32:
+	case (t.Kind() == reflect.Array && t.Len() <= 4 || t.Kind() == reflect.Slice) && t.Elem().Kind() == reflect.Float32:
+	default:
+		return errors.Errorf("type '%s' can't be animated", t)
+	}
+	return nil
+}
+
+type SpecKHRAnimationPointer struct {
+	Pointer *string `json:"pointer"` // required
+}
+
+func (s *SpecKHRAnimationPointer) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecKHRAnimationPointer) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		fallthrough
+	case LEVEL2:
+		if target, ok := parent.(*SpecAnimationChannelTarget); !ok {
+			issues.add(LEVEL2, CODE_UNDEFINED_PROPERTY, "", "KHRAnimationPointer is extension of animation channel target, but it is in '%s'", parent.Scheme())
+		} else if target.Path != nil && *target.Path != Pointer {
+			issues.add(LEVEL2, CODE_UNSATISFIED_DEPENDENCY, "", "KHRAnimationPointer need AnimationChannelTarget.Path 'pointer', but got '%s'", *target.Path)
+		}
+		if g, ok := root.(*SpecGLTF); ok && s.Pointer != nil && strings.HasPrefix(*s.Pointer, "/") {
+			if err := resolveSpecPointer(g, *s.Pointer); err != nil {
+				issues.add(LEVEL2, CODE_UNRESOLVED_REFERENCE, "/pointer", "KHRAnimationPointer.Pointer '%s' not resolved, %s", *s.Pointer, err.Error())
+			}
+		}
+		fallthrough
+	case LEVEL1:
+		if s.Pointer == nil {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/pointer", "KHRAnimationPointer.Pointer required")
+		} else if !strings.HasPrefix(*s.Pointer, "/") {
+			issues.add(LEVEL1, CODE_VALUE_NOT_IN_LIST, "/pointer", "KHRAnimationPointer.Pointer must be JSON pointer, but got '%s'", *s.Pointer)
+		}
+	}
+	return issues.Err()
+}
+func (s *SpecKHRAnimationPointer) To(ctx *parserContext) interface{} {
+	res := new(KHRAnimationPointer)
+	if s.Pointer != nil {
+		res.Pointer = *s.Pointer
+	}
+	return res
+}
+func (s *SpecKHRAnimationPointer) Link(Root *GLTF, parent interface{}, dst interface{}) error {
+	res := dst.(*KHRAnimationPointer)
+	// Pointer which is not resolved is reported by Syntax in LEVEL2, its Target is nil
+	if target, err := Root.ResolvePointer(res.Pointer); err == nil {
+		res.Target = target
+	}
+	return nil
+}
+
+func init() {
+	RegisterExtension(new(KHRAnimationPointer))
+}
@@ -0,0 +1,162 @@
+package gltf2
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// animationPointerTestJSON is glTF which has one channel of KHR_animation_pointer for each pointer
+func animationPointerTestJSON(pointers ...string) string {
+	channels := make([]string, len(pointers))
+	for i, v := range pointers {
+		channels[i] = fmt.Sprintf(`{"sampler":0,"target":{"path":"pointer","extensions":{"KHR_animation_pointer":{"pointer":"%s"}}}}`, v)
+	}
+	return fmt.Sprintf(`{
+	"asset":{"version":"2.0"},
+	"extensionsUsed":["KHR_animation_pointer","KHR_lights_punctual","KHR_texture_transform","KHR_materials_emissive_strength"],
+	"extensions":{"KHR_lights_punctual":{"lights":[{"type":"spot","spot":{}}]}},
+	"cameras":[{"type":"perspective","perspective":{"yfov":1,"znear":0.1}}],
+	"textures":[{}],
+	"materials":[{
+		"pbrMetallicRoughness":{"baseColorTexture":{"index":0,"extensions":{"KHR_texture_transform":{}}}},
+		"extensions":{"KHR_materials_emissive_strength":{"emissiveStrength":2}}
+	}],
+	"nodes":[{"camera":0,"extensions":{"KHR_lights_punctual":{"light":0}}}],
+	"scenes":[{"nodes":[0]}],
+	"animations":[{"samplers":[{"input":0,"output":0}],"channels":[%s]}],
+	"accessors":[{"componentType":5126,"count":1,"type":"SCALAR","min":[0],"max":[0]}]
+}`, strings.Join(channels, ","))
+}
+
+func TestResolvePointer(t *testing.T) {
+	g, err := Parser().AllKnownExtensions().Reader(strings.NewReader(animationPointerTestJSON("/nodes/0/translation"))).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		node     = g.Nodes[0]
+		pbr      = g.Materials[0].PBRMetallicRoughness
+		light    = g.Extensions.Get(new(KHRLightsPunctual)).(*KHRLightsPunctual).Lights[0]
+		strength = g.Materials[0].Extensions.Get(new(KHRMaterialsEmissiveStrength)).(*KHRMaterialsEmissiveStrength)
+		camera   = g.Cameras[0].Setting.(*PerspectiveCamera)
+	)
+	cases := []struct {
+		pointer string
+		object  interface{}
+		value   []float32
+		get     func() interface{}
+		expect  interface{}
+	}{
+		{"/nodes/0/translation", node, []float32{1, 2, 3}, func() interface{} { return node.Translation }, mgl32.Vec3{1, 2, 3}},
+		{"/nodes/0/rotation", node, []float32{0, 0, 1, 0}, func() interface{} { return node.Rotation }, mgl32.Quat{W: 0, V: mgl32.Vec3{0, 0, 1}}},
+		{"/nodes/0/scale", node, []float32{2, 2, 2}, func() interface{} { return node.Scale }, mgl32.Vec3{2, 2, 2}},
+		{"/materials/0/pbrMetallicRoughness/baseColorFactor", pbr, []float32{1, 0, 0, 1}, func() interface{} { return pbr.BaseColorFactor }, mgl32.Vec4{1, 0, 0, 1}},
+		{"/materials/0/pbrMetallicRoughness/metallicFactor", pbr, []float32{0.25}, func() interface{} { return pbr.MetallicFactor }, float32(0.25)},
+		{"/materials/0/emissiveFactor", g.Materials[0], []float32{1, 1, 0}, func() interface{} { return g.Materials[0].EmissiveFactor }, mgl32.Vec3{1, 1, 0}},
+		{"/materials/0/extensions/KHR_materials_emissive_strength/emissiveStrength", strength, []float32{4}, func() interface{} { return strength.EmissiveStrength }, float32(4)},
+		{"/extensions/KHR_lights_punctual/lights/0/intensity", light, []float32{3}, func() interface{} { return light.Intensity }, float32(3)},
+		{"/extensions/KHR_lights_punctual/lights/0/color", light, []float32{1, 0, 1}, func() interface{} { return light.Color }, mgl32.Vec3{1, 0, 1}},
+		{"/extensions/KHR_lights_punctual/lights/0/spot/outerConeAngle", light.Spot, []float32{0.3}, func() interface{} { return light.Spot.OuterConeAngle }, float32(0.3)},
+		{"/cameras/0/perspective/yfov", camera, []float32{0.5}, func() interface{} { return camera.Yfov }, float32(0.5)},
+	}
+	for _, c := range cases {
+		target, err := g.ResolvePointer(c.pointer)
+		if err != nil {
+			t.Errorf("%s : %v", c.pointer, err)
+			continue
+		}
+		if target.Object != c.object {
+			t.Errorf("%s : object %T, expected %T", c.pointer, target.Object, c.object)
+		}
+		if err := target.Set(c.value); err != nil {
+			t.Errorf("%s : %v", c.pointer, err)
+		}
+		if got := c.get(); got != c.expect {
+			t.Errorf("%s : %v, expected %v", c.pointer, got, c.expect)
+		}
+	}
+}
+
+// animationPointerTestInvalid is pointers which can't be resolved
+var animationPointerTestInvalid = []string{
+	"/nodes/0/translate",
+	"/nodes/1/translation",
+	"/nodes/0/name",
+	"/nodes/0/camera",
+	"/materials/0/userData",
+	"/materials/0/pbrMetallicRoughness/BaseColorFactor",
+	"/cameras/0/orthographic/xmag",
+	"/nodes/0/extensions/KHR_node_visibility/visible",
+}
+
+func TestResolvePointerInvalid(t *testing.T) {
+	g, err := Parser().AllKnownExtensions().Reader(strings.NewReader(animationPointerTestJSON("/nodes/0/translation"))).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range append(animationPointerTestInvalid, "nodes/0/translation") {
+		if target, err := g.ResolvePointer(v); err == nil {
+			t.Errorf("%s : resolved to %v, expected error", v, target)
+		}
+	}
+}
+
+func TestAnimationPointerIssues(t *testing.T) {
+	valid := []string{"/nodes/0/translation", "/extensions/KHR_lights_punctual/lights/0/intensity", "/cameras/0/perspective/yfov"}
+	js := animationPointerTestJSON(append(valid, animationPointerTestInvalid...)...)
+	issues, err := Parser().AllKnownExtensions().Reader(strings.NewReader(js)).Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reported := make(map[string]bool)
+	for _, v := range issues {
+		if v.Code == CODE_UNRESOLVED_REFERENCE && v.Severity == SeverityWarning {
+			reported[v.Pointer] = true
+		}
+	}
+	for i := range append(valid, animationPointerTestInvalid...) {
+		pointer := fmt.Sprintf("/animations/0/channels/%d/target/extensions/KHR_animation_pointer/pointer", i)
+		if expected := i >= len(valid); reported[pointer] != expected {
+			t.Errorf("channel %d reported %v, expected %v", i, reported[pointer], expected)
+		}
+	}
+	// LEVEL1 keep pointer which is not resolved without Target
+	g, err := Parser().AllKnownExtensions().Reader(strings.NewReader(js)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, ch := range g.Animations[0].Channels {
+		if p := ch.Target.AnimationPointer(); p == nil || (p.Target != nil) != (i < len(valid)) {
+			t.Errorf("channel %d Target %v", i, p)
+		}
+	}
+}
+
+func TestAnimationPointerEncode(t *testing.T) {
+	pointers := []string{"/materials/0/pbrMetallicRoughness/baseColorTexture/extensions/KHR_texture_transform/offset", "/nodes/0/rotation"}
+	g, err := Parser().AllKnownExtensions().Reader(strings.NewReader(animationPointerTestJSON(pointers...))).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := Encoder(&out).Encode(g); err != nil {
+		t.Fatal(err)
+	}
+	g2, err := Parser().AllKnownExtensions().Reader(&out).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, ch := range g2.Animations[0].Channels {
+		p := ch.Target.AnimationPointer()
+		if p == nil || p.Pointer != pointers[i] || p.Target == nil {
+			t.Fatalf("channel %d pointer %v, expected %s", i, p, pointers[i])
+		}
+	}
+	if tg := g2.Animations[0].Channels[0].Target.AnimationPointer().Target; tg.Components() != 2 {
+		t.Errorf("texture transform offset components %d, expected 2", tg.Components())
+	}
+}
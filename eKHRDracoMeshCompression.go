@@ -2,7 +2,11 @@ package gltf2
 
 import (
 	"encoding/json"
+	"fmt"
+	"github.com/iamGreedy/glog"
 	"github.com/pkg/errors"
+	"reflect"
+	"sort"
 )
 
 // if there is no None compressed mesh, extension Required have KHR_draco_mesh_compression
@@ -13,6 +17,9 @@ type KHRDracoMeshCompression struct {
 func (s *KHRDracoMeshCompression) ExtensionName() string {
 	return "KHR_draco_mesh_compression"
 }
+func (s *KHRDracoMeshCompression) ExtensionSchemes() []string {
+	return []string{SCHEME_MESH_PRIMITIVE}
+}
 func (s *KHRDracoMeshCompression) Constructor(src []byte) (Specifier, error) {
 	res := new(SpecKHRDracoMeshCompression)
 	if err := json.Unmarshal(src, res); err != nil{
@@ -20,6 +27,16 @@ func (s *KHRDracoMeshCompression) Constructor(src []byte) (Specifier, error) {
 	}
 	return res, nil
 }
+func (s *KHRDracoMeshCompression) Encode(ctx *encoderContext) (interface{}, error) {
+	attrs := make(map[AttributeKey]SpecGLTFID, len(s.Attributes))
+	for k, v := range s.Attributes {
+		attrs[k] = v
+	}
+	return &SpecKHRDracoMeshCompression{
+		BufferView: ctx.BufferView(s.BufferView),
+		Attributes: &attrs,
+	}, nil
+}
 
 type SpecKHRDracoMeshCompression struct {
 	BufferView *SpecGLTFID                 `json:"bufferView"` // required
@@ -30,23 +47,28 @@ func (s *SpecKHRDracoMeshCompression) Scheme() string {
 	return SCHEME_EXTENSION
 }
 func (s *SpecKHRDracoMeshCompression) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
 	case LEVEL2:
-		if !(parent.(*SpecMeshPrimitive).Mode != nil&& (*parent.(*SpecMeshPrimitive).Mode == TRIANGLES || *parent.(*SpecMeshPrimitive).Mode == TRIANGLE_STRIP)){
-			return errors.Errorf("KHRDracoMeshCompression >> MeshPrimitive.Mode is TRIANGLES or TRIANGLE_STRIP")
+		// undefined mode is TRIANGLES
+		if p, ok := parent.(*SpecMeshPrimitive); ok && p.Mode != nil && *p.Mode != TRIANGLES && *p.Mode != TRIANGLE_STRIP {
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_LIST, "", "KHRDracoMeshCompression >> MeshPrimitive.Mode is TRIANGLES or TRIANGLE_STRIP")
 		}
 		fallthrough
 	case LEVEL1:
 		if s.BufferView == nil {
-			return errors.Errorf("KHRDracoMeshCompression.BufferView required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/bufferView", "KHRDracoMeshCompression.BufferView required")
 		}
 		if s.Attributes == nil {
-			return errors.Errorf("KHRDracoMeshCompression.Attributes required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/attributes", "KHRDracoMeshCompression.Attributes required")
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.BufferView, len(g.BufferViews), "/bufferView", "KHRDracoMeshCompression.BufferView")
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecKHRDracoMeshCompression) To(ctx *parserContext) interface{} {
 	res := new(KHRDracoMeshCompression)
@@ -60,3 +82,173 @@ func (s *SpecKHRDracoMeshCompression) Link(Root *GLTF, parent interface{}, dst i
 	dst.(*KHRDracoMeshCompression).BufferView = Root.BufferViews[*s.BufferView]
 	return nil
 }
+
+// _UnpackDraco decode KHR_draco_mesh_compression, and replace primitive attributes, indices to new accessors
+//
+// Decoded data is appended to new buffer, compressed bufferView is not removed.
+// If draco data can't be decoded, the primitive keep its extension with warning, and extension name is not removed.
+func _UnpackDraco(parser *parserContext, gltf *GLTF, logger *glog.Glogger) error {
+	var (
+		name     = new(KHRDracoMeshCompression).ExtensionName()
+		b        *builder
+		replaced = make(map[*Accessor]bool)
+		failed   bool
+	)
+	for i, mesh := range gltf.Meshes {
+		for j, prim := range mesh.Primitives {
+			if prim.Extensions == nil {
+				continue
+			}
+			draco, ok := (*prim.Extensions)[name].(*KHRDracoMeshCompression)
+			if !ok {
+				continue
+			}
+			if len(mesh.Name) > 0 {
+				logger.Printf("glTF.Meshes['%s'].Primitives[%d]", mesh.Name, j)
+			} else {
+				logger.Printf("glTF.Meshes[%d].Primitives[%d]", i, j)
+			}
+			inner := logger.Indent()
+			decoded, attributes, err := loadDraco(draco)
+			if err != nil {
+				inner.Printf("Warning : %v, primitive is not unpacked", err)
+				failed = true
+				continue
+			}
+			if b == nil {
+				b = Builder(gltf, nil)
+			}
+			if err := unpackDraco(b, prim, decoded, attributes, replaced); err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("glTF.Meshes[%d].Primitives[%d]", i, j))
+			}
+			delete(*prim.Extensions, name)
+			inner.Printf("Unpacked %d attributes, %d indices", len(draco.Attributes), prim.Indices.Count)
+		}
+	}
+	if b == nil {
+		return nil
+	}
+	// accessor without bufferView is placeholder of draco data
+	var (
+		accessors = gltf.Accessors[:0]
+		shifted   bool
+	)
+	for i, v := range gltf.Accessors {
+		if !replaced[v] || v.BufferView != nil || v.Sparse != nil {
+			shifted = shifted || len(accessors) != i
+			accessors = append(accessors, v)
+		}
+	}
+	gltf.Accessors = accessors
+	// RawExtension is not linked, its accessor glTFid is not updated
+	if names := parser.RawExtensionNames(); shifted && len(names) > 0 {
+		logger.Printf("Warning : accessor glTFid is changed, RawExtension %v may refer wrong accessor", names)
+	}
+	if !failed {
+		gltf.ExtensionsUsed = removeExtensionName(gltf.ExtensionsUsed, name)
+		gltf.ExtensionsRequired = removeExtensionName(gltf.ExtensionsRequired, name)
+	}
+	return nil
+}
+
+// loadDraco decode draco data to slices of [N]T, primitive is not changed
+func loadDraco(draco *KHRDracoMeshCompression) (*dracoMesh, map[AttributeKey]interface{}, error) {
+	src, err := draco.BufferView.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+	mesh, err := decodeDraco(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	attributes := make(map[AttributeKey]interface{}, len(draco.Attributes))
+	for k, v := range draco.Attributes {
+		attr, ok := mesh.attributes[uint32(v)]
+		if !ok {
+			return nil, nil, errors.Errorf("KHRDracoMeshCompression.Attributes['%s'] id %d not found", k, v)
+		}
+		if attributes[k], err = attr.slice(mesh.numPoints); err != nil {
+			return nil, nil, err
+		}
+	}
+	return mesh, attributes, nil
+}
+func unpackDraco(b *builder, prim *MeshPrimitive, mesh *dracoMesh, decoded map[AttributeKey]interface{}, replaced map[*Accessor]bool) error {
+	keys := make([]string, 0, len(decoded))
+	for k := range decoded {
+		keys = append(keys, string(k))
+	}
+	sort.Strings(keys)
+	attributes := make(map[AttributeKey]*Accessor, len(prim.Attributes))
+	for k, v := range prim.Attributes {
+		attributes[k] = v
+	}
+	for _, k := range keys {
+		var (
+			key  = AttributeKey(k)
+			data = decoded[key]
+			err  error
+		)
+		if old := prim.Attributes[key]; old != nil {
+			replaced[old] = true
+			b.Name(old.Name)
+			if old.Normalized {
+				b.Normalized()
+			}
+			if old.Type.Count() == reflect.TypeOf(data).Elem().Len() {
+				b.Type(old.Type)
+			}
+		}
+		if attributes[key], err = b.Target(ARRAY_BUFFER).Accessor(data); err != nil {
+			return err
+		}
+	}
+	// indices keep component type if it can be
+	var (
+		componentType = UNSIGNED_INT
+		data          interface{}
+	)
+	if prim.Indices != nil {
+		replaced[prim.Indices] = true
+		componentType = prim.Indices.ComponentType
+		b.Name(prim.Indices.Name)
+	}
+	switch {
+	case componentType == UNSIGNED_BYTE && mesh.numPoints <= 1<<8:
+		indices := make([]uint8, len(mesh.indices))
+		for i, v := range mesh.indices {
+			indices[i] = uint8(v)
+		}
+		data = indices
+	case componentType != UNSIGNED_INT && mesh.numPoints <= 1<<16:
+		indices := make([]uint16, len(mesh.indices))
+		for i, v := range mesh.indices {
+			indices[i] = uint16(v)
+		}
+		data = indices
+	default:
+		data = mesh.indices
+	}
+	if _, err := b.Indices(prim, data); err != nil {
+		return err
+	}
+	prim.Attributes = attributes
+	prim.Mode = TRIANGLES
+	return nil
+}
+func removeExtensionName(names []string, name string) []string {
+	res := names[:0]
+	for _, v := range names {
+		if v != name {
+			res = append(res, v)
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
+func init() {
+	RegisterExtension(new(KHRDracoMeshCompression))
+}
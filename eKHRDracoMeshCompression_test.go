@@ -0,0 +1,179 @@
+package gltf2
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// dracoTestSequential is sequential quad, faces are (0, 1, 2), (0, 2, 3)
+//
+// Attribute id 0 is position, 1 is texture coordinate, 2 is normal.
+// Position is generic, texture coordinate is quantized difference, normal is octahedron.
+func dracoTestSequential() []byte {
+	symbols := dracoTestRANS([]uint32{1000, 0, 1000, 0, 1000, 1096}, 12, []uint32{0, 2, 2, 5, 4, 2})
+	return dracoTestLE(
+		[]byte("DRACO"), []byte{2, 2, 1, 0, 0, 0},
+		[]byte{2, 4, 0}, // faces, points, compressed
+		// indices, probability table is written as 2 bytes or zero run
+		[]byte{1, 3, 6, 161, 15, 3, 161, 15, 3, 161, 15, 33, 17},
+		[]byte{byte(len(symbols))}, symbols,
+		// attributes
+		[]byte{1, 3},
+		[]byte{0, 9, 3, 0, 0},
+		[]byte{3, 9, 2, 0, 1},
+		[]byte{1, 9, 3, 0, 2},
+		[]byte{0, 2, 3},
+		[]float32{0, 0, 0, 1, 0, 0, 1, 1, 0, 0, 1, 0},
+		// texture coordinate, difference in tagged rANS
+		[]byte{0, 1, 1, 0},
+		[]byte{3, 7, 1, 64, 1, 0},
+		[]byte{0x10, 0x24},
+		[]int32{0, 15},
+		// normal
+		[]byte{0xfe, 0, 1, 254, 254, 254, 254, 254, 254, 254, 254},
+		[]float32{0, 0, 1}, []byte{4},
+		[]byte{8},
+	)
+}
+
+// dracoTestDocument is glTF of draco primitives, each src has POSITION, TEXCOORD_0, NORMAL as attribute id 0, 1, 2
+func dracoTestDocument(srcs ...[]byte) string {
+	var (
+		buffer     []byte
+		views      []string
+		accessors  []string
+		primitives []string
+	)
+	for i, src := range srcs {
+		views = append(views, fmt.Sprintf(`{"buffer":0,"byteOffset":%d,"byteLength":%d}`, len(buffer), len(src)))
+		buffer = append(buffer, src...)
+		for len(buffer)%4 != 0 {
+			buffer = append(buffer, 0)
+		}
+		accessors = append(accessors,
+			`{"type":"VEC3","componentType":5126,"count":4}`,
+			`{"type":"VEC2","componentType":5126,"count":4}`,
+			`{"type":"VEC3","componentType":5126,"count":4}`,
+			`{"type":"SCALAR","componentType":5123,"count":6}`,
+		)
+		primitives = append(primitives, fmt.Sprintf(`{"attributes":{"POSITION":%d,"TEXCOORD_0":%d,"NORMAL":%d},"indices":%d,
+		"extensions":{"KHR_draco_mesh_compression":{"bufferView":%d,"attributes":{"POSITION":0,"TEXCOORD_0":1,"NORMAL":2}}}}`,
+			4*i, 4*i+1, 4*i+2, 4*i+3, i))
+	}
+	return fmt.Sprintf(`{
+	"asset":{"version":"2.0"},
+	"extensionsUsed":["KHR_draco_mesh_compression"],
+	"extensionsRequired":["KHR_draco_mesh_compression"],
+	"buffers":[{"byteLength":%d,"uri":"data:application/octet-stream;base64,%s"}],
+	"bufferViews":[%s],
+	"accessors":[%s],
+	"meshes":[{"primitives":[%s]}]
+}`, len(buffer), base64.StdEncoding.EncodeToString(buffer), strings.Join(views, ","), strings.Join(accessors, ","), strings.Join(primitives, ","))
+}
+
+func TestDraco(t *testing.T) {
+	g, err := Parser().Extensions(new(KHRDracoMeshCompression)).Tasks(Tasks.UnpackDraco).
+		Reader(strings.NewReader(dracoTestDocument(dracoTestSequential(), dracoTestSeam()))).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Accessors) != 8 || len(g.ExtensionsUsed) != 0 || len(g.ExtensionsRequired) != 0 {
+		t.Fatalf("%d accessors, extensions %v %v", len(g.Accessors), g.ExtensionsUsed, g.ExtensionsRequired)
+	}
+	for i, c := range []struct {
+		indices string
+		pos     []mgl32.Vec3
+		uv      []mgl32.Vec2
+		nor     mgl32.Vec3
+	}{
+		{
+			indices: "[0 1 2 0 2 3]",
+			pos:     []mgl32.Vec3{{0, 0, 0}, {1, 0, 0}, {1, 1, 0}, {0, 1, 0}},
+			uv:      []mgl32.Vec2{{0, 0}, {1, 0}, {1, 1}, {0, 1}},
+		},
+		{
+			indices: "[0 1 4 3 2 5]",
+			pos:     []mgl32.Vec3{{0, 0, 0}, {2, 0, 0}, {2, 0, 0}, {0, 2, 0}, {0, 2, 0}, {2, 2, 0}},
+			uv:      []mgl32.Vec2{{0, 0}, {2, 0}, {6, 0}, {4, 2}, {0, 2}, {6, 2}},
+			nor:     mgl32.Vec3{0, 0, 1},
+		},
+	} {
+		prim := g.Meshes[0].Primitives[i]
+		if len(*prim.Extensions) != 0 {
+			t.Errorf("primitive %d extensions %v", i, *prim.Extensions)
+		}
+		idx, err := ReadIndices(prim.Indices)
+		if err != nil || fmt.Sprint(idx) != c.indices || prim.Indices.ComponentType != UNSIGNED_SHORT {
+			t.Fatalf("primitive %d indices %v %v, expected %s", i, idx, err, c.indices)
+		}
+		pos, _ := ReadVec3f(prim.Attributes[POSITION])
+		uv, _ := ReadVec2f(prim.Attributes[TEXCOORD_0])
+		nor, _ := ReadVec3f(prim.Attributes[NORMAL])
+		if len(pos) != len(c.pos) || len(uv) != len(c.uv) || len(nor) != len(c.pos) {
+			t.Fatalf("primitive %d %v %v %v", i, pos, uv, nor)
+		}
+		for j := range c.pos {
+			if pos[j] != c.pos[j] || !uv[j].ApproxEqual(c.uv[j]) {
+				t.Errorf("primitive %d point %d %v %v, expected %v %v", i, j, pos[j], uv[j], c.pos[j], c.uv[j])
+			}
+		}
+		if i == 1 {
+			for j, v := range nor {
+				if !v.ApproxEqualThreshold(c.nor, 1e-6) {
+					t.Errorf("primitive %d normal %d %v, expected %v", i, j, v, c.nor)
+				}
+			}
+		}
+	}
+	if nor, _ := ReadVec3f(g.Meshes[0].Primitives[0].Attributes[NORMAL]); !nor[3].ApproxEqual(mgl32.Vec3{1, 0, 0}) {
+		t.Errorf("normal %v", nor)
+	}
+	var out bytes.Buffer
+	if err := Encoder(&out).DataURI().Encode(g); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "draco") {
+		t.Errorf("draco is not removed, %s", out.String())
+	}
+}
+
+// TestDracoFallback keep undecodable primitive with its extension, other primitive is unpacked
+func TestDracoFallback(t *testing.T) {
+	deprecated := dracoTestLE(dracoTestQuad(0, 0), []byte{1, 0x2f}, dracoTestRABS(false), []byte{1, 0xff, 0, 0}, dracoTestPosition(3, nil))
+	var log bytes.Buffer
+	g, err := Parser().Extensions(new(KHRDracoMeshCompression)).Tasks(Tasks.UnpackDraco).Logger(&log).
+		Reader(strings.NewReader(dracoTestDocument(dracoTestSequential(), deprecated))).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(log.String(), "Warning") {
+		t.Errorf("warning is not logged, %s", log.String())
+	}
+	name := new(KHRDracoMeshCompression).ExtensionName()
+	if len(g.ExtensionsUsed) != 1 || len(g.ExtensionsRequired) != 1 || g.ExtensionsRequired[0] != name {
+		t.Errorf("extensions %v %v, expected %s", g.ExtensionsUsed, g.ExtensionsRequired, name)
+	}
+	unpacked, kept := g.Meshes[0].Primitives[0], g.Meshes[0].Primitives[1]
+	if len(*unpacked.Extensions) != 0 || unpacked.Indices.BufferView == nil {
+		t.Errorf("primitive 0 is not unpacked")
+	}
+	if _, ok := (*kept.Extensions)[name].(*KHRDracoMeshCompression); !ok || kept.Indices.BufferView != nil {
+		t.Errorf("primitive 1 is changed")
+	}
+	// placeholder accessors of kept primitive remain
+	if len(g.Accessors) != 8 {
+		t.Errorf("%d accessors, expected 8", len(g.Accessors))
+	}
+	var out bytes.Buffer
+	if err := Encoder(&out).DataURI().Encode(g); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), name) {
+		t.Errorf("extension is not written, %s", out.String())
+	}
+}
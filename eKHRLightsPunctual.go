@@ -0,0 +1,348 @@
+package gltf2
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/pkg/errors"
+	"math"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Khronos/KHR_lights_punctual
+//
+// glTF level extension have Lights, node level extension have Light which is one of glTF level Lights
+//
+// ex) light := node.Extensions.Get(new(KHRLightsPunctual)).(*KHRLightsPunctual).Light
+type KHRLightsPunctual struct {
+	Lights []*KHRLight
+	Light  *KHRLight
+}
+
+func (s *KHRLightsPunctual) ExtensionName() string {
+	return "KHR_lights_punctual"
+}
+func (s *KHRLightsPunctual) ExtensionSchemes() []string {
+	return []string{SCHEME_GLTF, SCHEME_NODE}
+}
+func (s *KHRLightsPunctual) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecKHRLightsPunctual)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *KHRLightsPunctual) Encode(ctx *encoderContext) (interface{}, error) {
+	res := new(SpecKHRLightsPunctual)
+	if s.Light != nil {
+		var root *KHRLightsPunctual
+		if ext := ctx.GLTF().Extensions; ext != nil {
+			root, _ = ext.Get(s).(*KHRLightsPunctual)
+		}
+		if root == nil {
+			return nil, errors.New("KHRLightsPunctual.Light need glTF level KHRLightsPunctual")
+		}
+		for i, v := range root.Lights {
+			if v == s.Light {
+				id := SpecGLTFID(i)
+				res.Light = &id
+				break
+			}
+		}
+		if res.Light == nil {
+			return nil, errors.New("KHRLightsPunctual.Light is not in glTF level KHRLightsPunctual.Lights")
+		}
+	}
+	for _, light := range s.Lights {
+		spec, err := light.encode(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res.Lights = append(res.Lights, spec)
+	}
+	return res, nil
+}
+
+type KHRLight struct {
+	Name      string
+	Color     mgl32.Vec3 // default [1, 1, 1]
+	Intensity float32    // default 1
+	Type      KHRLightType
+	// +Inf if it is undefined
+	Range float32
+	// only for KHRLightTypeSpot
+	Spot       *KHRLightSpot
+	Extensions *Extensions
+	Extras     *Extras
+	// None spec
+	UserData interface{}
+}
+
+func (s *KHRLight) GetExtension() *Extensions {
+	return s.Extensions
+}
+func (s *KHRLight) SetExtension(extensions *Extensions) {
+	s.Extensions = extensions
+}
+func (s *KHRLight) encode(ctx *encoderContext) (res SpecKHRLight, err error) {
+	lightType := s.Type
+	res = SpecKHRLight{
+		Name:      optString(s.Name),
+		Intensity: optFloat32(s.Intensity, 1),
+		Type:      &lightType,
+		Extras:    s.Extras,
+	}
+	if s.Color != (mgl32.Vec3{1, 1, 1}) {
+		color := s.Color
+		res.Color = &color
+	}
+	if !math.IsInf(float64(s.Range), 1) {
+		res.Range = float32Ptr(s.Range)
+	}
+	if s.Spot != nil {
+		res.Spot = &SpecKHRLightSpot{
+			InnerConeAngle: optFloat32(s.Spot.InnerConeAngle, 0),
+			OuterConeAngle: optFloat32(s.Spot.OuterConeAngle, math.Pi/4),
+		}
+	}
+	if res.Extensions, err = ctx.Extensions(s.Extensions); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+type KHRLightSpot struct {
+	InnerConeAngle float32 // default 0
+	OuterConeAngle float32 // default PI / 4
+}
+
+type KHRLightType uint8
+
+const (
+	KHRLightTypeDirectional KHRLightType = iota
+	KHRLightTypePoint       KHRLightType = iota
+	KHRLightTypeSpot        KHRLightType = iota
+)
+
+func (s KHRLightType) String() string {
+	switch s {
+	case KHRLightTypeDirectional:
+		return "directional"
+	case KHRLightTypePoint:
+		return "point"
+	case KHRLightTypeSpot:
+		return "spot"
+	}
+	return "nil"
+}
+func (s *KHRLightType) MarshalJSON() ([]byte, error) {
+	if s.String() == "nil" {
+		return nil, errors.New("KHRLightType invalid")
+	}
+	return json.Marshal(s.String())
+}
+func (s *KHRLightType) UnmarshalJSON(data []byte) error {
+	var v string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return errors.WithMessage(ErrorJSON, err.Error())
+	}
+	switch v {
+	case "directional":
+		*s = KHRLightTypeDirectional
+	case "point":
+		*s = KHRLightTypePoint
+	case "spot":
+		*s = KHRLightTypeSpot
+	default:
+		return errors.WithMessage(ErrorEnum, fmt.Sprintf("'%s' is invalid KHRLightType", v))
+	}
+	return nil
+}
+
+type SpecKHRLightsPunctual struct {
+	Lights []SpecKHRLight `json:"lights,omitempty"` // glTF level, minitem(1)
+	Light  *SpecGLTFID    `json:"light,omitempty"`  // node level
+}
+
+func (s *SpecKHRLightsPunctual) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecKHRLightsPunctual) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		fallthrough
+	case LEVEL2:
+		if _, ok := parent.(*SpecGLTF); ok && s.Light != nil {
+			issues.add(LEVEL2, CODE_UNDEFINED_PROPERTY, "/light", "KHRLightsPunctual.Light is node level property")
+		}
+		if _, ok := parent.(*SpecNode); ok && s.Lights != nil {
+			issues.add(LEVEL2, CODE_UNDEFINED_PROPERTY, "/lights", "KHRLightsPunctual.Lights is glTF level property")
+		}
+		fallthrough
+	case LEVEL1:
+		if _, ok := parent.(*SpecNode); ok {
+			if s.Light == nil {
+				issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/light", "KHRLightsPunctual.Light required")
+			}
+			issues.reference(s.Light, len(khrLightsOf(root)), "/light", "KHRLightsPunctual.Light")
+		}
+	}
+	return issues.Err()
+}
+func (s *SpecKHRLightsPunctual) To(ctx *parserContext) interface{} {
+	res := new(KHRLightsPunctual)
+	if len(s.Lights) > 0 {
+		res.Lights = make([]*KHRLight, len(s.Lights))
+	}
+	return res
+}
+func (s *SpecKHRLightsPunctual) Link(Root *GLTF, parent interface{}, dst interface{}) error {
+	if s.Light == nil {
+		return nil
+	}
+	var lights []*KHRLight
+	if Root.Extensions != nil {
+		if root, ok := Root.Extensions.Get(dst.(*KHRLightsPunctual)).(*KHRLightsPunctual); ok {
+			lights = root.Lights
+		}
+	}
+	if !inRange(*s.Light, len(lights)) {
+		return errors.Errorf("KHRLightsPunctual.Light linking fail, %s", *s.Light)
+	}
+	dst.(*KHRLightsPunctual).Light = lights[*s.Light]
+	return nil
+}
+
+func (s *SpecKHRLightsPunctual) GetChild(i int) Specifier {
+	return &s.Lights[i]
+}
+func (s *SpecKHRLightsPunctual) SetChild(i int, dst, object interface{}) {
+	dst.(*KHRLightsPunctual).Lights[i] = object.(*KHRLight)
+}
+func (s *SpecKHRLightsPunctual) ChildPointer(i int) string {
+	return fmt.Sprintf("/lights/%d", i)
+}
+func (s *SpecKHRLightsPunctual) LenChild() int {
+	return len(s.Lights)
+}
+func (s *SpecKHRLightsPunctual) ImpleGetChild(i int, dst interface{}) interface{} {
+	return dst.(*KHRLightsPunctual).Lights[i]
+}
+
+// khrLightsOf is glTF level lights of root, it is used to check node level light
+func khrLightsOf(root Specifier) []SpecKHRLight {
+	g, ok := root.(*SpecGLTF)
+	if !ok || g.Extensions == nil {
+		return nil
+	}
+	if raw, ok := (*g.Extensions)[new(KHRLightsPunctual).ExtensionName()]; ok && raw.data != nil {
+		if ext, ok := raw.data.(*SpecKHRLightsPunctual); ok {
+			return ext.Lights
+		}
+	}
+	return nil
+}
+
+type SpecKHRLight struct {
+	Name       *string           `json:"name,omitempty"`
+	Color      *mgl32.Vec3       `json:"color,omitempty"`     // default [1, 1, 1], range(0, 1)
+	Intensity  *float32          `json:"intensity,omitempty"` // default 1, minimum(0)
+	Type       *KHRLightType     `json:"type"`                // required
+	Range      *float32          `json:"range,omitempty"`     // exclusiveMinimum(0)
+	Spot       *SpecKHRLightSpot `json:"spot,omitempty"`      // required if type is spot
+	Extensions *SpecExtensions   `json:"extensions,omitempty"`
+	Extras     *Extras           `json:"extras,omitempty"`
+}
+
+type SpecKHRLightSpot struct {
+	InnerConeAngle *float32 `json:"innerConeAngle,omitempty"` // default 0, range(0, outerConeAngle)
+	OuterConeAngle *float32 `json:"outerConeAngle,omitempty"` // default PI / 4, range(innerConeAngle, PI / 2)
+}
+
+func (s *SpecKHRLight) SpecExtension() *SpecExtensions {
+	return s.Extensions
+}
+func (s *SpecKHRLight) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecKHRLight) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		if s.Color != nil && !isValidF32Color3(*s.Color) {
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/color", "KHRLight.Color range(0, 1), but got '%v'", *s.Color)
+		}
+		if s.Intensity != nil && *s.Intensity < 0 {
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/intensity", "KHRLight.Intensity minimum(0), but got '%f'", *s.Intensity)
+		}
+		if s.Range != nil && *s.Range <= 0 {
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/range", "KHRLight.Range exclusiveMinimum(0), but got '%f'", *s.Range)
+		}
+		fallthrough
+	case LEVEL2:
+		if s.Type != nil && *s.Type != KHRLightTypeSpot && s.Spot != nil {
+			issues.add(LEVEL2, CODE_UNSATISFIED_DEPENDENCY, "/spot", "KHRLight.Spot is only for spot light, but type is '%s'", *s.Type)
+		}
+		if s.Spot != nil {
+			inner, outer := s.Spot.angles()
+			if inner < 0 || inner >= outer {
+				issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/spot/innerConeAngle", "KHRLightSpot.InnerConeAngle range(0, outerConeAngle), but got '%f'", inner)
+			}
+			if outer > math.Pi/2 {
+				issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/spot/outerConeAngle", "KHRLightSpot.OuterConeAngle maximum(PI / 2), but got '%f'", outer)
+			}
+		}
+		fallthrough
+	case LEVEL1:
+		if s.Type == nil {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/type", "KHRLight.Type required")
+		} else if *s.Type == KHRLightTypeSpot && s.Spot == nil {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/spot", "KHRLight.Spot required for spot light")
+		}
+	}
+	return issues.Err()
+}
+func (s *SpecKHRLight) To(ctx *parserContext) interface{} {
+	res := new(KHRLight)
+	if s.Name != nil {
+		res.Name = *s.Name
+	}
+	if s.Color == nil {
+		res.Color = mgl32.Vec3{1, 1, 1}
+	} else {
+		res.Color = *s.Color
+	}
+	if s.Intensity == nil {
+		res.Intensity = 1
+	} else {
+		res.Intensity = *s.Intensity
+	}
+	res.Type = *s.Type
+	if s.Range == nil {
+		res.Range = float32(math.Inf(1))
+	} else {
+		res.Range = *s.Range
+	}
+	if s.Spot != nil && res.Type == KHRLightTypeSpot {
+		res.Spot = new(KHRLightSpot)
+		res.Spot.InnerConeAngle, res.Spot.OuterConeAngle = s.Spot.angles()
+	}
+	res.Extras = s.Extras
+	return res
+}
+
+// angles apply default value
+func (s *SpecKHRLightSpot) angles() (inner, outer float32) {
+	inner, outer = 0, math.Pi/4
+	if s.InnerConeAngle != nil {
+		inner = *s.InnerConeAngle
+	}
+	if s.OuterConeAngle != nil {
+		outer = *s.OuterConeAngle
+	}
+	return inner, outer
+}
+
+func init() {
+	RegisterExtension(new(KHRLightsPunctual))
+}
@@ -0,0 +1,94 @@
+package gltf2
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const lightsPunctualTestJSON = `{"asset":{"version":"2.0"},"extensionsUsed":["KHR_lights_punctual"],
+	"extensions":{"KHR_lights_punctual":{"lights":[
+		{"type":"point","color":[1,0,0],"intensity":5},
+		{"name":"spot","type":"spot","spot":{"outerConeAngle":0.5},"range":10},
+		{"type":"directional"}
+	]}},
+	"nodes":[{"extensions":{"KHR_lights_punctual":{"light":1}}},{"extensions":{"KHR_lights_punctual":{"light":2}}}],
+	"scenes":[{"nodes":[0,1]}]}`
+
+// lightsPunctualTestCheck check lights of lightsPunctualTestJSON
+func lightsPunctualTestCheck(t *testing.T, g *GLTF) {
+	root, ok := g.Extensions.Get(new(KHRLightsPunctual)).(*KHRLightsPunctual)
+	if !ok || len(root.Lights) != 3 {
+		t.Fatalf("KHR_lights_punctual lights %v", root)
+	}
+	for i, node := range g.Nodes {
+		ext, ok := node.Extensions.Get(new(KHRLightsPunctual)).(*KHRLightsPunctual)
+		if !ok || ext.Light != root.Lights[i+1] {
+			t.Errorf("node %d light is not linked to lights[%d]", i, i+1)
+		}
+	}
+	point, spot, directional := root.Lights[0], root.Lights[1], root.Lights[2]
+	if point.Type != KHRLightTypePoint || point.Color != (mgl32.Vec3{1, 0, 0}) || point.Intensity != 5 || !math.IsInf(float64(point.Range), 1) || point.Spot != nil {
+		t.Errorf("point light %+v", point)
+	}
+	if spot.Type != KHRLightTypeSpot || spot.Name != "spot" || spot.Range != 10 || spot.Spot == nil {
+		t.Fatalf("spot light %+v", spot)
+	}
+	// default innerConeAngle is 0
+	if spot.Spot.InnerConeAngle != 0 || spot.Spot.OuterConeAngle != 0.5 {
+		t.Errorf("spot cone %+v", spot.Spot)
+	}
+	// default color and intensity
+	if directional.Type != KHRLightTypeDirectional || directional.Color != (mgl32.Vec3{1, 1, 1}) || directional.Intensity != 1 {
+		t.Errorf("directional light %+v", directional)
+	}
+}
+
+func TestKHRLightsPunctual(t *testing.T) {
+	issues, err := Parser().Extensions(new(KHRLightsPunctual)).Reader(strings.NewReader(lightsPunctualTestJSON)).Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("issues %v", issues)
+	}
+	g, err := Parser().Extensions(new(KHRLightsPunctual)).Reader(strings.NewReader(lightsPunctualTestJSON)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lightsPunctualTestCheck(t, g)
+	var out bytes.Buffer
+	if err := Encoder(&out).Encode(g); err != nil {
+		t.Fatal(err)
+	}
+	// default values are not written
+	if s := out.String(); strings.Count(s, `"range"`) != 1 || strings.Contains(s, `"innerConeAngle"`) {
+		t.Errorf("default values are written, %s", s)
+	}
+	g2, err := Parser().Extensions(new(KHRLightsPunctual)).Reader(&out).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lightsPunctualTestCheck(t, g2)
+}
+
+func TestKHRLightsPunctualIssues(t *testing.T) {
+	for pointer, bad := range map[string]string{
+		"/nodes/0/extensions/KHR_lights_punctual/light":                strings.Replace(lightsPunctualTestJSON, `"light":1`, `"light":3`, 1),
+		"/extensions/KHR_lights_punctual/lights/1/spot":                strings.Replace(lightsPunctualTestJSON, `"spot":{"outerConeAngle":0.5},`, ``, 1),
+		"/extensions/KHR_lights_punctual/lights/0/type":                strings.Replace(lightsPunctualTestJSON, `"type":"point",`, ``, 1),
+		"/extensions/KHR_lights_punctual/lights/1/spot/outerConeAngle": strings.Replace(lightsPunctualTestJSON, `"outerConeAngle":0.5`, `"outerConeAngle":2`, 1),
+		"/extensions/KHR_lights_punctual/lights/0/intensity":           strings.Replace(lightsPunctualTestJSON, `"intensity":5`, `"intensity":-1`, 1),
+	} {
+		issues, err := Parser().Extensions(new(KHRLightsPunctual)).Reader(strings.NewReader(bad)).Validate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(issues) != 1 || issues[0].Pointer != pointer {
+			t.Errorf("issues %v, expected one issue of '%s'", issues, pointer)
+		}
+	}
+}
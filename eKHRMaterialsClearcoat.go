@@ -0,0 +1,120 @@
+package gltf2
+
+import (
+	"encoding/json"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Khronos/KHR_materials_clearcoat
+type KHRMaterialsClearcoat struct {
+	ClearcoatFactor           float32 // default 0
+	ClearcoatTexture          *TextureInfo
+	ClearcoatRoughnessFactor  float32 // default 0
+	ClearcoatRoughnessTexture *TextureInfo
+	ClearcoatNormalTexture    *MaterialNormalTextureInfo
+}
+
+func (s *KHRMaterialsClearcoat) ExtensionName() string {
+	return "KHR_materials_clearcoat"
+}
+func (s *KHRMaterialsClearcoat) ExtensionSchemes() []string {
+	return []string{SCHEME_MATERIAL}
+}
+func (s *KHRMaterialsClearcoat) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecKHRMaterialsClearcoat)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *KHRMaterialsClearcoat) textureSites() []textureSite {
+	return sitesOf(s.ClearcoatTexture, s.ClearcoatRoughnessTexture, s.ClearcoatNormalTexture)
+}
+func (s *KHRMaterialsClearcoat) Encode(ctx *encoderContext) (interface{}, error) {
+	var err error
+	res := &SpecKHRMaterialsClearcoat{
+		ClearcoatFactor:          optFloat32(s.ClearcoatFactor, 0),
+		ClearcoatRoughnessFactor: optFloat32(s.ClearcoatRoughnessFactor, 0),
+	}
+	if res.ClearcoatTexture, err = ctx.TextureInfo(s.ClearcoatTexture); err != nil {
+		return nil, err
+	}
+	if res.ClearcoatRoughnessTexture, err = ctx.TextureInfo(s.ClearcoatRoughnessTexture); err != nil {
+		return nil, err
+	}
+	if res.ClearcoatNormalTexture, err = ctx.NormalTextureInfo(s.ClearcoatNormalTexture); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type SpecKHRMaterialsClearcoat struct {
+	ClearcoatFactor           *float32                       `json:"clearcoatFactor,omitempty"` // default 0, range(0, 1)
+	ClearcoatTexture          *SpecTextureInfo               `json:"clearcoatTexture,omitempty"`
+	ClearcoatRoughnessFactor  *float32                       `json:"clearcoatRoughnessFactor,omitempty"` // default 0, range(0, 1)
+	ClearcoatRoughnessTexture *SpecTextureInfo               `json:"clearcoatRoughnessTexture,omitempty"`
+	ClearcoatNormalTexture    *SpecMaterialNormalTextureInfo `json:"clearcoatNormalTexture,omitempty"`
+}
+
+func (s *SpecKHRMaterialsClearcoat) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecKHRMaterialsClearcoat) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		fallthrough
+	case LEVEL2:
+		if s.ClearcoatFactor != nil && (*s.ClearcoatFactor < 0 || *s.ClearcoatFactor > 1) {
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/clearcoatFactor", "KHRMaterialsClearcoat.ClearcoatFactor range(0, 1), but got '%f'", *s.ClearcoatFactor)
+		}
+		if s.ClearcoatRoughnessFactor != nil && (*s.ClearcoatRoughnessFactor < 0 || *s.ClearcoatRoughnessFactor > 1) {
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/clearcoatRoughnessFactor", "KHRMaterialsClearcoat.ClearcoatRoughnessFactor range(0, 1), but got '%f'", *s.ClearcoatRoughnessFactor)
+		}
+		issues.materialExtension(parent, "KHRMaterialsClearcoat")
+		fallthrough
+	case LEVEL1:
+	}
+	return issues.Err()
+}
+func (s *SpecKHRMaterialsClearcoat) To(ctx *parserContext) interface{} {
+	res := new(KHRMaterialsClearcoat)
+	if s.ClearcoatFactor != nil {
+		res.ClearcoatFactor = *s.ClearcoatFactor
+	}
+	if s.ClearcoatRoughnessFactor != nil {
+		res.ClearcoatRoughnessFactor = *s.ClearcoatRoughnessFactor
+	}
+	return res
+}
+
+func (s *SpecKHRMaterialsClearcoat) children() specTextureChildren {
+	return specTextureChildren{}.
+		textureInfo("/clearcoatTexture", s.ClearcoatTexture, func(dst interface{}) interface{} {
+			return &dst.(*KHRMaterialsClearcoat).ClearcoatTexture
+		}).
+		textureInfo("/clearcoatRoughnessTexture", s.ClearcoatRoughnessTexture, func(dst interface{}) interface{} {
+			return &dst.(*KHRMaterialsClearcoat).ClearcoatRoughnessTexture
+		}).
+		normalTextureInfo("/clearcoatNormalTexture", s.ClearcoatNormalTexture, func(dst interface{}) interface{} {
+			return &dst.(*KHRMaterialsClearcoat).ClearcoatNormalTexture
+		})
+}
+func (s *SpecKHRMaterialsClearcoat) GetChild(i int) Specifier {
+	return s.children().GetChild(i)
+}
+func (s *SpecKHRMaterialsClearcoat) SetChild(i int, dst, object interface{}) {
+	s.children().SetChild(i, dst, object)
+}
+func (s *SpecKHRMaterialsClearcoat) ChildPointer(i int) string {
+	return s.children().ChildPointer(i)
+}
+func (s *SpecKHRMaterialsClearcoat) LenChild() int {
+	return s.children().LenChild()
+}
+func (s *SpecKHRMaterialsClearcoat) ImpleGetChild(i int, dst interface{}) interface{} {
+	return s.children().ImpleGetChild(i, dst)
+}
+
+func init() {
+	RegisterExtension(new(KHRMaterialsClearcoat))
+}
@@ -0,0 +1,72 @@
+package gltf2
+
+import (
+	"encoding/json"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Khronos/KHR_materials_emissive_strength
+//
+// Material.EmissiveFactor is multiplied by EmissiveStrength, see Material.EmissiveRadiance
+type KHRMaterialsEmissiveStrength struct {
+	EmissiveStrength float32 // default 1
+}
+
+func (s *KHRMaterialsEmissiveStrength) ExtensionName() string {
+	return "KHR_materials_emissive_strength"
+}
+func (s *KHRMaterialsEmissiveStrength) ExtensionSchemes() []string {
+	return []string{SCHEME_MATERIAL}
+}
+func (s *KHRMaterialsEmissiveStrength) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecKHRMaterialsEmissiveStrength)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *KHRMaterialsEmissiveStrength) Encode(ctx *encoderContext) (interface{}, error) {
+	return &SpecKHRMaterialsEmissiveStrength{
+		EmissiveStrength: optFloat32(s.EmissiveStrength, 1),
+	}, nil
+}
+
+type SpecKHRMaterialsEmissiveStrength struct {
+	EmissiveStrength *float32 `json:"emissiveStrength,omitempty"` // default 1, minimum(0)
+}
+
+func (s *SpecKHRMaterialsEmissiveStrength) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecKHRMaterialsEmissiveStrength) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		fallthrough
+	case LEVEL2:
+		if s.EmissiveStrength != nil && *s.EmissiveStrength < 0 {
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/emissiveStrength", "KHRMaterialsEmissiveStrength.EmissiveStrength minimum(0), but got '%f'", *s.EmissiveStrength)
+		}
+		issues.materialExtension(parent, "KHRMaterialsEmissiveStrength")
+		if m, ok := parent.(*SpecMaterial); ok && m.Extensions != nil {
+			if _, ok := (*m.Extensions)[new(KHRMaterialsUnlit).ExtensionName()]; ok {
+				issues.add(LEVEL2, CODE_UNSATISFIED_DEPENDENCY, "", "KHRMaterialsEmissiveStrength must not be used with KHRMaterialsUnlit")
+			}
+		}
+		fallthrough
+	case LEVEL1:
+	}
+	return issues.Err()
+}
+func (s *SpecKHRMaterialsEmissiveStrength) To(ctx *parserContext) interface{} {
+	res := new(KHRMaterialsEmissiveStrength)
+	if s.EmissiveStrength == nil {
+		res.EmissiveStrength = 1
+	} else {
+		res.EmissiveStrength = *s.EmissiveStrength
+	}
+	return res
+}
+
+func init() {
+	RegisterExtension(new(KHRMaterialsEmissiveStrength))
+}
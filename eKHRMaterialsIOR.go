@@ -0,0 +1,65 @@
+package gltf2
+
+import (
+	"encoding/json"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Khronos/KHR_materials_ior
+type KHRMaterialsIOR struct {
+	IOR float32 // default 1.5
+}
+
+func (s *KHRMaterialsIOR) ExtensionName() string {
+	return "KHR_materials_ior"
+}
+func (s *KHRMaterialsIOR) ExtensionSchemes() []string {
+	return []string{SCHEME_MATERIAL}
+}
+func (s *KHRMaterialsIOR) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecKHRMaterialsIOR)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *KHRMaterialsIOR) Encode(ctx *encoderContext) (interface{}, error) {
+	return &SpecKHRMaterialsIOR{
+		IOR: optFloat32(s.IOR, 1.5),
+	}, nil
+}
+
+type SpecKHRMaterialsIOR struct {
+	IOR *float32 `json:"ior,omitempty"` // default 1.5, 0 or minimum(1)
+}
+
+func (s *SpecKHRMaterialsIOR) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecKHRMaterialsIOR) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		fallthrough
+	case LEVEL2:
+		if s.IOR != nil && *s.IOR != 0 && *s.IOR < 1 {
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/ior", "KHRMaterialsIOR.IOR is 0 or minimum(1), but got '%f'", *s.IOR)
+		}
+		issues.materialExtension(parent, "KHRMaterialsIOR")
+		fallthrough
+	case LEVEL1:
+	}
+	return issues.Err()
+}
+func (s *SpecKHRMaterialsIOR) To(ctx *parserContext) interface{} {
+	res := new(KHRMaterialsIOR)
+	if s.IOR == nil {
+		res.IOR = 1.5
+	} else {
+		res.IOR = *s.IOR
+	}
+	return res
+}
+
+func init() {
+	RegisterExtension(new(KHRMaterialsIOR))
+}
@@ -15,6 +15,9 @@ type KHRMaterialsPBRSpecularGlossiness struct {
 func (s *KHRMaterialsPBRSpecularGlossiness) ExtensionName() string {
 	return "KHR_materials_pbrSpecularGlossiness"
 }
+func (s *KHRMaterialsPBRSpecularGlossiness) ExtensionSchemes() []string {
+	return []string{SCHEME_MATERIAL}
+}
 func (s *KHRMaterialsPBRSpecularGlossiness) Constructor(src []byte) (Specifier, error) {
 	res := new(SpecKHRMaterialsPBRSpecularGlossiness)
 	if err := json.Unmarshal(src, res); err != nil {
@@ -22,13 +25,37 @@ func (s *KHRMaterialsPBRSpecularGlossiness) Constructor(src []byte) (Specifier,
 	}
 	return res, nil
 }
+func (s *KHRMaterialsPBRSpecularGlossiness) textureSites() []textureSite {
+	return sitesOf(s.DiffuseTexture, s.SpecularGlossinessTexture)
+}
+func (s *KHRMaterialsPBRSpecularGlossiness) Encode(ctx *encoderContext) (interface{}, error) {
+	var err error
+	res := &SpecKHRMaterialsPBRSpecularGlossiness{
+		GlossinessFactor: optFloat32(s.GlossinessFactor, 1),
+	}
+	if s.DiffuseFactor != (mgl32.Vec4{1, 1, 1, 1}) {
+		factor := s.DiffuseFactor
+		res.DiffuseFactor = &factor
+	}
+	if s.SpecularFactor != (mgl32.Vec3{1, 1, 1}) {
+		factor := s.SpecularFactor
+		res.SpecularFactor = &factor
+	}
+	if res.DiffuseTexture, err = ctx.TextureInfo(s.DiffuseTexture); err != nil {
+		return nil, err
+	}
+	if res.SpecularGlossinessTexture, err = ctx.TextureInfo(s.SpecularGlossinessTexture); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
 
 type SpecKHRMaterialsPBRSpecularGlossiness struct {
-	DiffuseFactor             *mgl32.Vec4      `json:"diffuseFactor"` // default:[1.0,1.0,1.0,1.0]
-	DiffuseTexture            *SpecTextureInfo `json:"diffuseTexture"`
-	SpecularFactor            *mgl32.Vec3      `json:"specularFactor"`   // default:[1.0,1.0,1.0]
-	GlossinessFactor          *float32         `json:"glossinessFactor"` // default:1.0
-	SpecularGlossinessTexture *SpecTextureInfo `json:"specularGlossinessTexture"`
+	DiffuseFactor             *mgl32.Vec4      `json:"diffuseFactor,omitempty"` // default:[1.0,1.0,1.0,1.0]
+	DiffuseTexture            *SpecTextureInfo `json:"diffuseTexture,omitempty"`
+	SpecularFactor            *mgl32.Vec3      `json:"specularFactor,omitempty"`   // default:[1.0,1.0,1.0]
+	GlossinessFactor          *float32         `json:"glossinessFactor,omitempty"` // default:1.0
+	SpecularGlossinessTexture *SpecTextureInfo `json:"specularGlossinessTexture,omitempty"`
 }
 
 func (s *SpecKHRMaterialsPBRSpecularGlossiness) Scheme() string {
@@ -77,6 +104,12 @@ func (s *SpecKHRMaterialsPBRSpecularGlossiness) SetChild(i int, dst, object inte
 		}
 	}
 }
+func (s *SpecKHRMaterialsPBRSpecularGlossiness) ChildPointer(i int) string {
+	if s.Children()[i] == Specifier(s.DiffuseTexture) {
+		return "/diffuseTexture"
+	}
+	return "/specularGlossinessTexture"
+}
 func (s *SpecKHRMaterialsPBRSpecularGlossiness) LenChild() int {
 	return len(s.Children())
 }
@@ -107,3 +140,7 @@ func (s *SpecKHRMaterialsPBRSpecularGlossiness) ImpleGetChild(i int, dst interfa
 	}
 	return nil
 }
+
+func init() {
+	RegisterExtension(new(KHRMaterialsPBRSpecularGlossiness))
+}
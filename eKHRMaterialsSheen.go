@@ -0,0 +1,116 @@
+package gltf2
+
+import (
+	"encoding/json"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Khronos/KHR_materials_sheen
+type KHRMaterialsSheen struct {
+	SheenColorFactor      mgl32.Vec3 // default [0, 0, 0]
+	SheenColorTexture     *TextureInfo
+	SheenRoughnessFactor  float32 // default 0
+	SheenRoughnessTexture *TextureInfo
+}
+
+func (s *KHRMaterialsSheen) ExtensionName() string {
+	return "KHR_materials_sheen"
+}
+func (s *KHRMaterialsSheen) ExtensionSchemes() []string {
+	return []string{SCHEME_MATERIAL}
+}
+func (s *KHRMaterialsSheen) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecKHRMaterialsSheen)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *KHRMaterialsSheen) textureSites() []textureSite {
+	return sitesOf(s.SheenColorTexture, s.SheenRoughnessTexture)
+}
+func (s *KHRMaterialsSheen) Encode(ctx *encoderContext) (interface{}, error) {
+	var err error
+	res := &SpecKHRMaterialsSheen{
+		SheenRoughnessFactor: optFloat32(s.SheenRoughnessFactor, 0),
+	}
+	if s.SheenColorFactor != (mgl32.Vec3{0, 0, 0}) {
+		factor := s.SheenColorFactor
+		res.SheenColorFactor = &factor
+	}
+	if res.SheenColorTexture, err = ctx.TextureInfo(s.SheenColorTexture); err != nil {
+		return nil, err
+	}
+	if res.SheenRoughnessTexture, err = ctx.TextureInfo(s.SheenRoughnessTexture); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type SpecKHRMaterialsSheen struct {
+	SheenColorFactor      *mgl32.Vec3      `json:"sheenColorFactor,omitempty"` // default [0, 0, 0], range(0, 1)
+	SheenColorTexture     *SpecTextureInfo `json:"sheenColorTexture,omitempty"`
+	SheenRoughnessFactor  *float32         `json:"sheenRoughnessFactor,omitempty"` // default 0, range(0, 1)
+	SheenRoughnessTexture *SpecTextureInfo `json:"sheenRoughnessTexture,omitempty"`
+}
+
+func (s *SpecKHRMaterialsSheen) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecKHRMaterialsSheen) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		fallthrough
+	case LEVEL2:
+		if s.SheenColorFactor != nil && !isValidF32Color3(*s.SheenColorFactor) {
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/sheenColorFactor", "KHRMaterialsSheen.SheenColorFactor validate(f32Color)")
+		}
+		if s.SheenRoughnessFactor != nil && (*s.SheenRoughnessFactor < 0 || *s.SheenRoughnessFactor > 1) {
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/sheenRoughnessFactor", "KHRMaterialsSheen.SheenRoughnessFactor range(0, 1), but got '%f'", *s.SheenRoughnessFactor)
+		}
+		issues.materialExtension(parent, "KHRMaterialsSheen")
+		fallthrough
+	case LEVEL1:
+	}
+	return issues.Err()
+}
+func (s *SpecKHRMaterialsSheen) To(ctx *parserContext) interface{} {
+	res := new(KHRMaterialsSheen)
+	if s.SheenColorFactor != nil {
+		res.SheenColorFactor = *s.SheenColorFactor
+	}
+	if s.SheenRoughnessFactor != nil {
+		res.SheenRoughnessFactor = *s.SheenRoughnessFactor
+	}
+	return res
+}
+
+func (s *SpecKHRMaterialsSheen) children() specTextureChildren {
+	return specTextureChildren{}.
+		textureInfo("/sheenColorTexture", s.SheenColorTexture, func(dst interface{}) interface{} {
+			return &dst.(*KHRMaterialsSheen).SheenColorTexture
+		}).
+		textureInfo("/sheenRoughnessTexture", s.SheenRoughnessTexture, func(dst interface{}) interface{} {
+			return &dst.(*KHRMaterialsSheen).SheenRoughnessTexture
+		})
+}
+func (s *SpecKHRMaterialsSheen) GetChild(i int) Specifier {
+	return s.children().GetChild(i)
+}
+func (s *SpecKHRMaterialsSheen) SetChild(i int, dst, object interface{}) {
+	s.children().SetChild(i, dst, object)
+}
+func (s *SpecKHRMaterialsSheen) ChildPointer(i int) string {
+	return s.children().ChildPointer(i)
+}
+func (s *SpecKHRMaterialsSheen) LenChild() int {
+	return s.children().LenChild()
+}
+func (s *SpecKHRMaterialsSheen) ImpleGetChild(i int, dst interface{}) interface{} {
+	return s.children().ImpleGetChild(i, dst)
+}
+
+func init() {
+	RegisterExtension(new(KHRMaterialsSheen))
+}
@@ -0,0 +1,120 @@
+package gltf2
+
+import (
+	"encoding/json"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Khronos/KHR_materials_specular
+type KHRMaterialsSpecular struct {
+	SpecularFactor       float32 // default 1
+	SpecularTexture      *TextureInfo
+	SpecularColorFactor  mgl32.Vec3 // default [1, 1, 1]
+	SpecularColorTexture *TextureInfo
+}
+
+func (s *KHRMaterialsSpecular) ExtensionName() string {
+	return "KHR_materials_specular"
+}
+func (s *KHRMaterialsSpecular) ExtensionSchemes() []string {
+	return []string{SCHEME_MATERIAL}
+}
+func (s *KHRMaterialsSpecular) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecKHRMaterialsSpecular)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *KHRMaterialsSpecular) textureSites() []textureSite {
+	return sitesOf(s.SpecularTexture, s.SpecularColorTexture)
+}
+func (s *KHRMaterialsSpecular) Encode(ctx *encoderContext) (interface{}, error) {
+	var err error
+	res := &SpecKHRMaterialsSpecular{
+		SpecularFactor: optFloat32(s.SpecularFactor, 1),
+	}
+	if s.SpecularColorFactor != (mgl32.Vec3{1, 1, 1}) {
+		factor := s.SpecularColorFactor
+		res.SpecularColorFactor = &factor
+	}
+	if res.SpecularTexture, err = ctx.TextureInfo(s.SpecularTexture); err != nil {
+		return nil, err
+	}
+	if res.SpecularColorTexture, err = ctx.TextureInfo(s.SpecularColorTexture); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type SpecKHRMaterialsSpecular struct {
+	SpecularFactor       *float32         `json:"specularFactor,omitempty"` // default 1, range(0, 1)
+	SpecularTexture      *SpecTextureInfo `json:"specularTexture,omitempty"`
+	SpecularColorFactor  *mgl32.Vec3      `json:"specularColorFactor,omitempty"` // default [1, 1, 1], minimum(0)
+	SpecularColorTexture *SpecTextureInfo `json:"specularColorTexture,omitempty"`
+}
+
+func (s *SpecKHRMaterialsSpecular) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecKHRMaterialsSpecular) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		fallthrough
+	case LEVEL2:
+		if s.SpecularFactor != nil && (*s.SpecularFactor < 0 || *s.SpecularFactor > 1) {
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/specularFactor", "KHRMaterialsSpecular.SpecularFactor range(0, 1), but got '%f'", *s.SpecularFactor)
+		}
+		if c := s.SpecularColorFactor; c != nil && (c[0] < 0 || c[1] < 0 || c[2] < 0) {
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/specularColorFactor", "KHRMaterialsSpecular.SpecularColorFactor minimum(0), but got '%v'", *c)
+		}
+		issues.materialExtension(parent, "KHRMaterialsSpecular")
+		fallthrough
+	case LEVEL1:
+	}
+	return issues.Err()
+}
+func (s *SpecKHRMaterialsSpecular) To(ctx *parserContext) interface{} {
+	res := new(KHRMaterialsSpecular)
+	if s.SpecularFactor == nil {
+		res.SpecularFactor = 1
+	} else {
+		res.SpecularFactor = *s.SpecularFactor
+	}
+	if s.SpecularColorFactor == nil {
+		res.SpecularColorFactor = mgl32.Vec3{1, 1, 1}
+	} else {
+		res.SpecularColorFactor = *s.SpecularColorFactor
+	}
+	return res
+}
+
+func (s *SpecKHRMaterialsSpecular) children() specTextureChildren {
+	return specTextureChildren{}.
+		textureInfo("/specularTexture", s.SpecularTexture, func(dst interface{}) interface{} {
+			return &dst.(*KHRMaterialsSpecular).SpecularTexture
+		}).
+		textureInfo("/specularColorTexture", s.SpecularColorTexture, func(dst interface{}) interface{} {
+			return &dst.(*KHRMaterialsSpecular).SpecularColorTexture
+		})
+}
+func (s *SpecKHRMaterialsSpecular) GetChild(i int) Specifier {
+	return s.children().GetChild(i)
+}
+func (s *SpecKHRMaterialsSpecular) SetChild(i int, dst, object interface{}) {
+	s.children().SetChild(i, dst, object)
+}
+func (s *SpecKHRMaterialsSpecular) ChildPointer(i int) string {
+	return s.children().ChildPointer(i)
+}
+func (s *SpecKHRMaterialsSpecular) LenChild() int {
+	return s.children().LenChild()
+}
+func (s *SpecKHRMaterialsSpecular) ImpleGetChild(i int, dst interface{}) interface{} {
+	return s.children().ImpleGetChild(i, dst)
+}
+
+func init() {
+	RegisterExtension(new(KHRMaterialsSpecular))
+}
@@ -0,0 +1,95 @@
+package gltf2
+
+import (
+	"encoding/json"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Khronos/KHR_materials_transmission
+type KHRMaterialsTransmission struct {
+	TransmissionFactor  float32 // default 0
+	TransmissionTexture *TextureInfo
+}
+
+func (s *KHRMaterialsTransmission) ExtensionName() string {
+	return "KHR_materials_transmission"
+}
+func (s *KHRMaterialsTransmission) ExtensionSchemes() []string {
+	return []string{SCHEME_MATERIAL}
+}
+func (s *KHRMaterialsTransmission) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecKHRMaterialsTransmission)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *KHRMaterialsTransmission) textureSites() []textureSite {
+	return sitesOf(s.TransmissionTexture)
+}
+func (s *KHRMaterialsTransmission) Encode(ctx *encoderContext) (interface{}, error) {
+	var err error
+	res := &SpecKHRMaterialsTransmission{
+		TransmissionFactor: optFloat32(s.TransmissionFactor, 0),
+	}
+	if res.TransmissionTexture, err = ctx.TextureInfo(s.TransmissionTexture); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type SpecKHRMaterialsTransmission struct {
+	TransmissionFactor  *float32         `json:"transmissionFactor,omitempty"` // default 0, range(0, 1)
+	TransmissionTexture *SpecTextureInfo `json:"transmissionTexture,omitempty"`
+}
+
+func (s *SpecKHRMaterialsTransmission) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecKHRMaterialsTransmission) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		fallthrough
+	case LEVEL2:
+		if s.TransmissionFactor != nil && (*s.TransmissionFactor < 0 || *s.TransmissionFactor > 1) {
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/transmissionFactor", "KHRMaterialsTransmission.TransmissionFactor range(0, 1), but got '%f'", *s.TransmissionFactor)
+		}
+		issues.materialExtension(parent, "KHRMaterialsTransmission")
+		fallthrough
+	case LEVEL1:
+	}
+	return issues.Err()
+}
+func (s *SpecKHRMaterialsTransmission) To(ctx *parserContext) interface{} {
+	res := new(KHRMaterialsTransmission)
+	if s.TransmissionFactor != nil {
+		res.TransmissionFactor = *s.TransmissionFactor
+	}
+	return res
+}
+
+func (s *SpecKHRMaterialsTransmission) children() specTextureChildren {
+	return specTextureChildren{}.
+		textureInfo("/transmissionTexture", s.TransmissionTexture, func(dst interface{}) interface{} {
+			return &dst.(*KHRMaterialsTransmission).TransmissionTexture
+		})
+}
+func (s *SpecKHRMaterialsTransmission) GetChild(i int) Specifier {
+	return s.children().GetChild(i)
+}
+func (s *SpecKHRMaterialsTransmission) SetChild(i int, dst, object interface{}) {
+	s.children().SetChild(i, dst, object)
+}
+func (s *SpecKHRMaterialsTransmission) ChildPointer(i int) string {
+	return s.children().ChildPointer(i)
+}
+func (s *SpecKHRMaterialsTransmission) LenChild() int {
+	return s.children().LenChild()
+}
+func (s *SpecKHRMaterialsTransmission) ImpleGetChild(i int, dst interface{}) interface{} {
+	return s.children().ImpleGetChild(i, dst)
+}
+
+func init() {
+	RegisterExtension(new(KHRMaterialsTransmission))
+}
@@ -0,0 +1,54 @@
+package gltf2
+
+import (
+	"encoding/json"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Khronos/KHR_materials_unlit
+//
+// Material with it is shaded by base color only, it has no property
+type KHRMaterialsUnlit struct {
+}
+
+func (s *KHRMaterialsUnlit) ExtensionName() string {
+	return "KHR_materials_unlit"
+}
+func (s *KHRMaterialsUnlit) ExtensionSchemes() []string {
+	return []string{SCHEME_MATERIAL}
+}
+func (s *KHRMaterialsUnlit) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecKHRMaterialsUnlit)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *KHRMaterialsUnlit) Encode(ctx *encoderContext) (interface{}, error) {
+	return &SpecKHRMaterialsUnlit{}, nil
+}
+
+type SpecKHRMaterialsUnlit struct {
+}
+
+func (s *SpecKHRMaterialsUnlit) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecKHRMaterialsUnlit) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		fallthrough
+	case LEVEL2:
+		issues.materialExtension(parent, "KHRMaterialsUnlit")
+		fallthrough
+	case LEVEL1:
+	}
+	return issues.Err()
+}
+func (s *SpecKHRMaterialsUnlit) To(ctx *parserContext) interface{} {
+	return new(KHRMaterialsUnlit)
+}
+
+func init() {
+	RegisterExtension(new(KHRMaterialsUnlit))
+}
@@ -0,0 +1,362 @@
+package gltf2
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Khronos/KHR_materials_variants
+//
+// glTF level extension have Variants, mesh primitive level extension have Mappings which give material of variants
+//
+// ex) materials, err := gltf.SelectVariant("red")
+type KHRMaterialsVariants struct {
+	Variants []*KHRMaterialsVariant
+	Mappings []*KHRMaterialsVariantMapping
+	// Default is MeshPrimitive.Material when it is parsed, it is used for primitive without mapping
+	//
+	// nil if primitive has no material, then Encoder writes primitive without material
+	Default *Material
+	// selected is material set by SelectVariant, Encoder writes Default while primitive keeps it
+	selected    *Material
+	hasSelected bool
+}
+
+func (s *KHRMaterialsVariants) ExtensionName() string {
+	return "KHR_materials_variants"
+}
+func (s *KHRMaterialsVariants) ExtensionSchemes() []string {
+	return []string{SCHEME_GLTF, SCHEME_MESH_PRIMITIVE}
+}
+func (s *KHRMaterialsVariants) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecKHRMaterialsVariants)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *KHRMaterialsVariants) Encode(ctx *encoderContext) (interface{}, error) {
+	res := new(SpecKHRMaterialsVariants)
+	if len(s.Mappings) > 0 {
+		variants := ctx.GLTF().Variants()
+		for i, mapping := range s.Mappings {
+			spec := SpecKHRMaterialsVariantMapping{
+				Material: ctx.Material(mapping.Material),
+				Name:     optString(mapping.Name),
+				Extras:   mapping.Extras,
+			}
+			for _, v := range mapping.Variants {
+				id := variantIndex(variants, v)
+				if id < 0 {
+					return nil, errors.Errorf("KHRMaterialsVariants.Mappings[%d] variant is not in glTF level KHRMaterialsVariants.Variants", i)
+				}
+				spec.Variants = append(spec.Variants, SpecGLTFID(id))
+			}
+			res.Mappings = append(res.Mappings, spec)
+		}
+	}
+	for _, variant := range s.Variants {
+		spec := SpecKHRMaterialsVariant{
+			Name:   &variant.Name,
+			Extras: variant.Extras,
+		}
+		var err error
+		if spec.Extensions, err = ctx.Extensions(variant.Extensions); err != nil {
+			return nil, err
+		}
+		res.Variants = append(res.Variants, spec)
+	}
+	return res, nil
+}
+
+// Material is material of variant, Default if there is no mapping of variant
+func (s *KHRMaterialsVariants) Material(variant *KHRMaterialsVariant) *Material {
+	for _, mapping := range s.Mappings {
+		for _, v := range mapping.Variants {
+			if v == variant {
+				return mapping.Material
+			}
+		}
+	}
+	return s.Default
+}
+
+type KHRMaterialsVariant struct {
+	Name       string
+	Extensions *Extensions
+	Extras     *Extras
+	// None spec
+	UserData interface{}
+}
+
+func (s *KHRMaterialsVariant) GetExtension() *Extensions {
+	return s.Extensions
+}
+func (s *KHRMaterialsVariant) SetExtension(extensions *Extensions) {
+	s.Extensions = extensions
+}
+
+type KHRMaterialsVariantMapping struct {
+	Material *Material
+	Variants []*KHRMaterialsVariant
+	Name     string
+	Extras   *Extras
+}
+
+// Variants is glTF level KHR_materials_variants variants
+func (s *GLTF) Variants() []*KHRMaterialsVariant {
+	if s.Extensions == nil {
+		return nil
+	}
+	if ext, ok := s.Extensions.Get(new(KHRMaterialsVariants)).(*KHRMaterialsVariants); ok {
+		return ext.Variants
+	}
+	return nil
+}
+
+// VariantMaterials is material of every MeshPrimitive which has KHR_materials_variants for variant name
+//
+// Empty name is default material of primitives
+func (s *GLTF) VariantMaterials(name string) (map[*MeshPrimitive]*Material, error) {
+	var variant *KHRMaterialsVariant
+	if name != "" {
+		for _, v := range s.Variants() {
+			if v.Name == name {
+				variant = v
+				break
+			}
+		}
+		if variant == nil {
+			return nil, errors.Errorf("KHRMaterialsVariants variant '%s' not found", name)
+		}
+	}
+	res := make(map[*MeshPrimitive]*Material)
+	for _, mesh := range s.Meshes {
+		for _, prim := range mesh.Primitives {
+			if prim.Extensions == nil {
+				continue
+			}
+			if ext, ok := prim.Extensions.Get(new(KHRMaterialsVariants)).(*KHRMaterialsVariants); ok {
+				res[prim] = ext.Material(variant)
+			}
+		}
+	}
+	return res, nil
+}
+
+// SelectVariant set MeshPrimitive.Material to VariantMaterials of name, empty name restore default material
+//
+// Encoder writes Default as material of primitive, so selected variant is not saved.
+// But material assigned to primitive after SelectVariant is written as it is.
+func (s *GLTF) SelectVariant(name string) (map[*MeshPrimitive]*Material, error) {
+	res, err := s.VariantMaterials(name)
+	if err != nil {
+		return nil, err
+	}
+	for prim, material := range res {
+		prim.Material = material
+		ext := prim.Extensions.Get(new(KHRMaterialsVariants)).(*KHRMaterialsVariants)
+		ext.selected, ext.hasSelected = material, true
+	}
+	return res, nil
+}
+
+// variantDefaultMaterial is material of primitive without selected variant, it is written by Encoder
+func variantDefaultMaterial(prim *MeshPrimitive) *Material {
+	if prim.Extensions == nil {
+		return prim.Material
+	}
+	ext, ok := prim.Extensions.Get(new(KHRMaterialsVariants)).(*KHRMaterialsVariants)
+	if ok && len(ext.Mappings) > 0 && ext.hasSelected && prim.Material == ext.selected {
+		return ext.Default
+	}
+	return prim.Material
+}
+
+// variantIndex is index of variant, -1 if not found
+func variantIndex(variants []*KHRMaterialsVariant, variant *KHRMaterialsVariant) int {
+	for i, v := range variants {
+		if v == variant {
+			return i
+		}
+	}
+	return -1
+}
+
+type SpecKHRMaterialsVariants struct {
+	Variants []SpecKHRMaterialsVariant        `json:"variants,omitempty"` // glTF level, minItems(1)
+	Mappings []SpecKHRMaterialsVariantMapping `json:"mappings,omitempty"` // mesh primitive level, minItems(1)
+}
+
+func (s *SpecKHRMaterialsVariants) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecKHRMaterialsVariants) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	_, atRoot := parent.(*SpecGLTF)
+	_, atPrimitive := parent.(*SpecMeshPrimitive)
+	switch strictness {
+	case LEVEL3:
+		fallthrough
+	case LEVEL2:
+		if !atRoot && s.Variants != nil {
+			issues.add(LEVEL2, CODE_UNDEFINED_PROPERTY, "/variants", "KHRMaterialsVariants.Variants is glTF level property")
+		}
+		if !atPrimitive && s.Mappings != nil {
+			issues.add(LEVEL2, CODE_UNDEFINED_PROPERTY, "/mappings", "KHRMaterialsVariants.Mappings is mesh primitive level property")
+		}
+		// variant is used once per primitive, it include duplicate in one mapping
+		used := make(map[SpecGLTFID]bool)
+		for i, mapping := range s.Mappings {
+			for _, v := range mapping.Variants {
+				if used[v] {
+					issues.add(LEVEL2, CODE_DUPLICATE_ELEMENTS, fmt.Sprintf("/mappings/%d/variants", i), "KHRMaterialsVariants variant '%d' is mapped more than once", v)
+				}
+				used[v] = true
+			}
+		}
+		fallthrough
+	case LEVEL1:
+		if atRoot && len(s.Variants) == 0 {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/variants", "KHRMaterialsVariants.Variants required")
+		}
+		if atPrimitive {
+			if len(s.Mappings) == 0 {
+				issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/mappings", "KHRMaterialsVariants.Mappings required")
+			}
+			g, _ := root.(*SpecGLTF)
+			for i, mapping := range s.Mappings {
+				pointer := fmt.Sprintf("/mappings/%d", i)
+				if mapping.Material == nil {
+					issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, pointer+"/material", "KHRMaterialsVariantMapping.Material required")
+				}
+				if len(mapping.Variants) == 0 {
+					issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, pointer+"/variants", "KHRMaterialsVariantMapping.Variants required")
+				}
+				if g != nil {
+					issues.reference(mapping.Material, len(g.Materials), pointer+"/material", "KHRMaterialsVariantMapping.Material")
+				}
+				variants := khrMaterialsVariantsOf(root)
+				for j := range mapping.Variants {
+					issues.reference(&mapping.Variants[j], len(variants), fmt.Sprintf("%s/variants/%d", pointer, j), "KHRMaterialsVariantMapping.Variants")
+				}
+			}
+		}
+	}
+	return issues.Err()
+}
+func (s *SpecKHRMaterialsVariants) To(ctx *parserContext) interface{} {
+	res := new(KHRMaterialsVariants)
+	if len(s.Variants) > 0 {
+		res.Variants = make([]*KHRMaterialsVariant, len(s.Variants))
+	}
+	for _, v := range s.Mappings {
+		mapping := &KHRMaterialsVariantMapping{Extras: v.Extras}
+		if v.Name != nil {
+			mapping.Name = *v.Name
+		}
+		res.Mappings = append(res.Mappings, mapping)
+	}
+	return res
+}
+func (s *SpecKHRMaterialsVariants) Link(Root *GLTF, parent interface{}, dst interface{}) error {
+	res := dst.(*KHRMaterialsVariants)
+	if prim, ok := parent.(*MeshPrimitive); ok {
+		res.Default = prim.Material
+	}
+	if len(s.Mappings) == 0 {
+		return nil
+	}
+	variants := Root.Variants()
+	for i, v := range s.Mappings {
+		if !inRange(*v.Material, len(Root.Materials)) {
+			return errors.Errorf("KHRMaterialsVariantMapping.Material linking fail, %s", *v.Material)
+		}
+		res.Mappings[i].Material = Root.Materials[*v.Material]
+		res.Mappings[i].Variants = make([]*KHRMaterialsVariant, len(v.Variants))
+		for j, id := range v.Variants {
+			if !inRange(id, len(variants)) {
+				return errors.Errorf("KHRMaterialsVariantMapping.Variants linking fail, %s", id)
+			}
+			res.Mappings[i].Variants[j] = variants[id]
+		}
+	}
+	return nil
+}
+
+func (s *SpecKHRMaterialsVariants) GetChild(i int) Specifier {
+	return &s.Variants[i]
+}
+func (s *SpecKHRMaterialsVariants) SetChild(i int, dst, object interface{}) {
+	dst.(*KHRMaterialsVariants).Variants[i] = object.(*KHRMaterialsVariant)
+}
+func (s *SpecKHRMaterialsVariants) ChildPointer(i int) string {
+	return fmt.Sprintf("/variants/%d", i)
+}
+func (s *SpecKHRMaterialsVariants) LenChild() int {
+	return len(s.Variants)
+}
+func (s *SpecKHRMaterialsVariants) ImpleGetChild(i int, dst interface{}) interface{} {
+	return dst.(*KHRMaterialsVariants).Variants[i]
+}
+
+// khrMaterialsVariantsOf is glTF level variants of root, it is used to check mappings
+func khrMaterialsVariantsOf(root Specifier) []SpecKHRMaterialsVariant {
+	g, ok := root.(*SpecGLTF)
+	if !ok || g.Extensions == nil {
+		return nil
+	}
+	if raw, ok := (*g.Extensions)[new(KHRMaterialsVariants).ExtensionName()]; ok && raw.data != nil {
+		if ext, ok := raw.data.(*SpecKHRMaterialsVariants); ok {
+			return ext.Variants
+		}
+	}
+	return nil
+}
+
+type SpecKHRMaterialsVariant struct {
+	Name       *string         `json:"name"` // required
+	Extensions *SpecExtensions `json:"extensions,omitempty"`
+	Extras     *Extras         `json:"extras,omitempty"`
+}
+
+func (s *SpecKHRMaterialsVariant) SpecExtension() *SpecExtensions {
+	return s.Extensions
+}
+func (s *SpecKHRMaterialsVariant) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecKHRMaterialsVariant) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		fallthrough
+	case LEVEL2:
+		fallthrough
+	case LEVEL1:
+		if s.Name == nil {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/name", "KHRMaterialsVariant.Name required")
+		}
+	}
+	return issues.Err()
+}
+func (s *SpecKHRMaterialsVariant) To(ctx *parserContext) interface{} {
+	res := new(KHRMaterialsVariant)
+	if s.Name != nil {
+		res.Name = *s.Name
+	}
+	res.Extras = s.Extras
+	return res
+}
+
+type SpecKHRMaterialsVariantMapping struct {
+	Material *SpecGLTFID  `json:"material"` // required
+	Variants []SpecGLTFID `json:"variants"` // required, minItems(1), unique
+	Name     *string      `json:"name,omitempty"`
+	Extras   *Extras      `json:"extras,omitempty"`
+}
+
+func init() {
+	RegisterExtension(new(KHRMaterialsVariants))
+}
@@ -0,0 +1,104 @@
+package gltf2
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSelectVariantEncode(t *testing.T) {
+	js := `{"asset":{"version":"2.0"},"extensionsUsed":["KHR_materials_variants"],
+	"extensions":{"KHR_materials_variants":{"variants":[{"name":"red"},{"name":"blue"}]}},
+	"materials":[{"name":"base"},{"name":"r"},{"name":"b"}],
+	"accessors":[{"componentType":5126,"count":1,"type":"VEC3","min":[0,0,0],"max":[0,0,0]}],
+	"meshes":[{"primitives":[{"attributes":{"POSITION":0},"material":0,"extensions":{"KHR_materials_variants":{"mappings":[{"material":1,"variants":[0]},{"material":2,"variants":[1]}]}}}]}]}`
+	g, err := Parser().Extensions(new(KHRMaterialsVariants)).Reader(strings.NewReader(js)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.SelectVariant("blue"); err != nil {
+		t.Fatal(err)
+	}
+	if g.Meshes[0].Primitives[0].Material != g.Materials[2] {
+		t.Fatal("variant 'blue' is not selected")
+	}
+	var out bytes.Buffer
+	if err := Encoder(&out).Encode(g); err != nil {
+		t.Fatal(err)
+	}
+	g2, err := Parser().Extensions(new(KHRMaterialsVariants)).Reader(&out).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prim := g2.Meshes[0].Primitives[0]
+	// default material is kept, selected variant is not written
+	if prim.Material != g2.Materials[0] {
+		t.Errorf("MeshPrimitive.Material '%s', expected 'base'", prim.Material.Name)
+	}
+	materials, err := g2.VariantMaterials("blue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if materials[prim] != g2.Materials[2] {
+		t.Errorf("variant 'blue' material is not 'b'")
+	}
+}
+
+// selectVariantTestEncode encode g and parse it again
+func selectVariantTestEncode(t *testing.T, g *GLTF) (*GLTF, string) {
+	var out bytes.Buffer
+	if err := Encoder(&out).Encode(g); err != nil {
+		t.Fatal(err)
+	}
+	js := out.String()
+	res, err := Parser().Extensions(new(KHRMaterialsVariants)).Reader(&out).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res, js
+}
+
+func TestSelectVariantEncodeNoDefault(t *testing.T) {
+	js := `{"asset":{"version":"2.0"},"extensionsUsed":["KHR_materials_variants"],
+	"extensions":{"KHR_materials_variants":{"variants":[{"name":"red"}]}},
+	"materials":[{"name":"r"}],
+	"accessors":[{"componentType":5126,"count":1,"type":"VEC3","min":[0,0,0],"max":[0,0,0]}],
+	"meshes":[{"primitives":[{"attributes":{"POSITION":0},"extensions":{"KHR_materials_variants":{"mappings":[{"material":0,"variants":[0]}]}}}]}]}`
+	g, err := Parser().Extensions(new(KHRMaterialsVariants)).Reader(strings.NewReader(js)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.SelectVariant("red"); err != nil {
+		t.Fatal(err)
+	}
+	g2, out := selectVariantTestEncode(t, g)
+	// primitive without material is written without material
+	if prim := g2.Meshes[0].Primitives[0]; prim.Material != nil {
+		t.Errorf("MeshPrimitive.Material '%s', expected nil, %s", prim.Material.Name, out)
+	}
+}
+
+func TestSelectVariantEncodeReassign(t *testing.T) {
+	js := `{"asset":{"version":"2.0"},"extensionsUsed":["KHR_materials_variants"],
+	"extensions":{"KHR_materials_variants":{"variants":[{"name":"red"}]}},
+	"materials":[{"name":"base"},{"name":"r"},{"name":"other"}],
+	"accessors":[{"componentType":5126,"count":1,"type":"VEC3","min":[0,0,0],"max":[0,0,0]}],
+	"meshes":[{"primitives":[{"attributes":{"POSITION":0},"material":0,"extensions":{"KHR_materials_variants":{"mappings":[{"material":1,"variants":[0]}]}}}]}]}`
+	for _, selected := range []bool{false, true} {
+		g, err := Parser().Extensions(new(KHRMaterialsVariants)).Reader(strings.NewReader(js)).Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if selected {
+			if _, err := g.SelectVariant("red"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// material assigned by user is written
+		g.Meshes[0].Primitives[0].Material = g.Materials[2]
+		g2, out := selectVariantTestEncode(t, g)
+		if prim := g2.Meshes[0].Primitives[0]; prim.Material == nil || prim.Material.Name != "other" {
+			t.Errorf("selected %v, MeshPrimitive.Material is not 'other', %s", selected, out)
+		}
+	}
+}
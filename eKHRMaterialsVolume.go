@@ -0,0 +1,125 @@
+package gltf2
+
+import (
+	"encoding/json"
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Khronos/KHR_materials_volume
+type KHRMaterialsVolume struct {
+	ThicknessFactor  float32 // default 0
+	ThicknessTexture *TextureInfo
+	// +Inf if it is undefined
+	AttenuationDistance float32
+	AttenuationColor    mgl32.Vec3 // default [1, 1, 1]
+}
+
+func (s *KHRMaterialsVolume) ExtensionName() string {
+	return "KHR_materials_volume"
+}
+func (s *KHRMaterialsVolume) ExtensionSchemes() []string {
+	return []string{SCHEME_MATERIAL}
+}
+func (s *KHRMaterialsVolume) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecKHRMaterialsVolume)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *KHRMaterialsVolume) textureSites() []textureSite {
+	return sitesOf(s.ThicknessTexture)
+}
+func (s *KHRMaterialsVolume) Encode(ctx *encoderContext) (interface{}, error) {
+	var err error
+	res := &SpecKHRMaterialsVolume{
+		ThicknessFactor: optFloat32(s.ThicknessFactor, 0),
+	}
+	if res.ThicknessTexture, err = ctx.TextureInfo(s.ThicknessTexture); err != nil {
+		return nil, err
+	}
+	if !math.IsInf(float64(s.AttenuationDistance), 1) {
+		res.AttenuationDistance = float32Ptr(s.AttenuationDistance)
+	}
+	if s.AttenuationColor != (mgl32.Vec3{1, 1, 1}) {
+		color := s.AttenuationColor
+		res.AttenuationColor = &color
+	}
+	return res, nil
+}
+
+type SpecKHRMaterialsVolume struct {
+	ThicknessFactor     *float32         `json:"thicknessFactor,omitempty"` // default 0, minimum(0)
+	ThicknessTexture    *SpecTextureInfo `json:"thicknessTexture,omitempty"`
+	AttenuationDistance *float32         `json:"attenuationDistance,omitempty"` // default +Inf, exclusiveMinimum(0)
+	AttenuationColor    *mgl32.Vec3      `json:"attenuationColor,omitempty"`    // default [1, 1, 1], range(0, 1)
+}
+
+func (s *SpecKHRMaterialsVolume) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecKHRMaterialsVolume) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		fallthrough
+	case LEVEL2:
+		if s.ThicknessFactor != nil && *s.ThicknessFactor < 0 {
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/thicknessFactor", "KHRMaterialsVolume.ThicknessFactor minimum(0), but got '%f'", *s.ThicknessFactor)
+		}
+		if s.AttenuationDistance != nil && *s.AttenuationDistance <= 0 {
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/attenuationDistance", "KHRMaterialsVolume.AttenuationDistance exclusiveMinimum(0), but got '%f'", *s.AttenuationDistance)
+		}
+		if s.AttenuationColor != nil && !isValidF32Color3(*s.AttenuationColor) {
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/attenuationColor", "KHRMaterialsVolume.AttenuationColor validate(f32Color)")
+		}
+		issues.materialExtension(parent, "KHRMaterialsVolume")
+		fallthrough
+	case LEVEL1:
+	}
+	return issues.Err()
+}
+func (s *SpecKHRMaterialsVolume) To(ctx *parserContext) interface{} {
+	res := new(KHRMaterialsVolume)
+	if s.ThicknessFactor != nil {
+		res.ThicknessFactor = *s.ThicknessFactor
+	}
+	if s.AttenuationDistance == nil {
+		res.AttenuationDistance = float32(math.Inf(1))
+	} else {
+		res.AttenuationDistance = *s.AttenuationDistance
+	}
+	if s.AttenuationColor == nil {
+		res.AttenuationColor = mgl32.Vec3{1, 1, 1}
+	} else {
+		res.AttenuationColor = *s.AttenuationColor
+	}
+	return res
+}
+
+func (s *SpecKHRMaterialsVolume) children() specTextureChildren {
+	return specTextureChildren{}.
+		textureInfo("/thicknessTexture", s.ThicknessTexture, func(dst interface{}) interface{} {
+			return &dst.(*KHRMaterialsVolume).ThicknessTexture
+		})
+}
+func (s *SpecKHRMaterialsVolume) GetChild(i int) Specifier {
+	return s.children().GetChild(i)
+}
+func (s *SpecKHRMaterialsVolume) SetChild(i int, dst, object interface{}) {
+	s.children().SetChild(i, dst, object)
+}
+func (s *SpecKHRMaterialsVolume) ChildPointer(i int) string {
+	return s.children().ChildPointer(i)
+}
+func (s *SpecKHRMaterialsVolume) LenChild() int {
+	return s.children().LenChild()
+}
+func (s *SpecKHRMaterialsVolume) ImpleGetChild(i int, dst interface{}) interface{} {
+	return s.children().ImpleGetChild(i, dst)
+}
+
+func init() {
+	RegisterExtension(new(KHRMaterialsVolume))
+}
@@ -0,0 +1,113 @@
+package gltf2
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const materialsTestJSON = `{"asset":{"version":"2.0"},
+	"extensionsUsed":["KHR_materials_clearcoat","KHR_materials_transmission","KHR_materials_volume","KHR_materials_ior","KHR_materials_specular","KHR_materials_sheen"],
+	"images":[{"uri":"a.png"}],"textures":[{"source":0},{"source":0}],
+	"materials":[{"extensions":{
+		"KHR_materials_clearcoat":{"clearcoatFactor":0.5,"clearcoatNormalTexture":{"index":1,"scale":2}},
+		"KHR_materials_transmission":{"transmissionFactor":0.3,"transmissionTexture":{"index":0}},
+		"KHR_materials_volume":{"thicknessFactor":1,"thicknessTexture":{"index":1,"texCoord":1}},
+		"KHR_materials_ior":{"ior":1.4},
+		"KHR_materials_specular":{"specularTexture":{"index":0},"specularColorTexture":{"index":1}},
+		"KHR_materials_sheen":{"sheenColorFactor":[1,0,0],"sheenRoughnessFactor":0.2,"sheenColorTexture":{"index":0}}
+	}},{"extensions":{"KHR_materials_ior":{}}}]}`
+
+func materialsTestParser(src string) *parser {
+	return Parser().Extensions(
+		new(KHRMaterialsClearcoat), new(KHRMaterialsTransmission), new(KHRMaterialsVolume),
+		new(KHRMaterialsIOR), new(KHRMaterialsSpecular), new(KHRMaterialsSheen),
+	).Reader(strings.NewReader(src))
+}
+
+// materialsTestCheck check materials of materialsTestJSON
+func materialsTestCheck(t *testing.T, g *GLTF) {
+	m := g.Materials[0]
+	cc := m.Extensions.Get(new(KHRMaterialsClearcoat)).(*KHRMaterialsClearcoat)
+	if cc.ClearcoatFactor != 0.5 || cc.ClearcoatNormalTexture == nil || cc.ClearcoatNormalTexture.Index != g.Textures[1] || cc.ClearcoatNormalTexture.Scale != 2 {
+		t.Errorf("clearcoat %+v", cc)
+	}
+	tr := m.Extensions.Get(new(KHRMaterialsTransmission)).(*KHRMaterialsTransmission)
+	if tr.TransmissionFactor != 0.3 || tr.TransmissionTexture == nil || tr.TransmissionTexture.Index != g.Textures[0] {
+		t.Errorf("transmission %+v", tr)
+	}
+	// default attenuation is +Inf and white
+	vol := m.Extensions.Get(new(KHRMaterialsVolume)).(*KHRMaterialsVolume)
+	if vol.ThicknessTexture == nil || vol.ThicknessTexture.Index != g.Textures[1] || vol.ThicknessTexture.TexCoord != 1 ||
+		!math.IsInf(float64(vol.AttenuationDistance), 1) || vol.AttenuationColor != (mgl32.Vec3{1, 1, 1}) {
+		t.Errorf("volume %+v", vol)
+	}
+	if ior := m.Extensions.Get(new(KHRMaterialsIOR)).(*KHRMaterialsIOR); ior.IOR != 1.4 {
+		t.Errorf("ior %v, expected 1.4", ior.IOR)
+	}
+	if ior := g.Materials[1].Extensions.Get(new(KHRMaterialsIOR)).(*KHRMaterialsIOR); ior.IOR != 1.5 {
+		t.Errorf("default ior %v, expected 1.5", ior.IOR)
+	}
+	sp := m.Extensions.Get(new(KHRMaterialsSpecular)).(*KHRMaterialsSpecular)
+	if sp.SpecularFactor != 1 || sp.SpecularColorFactor != (mgl32.Vec3{1, 1, 1}) || sp.SpecularTexture == nil || sp.SpecularColorTexture == nil || sp.SpecularColorTexture.Index != g.Textures[1] {
+		t.Errorf("specular %+v", sp)
+	}
+	sh := m.Extensions.Get(new(KHRMaterialsSheen)).(*KHRMaterialsSheen)
+	if sh.SheenColorFactor != (mgl32.Vec3{1, 0, 0}) || sh.SheenRoughnessFactor != 0.2 || sh.SheenColorTexture == nil || sh.SheenRoughnessTexture != nil {
+		t.Errorf("sheen %+v", sh)
+	}
+	// textures of extensions are visited by KHR_texture_transform
+	if n := len(materialTextureSites(m)); n != 6 {
+		t.Errorf("%d texture sites, expected 6", n)
+	}
+}
+
+func TestKHRMaterials(t *testing.T) {
+	issues, err := materialsTestParser(materialsTestJSON).Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("issues %v", issues)
+	}
+	g, err := materialsTestParser(materialsTestJSON).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	materialsTestCheck(t, g)
+	var out bytes.Buffer
+	if err := Encoder(&out).Encode(g); err != nil {
+		t.Fatal(err)
+	}
+	// default values are not written
+	for _, v := range []string{"attenuationDistance", "attenuationColor", "specularFactor", `"ior":1.5`} {
+		if strings.Contains(out.String(), v) {
+			t.Errorf("default %s is written, %s", v, out.String())
+		}
+	}
+	g2, err := materialsTestParser(out.String()).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	materialsTestCheck(t, g2)
+}
+
+func TestKHRMaterialsIssues(t *testing.T) {
+	for pointer, bad := range map[string]string{
+		"/materials/0/extensions/KHR_materials_ior/ior":                                strings.Replace(materialsTestJSON, `"ior":1.4`, `"ior":0.5`, 1),
+		"/materials/0/extensions/KHR_materials_transmission/transmissionFactor":        strings.Replace(materialsTestJSON, `"transmissionFactor":0.3`, `"transmissionFactor":2`, 1),
+		"/materials/0/extensions/KHR_materials_clearcoat/clearcoatNormalTexture/index": strings.Replace(materialsTestJSON, `"index":1,"scale":2`, `"index":5,"scale":2`, 1),
+		"/materials/0/extensions/KHR_materials_sheen/sheenColorFactor":                 strings.Replace(materialsTestJSON, `"sheenColorFactor":[1,0,0]`, `"sheenColorFactor":[2,0,0]`, 1),
+	} {
+		issues, err := materialsTestParser(bad).Validate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(issues) != 1 || issues[0].Pointer != pointer {
+			t.Errorf("issues %v, expected one issue of '%s'", issues, pointer)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package gltf2
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/iamGreedy/glog"
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Khronos/KHR_mesh_quantization
+//
+// It has no property, it is only in ExtensionsUsed(ExtensionsRequired)
+// POSITION, NORMAL, TANGENT, TEXCOORD_n can be BYTE, SHORT with it, see AttributeKey.AssociateType
+type KHRMeshQuantization struct {
+}
+
+func (s *KHRMeshQuantization) ExtensionName() string {
+	return "KHR_mesh_quantization"
+}
+func (s *KHRMeshQuantization) ExtensionSchemes() []string {
+	return []string{}
+}
+func (s *KHRMeshQuantization) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecKHRMeshQuantization)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *KHRMeshQuantization) Encode(ctx *encoderContext) (interface{}, error) {
+	return &SpecKHRMeshQuantization{}, nil
+}
+
+type SpecKHRMeshQuantization struct {
+}
+
+func (s *SpecKHRMeshQuantization) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecKHRMeshQuantization) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	return nil
+}
+func (s *SpecKHRMeshQuantization) To(ctx *parserContext) interface{} {
+	return new(KHRMeshQuantization)
+}
+
+// _Dequantize rewrite quantized POSITION, NORMAL, TANGENT, TEXCOORD_n accessors(morph targets too) to FLOAT
+//
+// Accessor is modified in place, so every reference is kept. Converted data is appended to new buffer,
+// old bufferView is not removed. Normalized value is converted to float of its range, ex) normalized SHORT to [-1, 1]
+func _Dequantize(parser *parserContext, gltf *GLTF, logger *glog.Glogger) error {
+	var (
+		b    *builder
+		done = make(map[*Accessor]bool)
+		temp = make(map[*Accessor]bool)
+	)
+	dequantize := func(key AttributeKey, accessor *Accessor) error {
+		switch key.Semantic() {
+		case "POSITION", "NORMAL", "TANGENT", "TEXCOORD":
+		default:
+			return nil
+		}
+		if accessor == nil || accessor.ComponentType == FLOAT || done[accessor] {
+			return nil
+		}
+		done[accessor] = true
+		if b == nil {
+			b = Builder(gltf, nil)
+		}
+		res, err := dequantizeAccessor(b, accessor)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("'%s'", key))
+		}
+		temp[res] = true
+		logger.Printf("'%s' %s of %s to %s", key, accessor.Type, accessor.ComponentType, res.ComponentType)
+		accessor.BufferView = res.BufferView
+		accessor.ByteOffset = 0
+		accessor.ComponentType = res.ComponentType
+		accessor.Normalized = false
+		accessor.Sparse = nil
+		if len(accessor.Min) > 0 || len(accessor.Max) > 0 {
+			accessor.Min, accessor.Max = res.Min, res.Max
+		}
+		return nil
+	}
+	for i, mesh := range gltf.Meshes {
+		for j, prim := range mesh.Primitives {
+			for _, k := range sortedAccessorKeys(prim.Attributes) {
+				if err := dequantize(k, prim.Attributes[k]); err != nil {
+					return errors.WithMessage(err, fmt.Sprintf("glTF.Meshes[%d].Primitives[%d].Attributes", i, j))
+				}
+			}
+			for t, target := range prim.Targets {
+				for _, k := range sortedAccessorKeys(target) {
+					if err := dequantize(k, target[k]); err != nil {
+						return errors.WithMessage(err, fmt.Sprintf("glTF.Meshes[%d].Primitives[%d].Targets[%d]", i, j, t))
+					}
+				}
+			}
+		}
+	}
+	if b == nil {
+		return nil
+	}
+	// builder accessors are only used as data of dequantized accessor
+	// they are after source accessors, so glTFid of source accessor is kept, ex) glTFid in RawExtension
+	accessors := gltf.Accessors[:0]
+	for _, v := range gltf.Accessors {
+		if !temp[v] {
+			accessors = append(accessors, v)
+		}
+	}
+	gltf.Accessors = accessors
+	name := new(KHRMeshQuantization).ExtensionName()
+	gltf.ExtensionsUsed = removeExtensionName(gltf.ExtensionsUsed, name)
+	gltf.ExtensionsRequired = removeExtensionName(gltf.ExtensionsRequired, name)
+	return nil
+}
+
+// dequantizeAccessor append FLOAT data of accessor with builder
+func dequantizeAccessor(b *builder, accessor *Accessor) (*Accessor, error) {
+	fs, err := ReadFloats(accessor)
+	if err != nil {
+		return nil, err
+	}
+	n := accessor.Type.Count()
+	data := reflect.MakeSlice(reflect.SliceOf(reflect.ArrayOf(n, reflect.TypeOf(float32(0)))), accessor.Count, accessor.Count)
+	for i := 0; i < accessor.Count; i++ {
+		elem := data.Index(i)
+		for j := 0; j < n; j++ {
+			elem.Index(j).SetFloat(float64(fs[i*n+j]))
+		}
+	}
+	return b.Target(ARRAY_BUFFER).Type(accessor.Type).Accessor(data.Interface())
+}
+
+func init() {
+	RegisterExtension(new(KHRMeshQuantization))
+}
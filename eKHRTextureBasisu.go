@@ -0,0 +1,94 @@
+package gltf2
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Khronos/KHR_texture_basisu
+//
+// It is extension of Texture, Source is KTX2 image used instead of Texture.Source
+// Source can be loaded by Image.Load(ETC1S, UASTC) or KHRTextureBasisu.KTX2 for raw mip levels,
+// KTX2.Validate report KTX2 which can't be loaded
+type KHRTextureBasisu struct {
+	Source Image
+}
+
+func (s *KHRTextureBasisu) ExtensionName() string {
+	return "KHR_texture_basisu"
+}
+func (s *KHRTextureBasisu) ExtensionSchemes() []string {
+	return []string{SCHEME_TEXTURE}
+}
+func (s *KHRTextureBasisu) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecKHRTextureBasisu)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *KHRTextureBasisu) Encode(ctx *encoderContext) (interface{}, error) {
+	return &SpecKHRTextureBasisu{
+		Source: ctx.Image(s.Source),
+	}, nil
+}
+
+func (s *KHRTextureBasisu) source() Image {
+	return s.Source
+}
+
+// KTX2 load KTX2 container of Source without transcode
+func (s *KHRTextureBasisu) KTX2() (*KTX2, error) {
+	src, ok := s.Source.(interface {
+		LoadRaw() ([]byte, error)
+	})
+	if !ok {
+		return nil, errors.Errorf("KHRTextureBasisu.Source '%T' can't be loaded raw", s.Source)
+	}
+	data, err := src.LoadRaw()
+	if err != nil {
+		return nil, err
+	}
+	return DecodeKTX2(data)
+}
+
+type SpecKHRTextureBasisu struct {
+	Source *SpecGLTFID `json:"source"` // required
+}
+
+func (s *SpecKHRTextureBasisu) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecKHRTextureBasisu) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		fallthrough
+	case LEVEL2:
+		issues.textureExtension(parent, "KHRTextureBasisu")
+		issues.imageMimeType(root, s.Source, ImageKTX2, "/source", "KHRTextureBasisu.Source")
+		fallthrough
+	case LEVEL1:
+		if s.Source == nil {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/source", "KHRTextureBasisu.Source required")
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.Source, len(g.Images), "/source", "KHRTextureBasisu.Source")
+		}
+	}
+	return issues.Err()
+}
+func (s *SpecKHRTextureBasisu) To(ctx *parserContext) interface{} {
+	return new(KHRTextureBasisu)
+}
+func (s *SpecKHRTextureBasisu) Link(Root *GLTF, parent interface{}, dst interface{}) error {
+	if !inRange(*s.Source, len(Root.Images)) {
+		return errors.Errorf("KHRTextureBasisu.Source linking fail, %s", *s.Source)
+	}
+	dst.(*KHRTextureBasisu).Source = Root.Images[*s.Source]
+	return nil
+}
+
+func init() {
+	RegisterExtension(new(KHRTextureBasisu))
+}
@@ -0,0 +1,316 @@
+package gltf2
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/iamGreedy/glog"
+	"math"
+	"sort"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Khronos/KHR_texture_transform
+//
+// It can be extension of TextureInfo, MaterialNormalTextureInfo, MaterialOcclusionTextureInfo
+type KHRTextureTransform struct {
+	Offset   mgl32.Vec2 // default [0, 0]
+	Rotation float32    // default 0, radian
+	Scale    mgl32.Vec2 // default [1, 1]
+	// nil if it is undefined, otherwise it override texCoord of textureInfo
+	TexCoord *IndexTexCoord
+}
+
+func (s *KHRTextureTransform) ExtensionName() string {
+	return "KHR_texture_transform"
+}
+func (s *KHRTextureTransform) ExtensionSchemes() []string {
+	return []string{SCHEME_TEXTURE_INFO, SCHEME_MATERIAL_NORMAL_TEXTUREINFO, SCHEME_MATERIAL_OCCLUSION_TEXTUREINFO}
+}
+func (s *KHRTextureTransform) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecKHRTextureTransform)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *KHRTextureTransform) Encode(ctx *encoderContext) (interface{}, error) {
+	res := &SpecKHRTextureTransform{
+		Rotation: optFloat32(s.Rotation, 0),
+		TexCoord: s.TexCoord,
+	}
+	if s.Offset != (mgl32.Vec2{0, 0}) {
+		offset := s.Offset
+		res.Offset = &offset
+	}
+	if s.Scale != (mgl32.Vec2{1, 1}) {
+		scale := s.Scale
+		res.Scale = &scale
+	}
+	return res, nil
+}
+
+// Matrix is UV transform, Translation * Rotation * Scale
+//
+// uv' = Matrix().Mul3x1(uv.Vec3(1)).Vec2()
+func (s *KHRTextureTransform) Matrix() mgl32.Mat3 {
+	sin, cos := float32(math.Sin(float64(s.Rotation))), float32(math.Cos(float64(s.Rotation)))
+	translation := mgl32.Translate2D(s.Offset[0], s.Offset[1])
+	rotation := mgl32.Mat3{
+		cos, -sin, 0,
+		sin, cos, 0,
+		0, 0, 1,
+	}
+	scale := mgl32.Scale2D(s.Scale[0], s.Scale[1])
+	return translation.Mul3(rotation).Mul3(scale)
+}
+
+type SpecKHRTextureTransform struct {
+	Offset   *mgl32.Vec2    `json:"offset,omitempty"`   // default [0, 0]
+	Rotation *float32       `json:"rotation,omitempty"` // default 0
+	Scale    *mgl32.Vec2    `json:"scale,omitempty"`    // default [1, 1]
+	TexCoord *IndexTexCoord `json:"texCoord,omitempty"` // minimum(0)
+}
+
+func (s *SpecKHRTextureTransform) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecKHRTextureTransform) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		if s.TexCoord != nil && *s.TexCoord < 0 {
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/texCoord", "KHRTextureTransform.TexCoord minimum(0), but got '%d'", *s.TexCoord)
+		}
+		fallthrough
+	case LEVEL2:
+		switch parent.(type) {
+		case *SpecTextureInfo, *SpecMaterialNormalTextureInfo, *SpecMaterialOcclusionTextureInfo:
+		default:
+			issues.add(LEVEL2, CODE_UNDEFINED_PROPERTY, "", "KHRTextureTransform is extension of textureInfo, but it is in '%s'", parent.Scheme())
+		}
+		fallthrough
+	case LEVEL1:
+	}
+	return issues.Err()
+}
+func (s *SpecKHRTextureTransform) To(ctx *parserContext) interface{} {
+	res := new(KHRTextureTransform)
+	if s.Offset != nil {
+		res.Offset = *s.Offset
+	}
+	if s.Rotation != nil {
+		res.Rotation = *s.Rotation
+	}
+	if s.Scale == nil {
+		res.Scale = mgl32.Vec2{1, 1}
+	} else {
+		res.Scale = *s.Scale
+	}
+	if s.TexCoord != nil {
+		texCoord := *s.TexCoord
+		res.TexCoord = &texCoord
+	}
+	return res
+}
+
+// textureSite is one textureInfo of material
+type textureSite struct {
+	texture    *Texture
+	texCoord   *IndexTexCoord
+	extensions *Extensions
+}
+
+func (s textureSite) transform() *KHRTextureTransform {
+	if s.extensions == nil {
+		return nil
+	}
+	res, _ := s.extensions.GetByName(new(KHRTextureTransform).ExtensionName()).(*KHRTextureTransform)
+	return res
+}
+
+// materialTextures is material extension which has textureInfo
+type materialTextures interface {
+	textureSites() []textureSite
+}
+
+// sitesOf is textureSite of non nil *TextureInfo, *MaterialNormalTextureInfo, *MaterialOcclusionTextureInfo
+func sitesOf(infos ...interface{}) (res []textureSite) {
+	for _, info := range infos {
+		switch v := info.(type) {
+		case *TextureInfo:
+			if v != nil {
+				res = append(res, textureSite{v.Index, &v.TexCoord, v.Extensions})
+			}
+		case *MaterialNormalTextureInfo:
+			if v != nil {
+				res = append(res, textureSite{v.Index, &v.TexCoord, v.Extensions})
+			}
+		case *MaterialOcclusionTextureInfo:
+			if v != nil {
+				res = append(res, textureSite{v.Index, &v.TexCoord, v.Extensions})
+			}
+		}
+	}
+	return res
+}
+
+// materialTextureSites is every textureInfo of material, include material extensions which is materialTextures
+func materialTextureSites(material *Material) (res []textureSite) {
+	if pbr := material.PBRMetallicRoughness; pbr != nil {
+		res = append(res, sitesOf(pbr.BaseColorTexture, pbr.MetallicRoughnessTexture)...)
+	}
+	res = append(res, sitesOf(material.NormalTexture, material.OcclusionTexture, material.EmissiveTexture)...)
+	if material.Extensions != nil {
+		names := make([]string, 0, len(*material.Extensions))
+		for k := range *material.Extensions {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		for _, k := range names {
+			if v, ok := (*material.Extensions)[k].(materialTextures); ok {
+				res = append(res, v.textureSites()...)
+			}
+		}
+	}
+	return res
+}
+
+// _BakeTextureTransform apply KHR_texture_transform to TEXCOORD accessor, and remove the extension
+//
+// It bakes textureInfo only if its texture is used by one material, and every textureInfo of the material using same texCoord has same transform.
+// TEXCOORD accessor must be FLOAT without sparse, and must not be shared with primitive of another material.
+func _BakeTextureTransform(parser *parserContext, gltf *GLTF, logger *glog.Glogger) error {
+	type group struct {
+		material *Material
+		texCoord IndexTexCoord
+	}
+	var (
+		materials    = make(map[*Material]int)
+		textureUsers = make(map[*Texture]map[*Material]bool)
+		accessorUser = make(map[*Accessor]map[group]bool)
+		groups       = make(map[group][]textureSite)
+		order        []group
+	)
+	for i, material := range gltf.Materials {
+		materials[material] = i
+		for _, site := range materialTextureSites(material) {
+			if textureUsers[site.texture] == nil {
+				textureUsers[site.texture] = make(map[*Material]bool)
+			}
+			textureUsers[site.texture][material] = true
+			texCoord := *site.texCoord
+			if t := site.transform(); t != nil && t.TexCoord != nil {
+				texCoord = *t.TexCoord
+			}
+			g := group{material, texCoord}
+			if _, ok := groups[g]; !ok {
+				order = append(order, g)
+			}
+			groups[g] = append(groups[g], site)
+		}
+	}
+	primitives := make(map[*Material][]*MeshPrimitive)
+	for _, mesh := range gltf.Meshes {
+		for _, prim := range mesh.Primitives {
+			primitives[prim.Material] = append(primitives[prim.Material], prim)
+			for k, v := range prim.Attributes {
+				var set IndexTexCoord
+				if _, err := fmt.Sscanf(string(k), "TEXCOORD_%d", &set); err != nil {
+					continue
+				}
+				if accessorUser[v] == nil {
+					accessorUser[v] = make(map[group]bool)
+				}
+				accessorUser[v][group{prim.Material, set}] = true
+			}
+		}
+	}
+	for _, g := range order {
+		sites := groups[g]
+		transform := sites[0].transform()
+		if transform == nil {
+			continue
+		}
+		bakable := true
+		for _, site := range sites {
+			if t := site.transform(); t == nil || t.Offset != transform.Offset || t.Rotation != transform.Rotation || t.Scale != transform.Scale {
+				bakable = false
+			}
+			if len(textureUsers[site.texture]) > 1 {
+				bakable = false
+			}
+		}
+		var (
+			accessors []*Accessor
+			visited   = make(map[*Accessor]bool)
+		)
+		for _, prim := range primitives[g.material] {
+			accessor, ok := prim.Attributes[AttributeKey(fmt.Sprintf("TEXCOORD_%d", g.texCoord))]
+			// primitives of the material can share accessor, it must be baked once
+			if !ok || visited[accessor] {
+				continue
+			}
+			visited[accessor] = true
+			if len(accessorUser[accessor]) > 1 || accessor.ComponentType != FLOAT || accessor.Type != VEC2 || accessor.Sparse != nil || accessor.BufferView == nil {
+				bakable = false
+			}
+			accessors = append(accessors, accessor)
+		}
+		if len(g.material.Name) > 0 {
+			logger.Printf("glTF.Materials['%s'] TEXCOORD_%d", g.material.Name, g.texCoord)
+		} else {
+			logger.Printf("glTF.Materials[%d] TEXCOORD_%d", materials[g.material], g.texCoord)
+		}
+		inner := logger.Indent()
+		if !bakable {
+			inner.Println("Shared or not FLOAT VEC2, skip")
+			continue
+		}
+		mtx := transform.Matrix()
+		for _, accessor := range accessors {
+			if _, err := accessor.BufferView.Buffer.Modify(); err != nil {
+				return err
+			}
+			err := accessor.ForEach(func(i int, elem []byte) error {
+				uv := mgl32.Vec2{
+					math.Float32frombits(binary.LittleEndian.Uint32(elem[0:])),
+					math.Float32frombits(binary.LittleEndian.Uint32(elem[4:])),
+				}
+				uv = mtx.Mul3x1(uv.Vec3(1)).Vec2()
+				binary.LittleEndian.PutUint32(elem[0:], math.Float32bits(uv[0]))
+				binary.LittleEndian.PutUint32(elem[4:], math.Float32bits(uv[1]))
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if len(accessor.Min) > 0 || len(accessor.Max) > 0 {
+				if accessor.Min, accessor.Max, err = accessorMinMax(accessor); err != nil {
+					return err
+				}
+			}
+		}
+		for _, site := range sites {
+			*site.texCoord = g.texCoord
+			delete(*site.extensions, transform.ExtensionName())
+		}
+		inner.Printf("Baked %d accessors", len(accessors))
+	}
+	// extension is removed only if every textureInfo is baked
+	name := new(KHRTextureTransform).ExtensionName()
+	for _, material := range gltf.Materials {
+		for _, site := range materialTextureSites(material) {
+			if site.transform() != nil {
+				return nil
+			}
+		}
+	}
+	gltf.ExtensionsUsed = removeExtensionName(gltf.ExtensionsUsed, name)
+	gltf.ExtensionsRequired = removeExtensionName(gltf.ExtensionsRequired, name)
+	return nil
+}
+
+func init() {
+	RegisterExtension(new(KHRTextureTransform))
+}
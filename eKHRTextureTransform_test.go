@@ -0,0 +1,87 @@
+package gltf2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestBakeTextureTransformSharedAccessor(t *testing.T) {
+	g, err := Parser().Reader(strings.NewReader(`{"asset":{"version":"2.0"}}`)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	transform := &KHRTextureTransform{Offset: mgl32.Vec2{0.5, 0.25}, Scale: mgl32.Vec2{1, 1}}
+	var (
+		ext      = Extensions{transform.ExtensionName(): transform}
+		texture  = &Texture{}
+		material = &Material{PBRMetallicRoughness: &MaterialPBRMetallicRoughness{
+			BaseColorTexture: &TextureInfo{Index: texture, Extensions: &ext},
+		}}
+		b     = Builder(g, nil)
+		first = &MeshPrimitive{Material: material}
+	)
+	b.Attribute(first, POSITION, []mgl32.Vec3{{0, 0, 0}, {1, 0, 0}})
+	b.Attribute(first, TEXCOORD_0, []mgl32.Vec2{{0, 0}, {1, 1}})
+	// second primitive of same material shares every accessor
+	second := &MeshPrimitive{Material: material, Attributes: first.Attributes}
+	g.Materials = append(g.Materials, material)
+	g.Textures = append(g.Textures, texture)
+	g.Meshes = append(g.Meshes, &Mesh{Primitives: []*MeshPrimitive{first, second}})
+	g.ExtensionsUsed = []string{transform.ExtensionName()}
+	g.ExtensionsRequired = []string{transform.ExtensionName()}
+
+	if err := Tasks.BakeTextureTransform.(PostTask).PostLoad(nil, g, nil); err != nil {
+		t.Fatal(err)
+	}
+	uvs, err := ReadVec2f(first.Attributes[TEXCOORD_0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []mgl32.Vec2{{0.5, 0.25}, {1.5, 1.25}}
+	for i := range expected {
+		if !uvs[i].ApproxEqualThreshold(expected[i], 1e-6) {
+			t.Errorf("TEXCOORD_0[%d] %v, expected %v", i, uvs[i], expected[i])
+		}
+	}
+	if len(ext) != 0 {
+		t.Errorf("KHR_texture_transform is not removed from textureInfo")
+	}
+	if len(g.ExtensionsUsed) != 0 || len(g.ExtensionsRequired) != 0 {
+		t.Errorf("extensionsUsed %v, extensionsRequired %v, expected empty", g.ExtensionsUsed, g.ExtensionsRequired)
+	}
+}
+
+func TestBakeTextureTransformKeepExtension(t *testing.T) {
+	g, err := Parser().Reader(strings.NewReader(`{"asset":{"version":"2.0"}}`)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	transform := &KHRTextureTransform{Offset: mgl32.Vec2{0.5, 0}, Scale: mgl32.Vec2{1, 1}}
+	var (
+		ext     = Extensions{transform.ExtensionName(): transform}
+		texture = &Texture{}
+		b       = Builder(g, nil)
+	)
+	// texture used by two materials is not baked
+	for i := 0; i < 2; i++ {
+		material := &Material{PBRMetallicRoughness: &MaterialPBRMetallicRoughness{
+			BaseColorTexture: &TextureInfo{Index: texture, Extensions: &ext},
+		}}
+		prim := &MeshPrimitive{Material: material}
+		b.Attribute(prim, POSITION, []mgl32.Vec3{{0, 0, 0}})
+		b.Attribute(prim, TEXCOORD_0, []mgl32.Vec2{{0, 0}})
+		g.Materials = append(g.Materials, material)
+		g.Meshes = append(g.Meshes, &Mesh{Primitives: []*MeshPrimitive{prim}})
+	}
+	g.Textures = append(g.Textures, texture)
+	g.ExtensionsUsed = []string{transform.ExtensionName()}
+
+	if err := Tasks.BakeTextureTransform.(PostTask).PostLoad(nil, g, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(ext) != 1 || len(g.ExtensionsUsed) != 1 {
+		t.Errorf("KHR_texture_transform must be kept, textureInfo %v, extensionsUsed %v", ext, g.ExtensionsUsed)
+	}
+}
@@ -0,0 +1,254 @@
+package gltf2
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/pkg/errors"
+	"image"
+	"math"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Vendor/MSFT_lod
+//
+// It is extension of Node or Material, Ids is lower detail of parent from high to low
+// Nodes is used for node extension, Materials is used for material extension
+// Screen coverage of levels is 'MSFT_screencoverage' in Extras of parent, see Node.SelectLOD
+type MSFTLod struct {
+	Nodes     []*Node
+	Materials []*Material
+}
+
+const msftScreenCoverage = "MSFT_screencoverage"
+
+func (s *MSFTLod) ExtensionName() string {
+	return "MSFT_lod"
+}
+func (s *MSFTLod) ExtensionSchemes() []string {
+	return []string{SCHEME_NODE, SCHEME_MATERIAL}
+}
+func (s *MSFTLod) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecMSFTLod)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *MSFTLod) Encode(ctx *encoderContext) (interface{}, error) {
+	res := new(SpecMSFTLod)
+	for _, node := range s.Nodes {
+		res.Ids = append(res.Ids, *ctx.Node(node))
+	}
+	for _, material := range s.Materials {
+		res.Ids = append(res.Ids, *ctx.Material(material))
+	}
+	return res, nil
+}
+
+// LODs is node itself and MSFT_lod nodes, from high detail to low detail
+func (s *Node) LODs() []*Node {
+	res := []*Node{s}
+	if s.Extensions != nil {
+		if ext, ok := s.Extensions.Get(new(MSFTLod)).(*MSFTLod); ok {
+			res = append(res, ext.Nodes...)
+		}
+	}
+	return res
+}
+
+// SelectLOD is one of LODs for screen coverage, nil if coverage is smaller than last MSFT_screencoverage
+//
+// Without MSFT_screencoverage, node itself is selected
+// ex) lod := node.SelectLOD(ScreenCoverage(node, parentWorld, view, camera.Setting, monitorSize))
+func (s *Node) SelectLOD(coverage float32) *Node {
+	lods := s.LODs()
+	if i := selectLOD(screenCoverageOf(s.Extras), len(lods), coverage); i >= 0 {
+		return lods[i]
+	}
+	return nil
+}
+
+// LODs is material itself and MSFT_lod materials, from high detail to low detail
+func (s *Material) LODs() []*Material {
+	res := []*Material{s}
+	if s.Extensions != nil {
+		if ext, ok := s.Extensions.Get(new(MSFTLod)).(*MSFTLod); ok {
+			res = append(res, ext.Materials...)
+		}
+	}
+	return res
+}
+
+// SelectLOD is one of LODs for screen coverage, same as Node.SelectLOD
+func (s *Material) SelectLOD(coverage float32) *Material {
+	lods := s.LODs()
+	if i := selectLOD(screenCoverageOf(s.Extras), len(lods), coverage); i >= 0 {
+		return lods[i]
+	}
+	return nil
+}
+
+// ScreenCoverage is projected height of node bounds(children too) over screen height, in [0, 1]
+//
+// parent is world matrix of node parent, view is inverse of camera world matrix, projection is CameraSetting.View
+// If bounds is behind of camera, it is 1
+func ScreenCoverage(node *Node, parent, view mgl32.Mat4, camera CameraSetting, monitorSize image.Point) float32 {
+	min, max, ok := uMinMax(node)
+	if !ok {
+		return 0
+	}
+	mtx := camera.View(monitorSize).Mul4(view).Mul4(parent)
+	var low, high float32 = math.MaxFloat32, -math.MaxFloat32
+	for i := 0; i < 8; i++ {
+		var p mgl32.Vec3
+		for j := range p {
+			if i>>uint(j)&1 == 0 {
+				p[j] = min[j]
+			} else {
+				p[j] = max[j]
+			}
+		}
+		clip := mtx.Mul4x1(p.Vec4(1))
+		if clip[3] <= 0 {
+			return 1
+		}
+		y := clip[1] / clip[3]
+		if y < low {
+			low = y
+		}
+		if y > high {
+			high = y
+		}
+	}
+	// NDC height is 2
+	return mgl32.Clamp((mgl32.Clamp(high, -1, 1)-mgl32.Clamp(low, -1, 1))/2, 0, 1)
+}
+
+// selectLOD is index of level, -1 if it is culled
+//
+// coverages[i] is minimum coverage of level i, coverage smaller than last one is culled
+// Level without coverage is used for smaller coverage than every coverages
+func selectLOD(coverages []float32, levels int, coverage float32) int {
+	for i, v := range coverages {
+		if i >= levels {
+			break
+		}
+		if coverage >= v {
+			return i
+		}
+	}
+	if len(coverages) < levels {
+		return len(coverages)
+	}
+	return -1
+}
+
+// screenCoverageOf is MSFT_screencoverage of extras, nil if it is undefined or not number array
+func screenCoverageOf(extras *Extras) []float32 {
+	if extras == nil {
+		return nil
+	}
+	arr, ok := (*extras)[msftScreenCoverage].([]interface{})
+	if !ok {
+		return nil
+	}
+	res := make([]float32, len(arr))
+	for i, v := range arr {
+		f, ok := v.(float64)
+		if !ok {
+			return nil
+		}
+		res[i] = float32(f)
+	}
+	return res
+}
+
+type SpecMSFTLod struct {
+	Ids []SpecGLTFID `json:"ids"` // required, minItems(1)
+}
+
+func (s *SpecMSFTLod) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecMSFTLod) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	var (
+		length int
+		extras *Extras
+	)
+	g, _ := root.(*SpecGLTF)
+	switch p := parent.(type) {
+	case *SpecNode:
+		extras = p.Extras
+		if g != nil {
+			length = len(g.Nodes)
+		}
+	case *SpecMaterial:
+		extras = p.Extras
+		if g != nil {
+			length = len(g.Materials)
+		}
+	}
+	switch strictness {
+	case LEVEL3:
+		if coverages := screenCoverageOf(extras); coverages != nil {
+			if len(coverages) > len(s.Ids)+1 {
+				issues.add(LEVEL3, CODE_ARRAY_LENGTH_NOT_IN_RANGE, "", "%s has %d items, but MSFTLod has %d levels", msftScreenCoverage, len(coverages), len(s.Ids)+1)
+			}
+			for i := 1; i < len(coverages); i++ {
+				if coverages[i] > coverages[i-1] {
+					issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "", "%s must be decreasing, but [%d] %v > [%d] %v", msftScreenCoverage, i, coverages[i], i-1, coverages[i-1])
+				}
+			}
+		}
+		fallthrough
+	case LEVEL2:
+		switch parent.(type) {
+		case *SpecNode, *SpecMaterial:
+		default:
+			issues.add(LEVEL2, CODE_UNDEFINED_PROPERTY, "", "MSFTLod is extension of node or material, but it is in '%s'", parent.Scheme())
+		}
+		fallthrough
+	case LEVEL1:
+		if len(s.Ids) == 0 {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/ids", "MSFTLod.Ids required")
+		}
+		if g != nil {
+			for i := range s.Ids {
+				issues.reference(&s.Ids[i], length, fmt.Sprintf("/ids/%d", i), "MSFTLod.Ids")
+			}
+		}
+	}
+	return issues.Err()
+}
+func (s *SpecMSFTLod) To(ctx *parserContext) interface{} {
+	return new(MSFTLod)
+}
+func (s *SpecMSFTLod) Link(Root *GLTF, parent interface{}, dst interface{}) error {
+	res := dst.(*MSFTLod)
+	switch parent.(type) {
+	case *Node:
+		res.Nodes = make([]*Node, len(s.Ids))
+		for i, id := range s.Ids {
+			if !inRange(id, len(Root.Nodes)) {
+				return errors.Errorf("MSFTLod.Ids[%d] linking fail, %s", i, id)
+			}
+			res.Nodes[i] = Root.Nodes[id]
+		}
+	case *Material:
+		res.Materials = make([]*Material, len(s.Ids))
+		for i, id := range s.Ids {
+			if !inRange(id, len(Root.Materials)) {
+				return errors.Errorf("MSFTLod.Ids[%d] linking fail, %s", i, id)
+			}
+			res.Materials[i] = Root.Materials[id]
+		}
+	default:
+		return errors.Errorf("MSFTLod linking fail, parent '%T' is not Node or Material", parent)
+	}
+	return nil
+}
+
+func init() {
+	RegisterExtension(new(MSFTLod))
+}
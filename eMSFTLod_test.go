@@ -0,0 +1,111 @@
+package gltf2
+
+import (
+	"bytes"
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const lodTestJSON = `{"asset":{"version":"2.0"},"extensionsUsed":["MSFT_lod"],
+	"materials":[{"extensions":{"MSFT_lod":{"ids":[1]}}},{}],
+	"nodes":[{"extensions":{"MSFT_lod":{"ids":[1,2]}},"extras":{"MSFT_screencoverage":[0.5,0.2,0.01]}},{},{}],
+	"scenes":[{"nodes":[0]}]}`
+
+// lodTestCheck check LOD chains of lodTestJSON
+func lodTestCheck(t *testing.T, g *GLTF) {
+	n := g.Nodes[0]
+	if lods := n.LODs(); len(lods) != 3 || lods[0] != n || lods[1] != g.Nodes[1] || lods[2] != g.Nodes[2] {
+		t.Fatalf("node LODs %v", lods)
+	}
+	for _, c := range []struct {
+		coverage float32
+		expected *Node
+	}{
+		{0.7, n},
+		{0.5, n},
+		{0.3, g.Nodes[1]},
+		{0.05, g.Nodes[2]},
+		// under last coverage is culled
+		{0.001, nil},
+	} {
+		if got := n.SelectLOD(c.coverage); got != c.expected {
+			t.Errorf("SelectLOD(%v) %p, expected %p", c.coverage, got, c.expected)
+		}
+	}
+	// node without LOD is itself
+	if lods := g.Nodes[1].LODs(); len(lods) != 1 || g.Nodes[1].SelectLOD(0) != g.Nodes[1] {
+		t.Errorf("LODs of node 1 %v", lods)
+	}
+	// without screen coverage, highest LOD is selected
+	m := g.Materials[0]
+	if lods := m.LODs(); len(lods) != 2 || lods[1] != g.Materials[1] || m.SelectLOD(0) != m {
+		t.Errorf("material LODs %v", lods)
+	}
+}
+
+func TestMSFTLod(t *testing.T) {
+	issues, err := Parser().Extensions(new(MSFTLod)).Reader(strings.NewReader(lodTestJSON)).Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("issues %v", issues)
+	}
+	g, err := Parser().Extensions(new(MSFTLod)).Reader(strings.NewReader(lodTestJSON)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lodTestCheck(t, g)
+	var out bytes.Buffer
+	if err := Encoder(&out).Encode(g); err != nil {
+		t.Fatal(err)
+	}
+	g2, err := Parser().Extensions(new(MSFTLod)).Reader(&out).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lodTestCheck(t, g2)
+}
+
+func TestMSFTLodIssues(t *testing.T) {
+	for pointer, bad := range map[string]string{
+		"/nodes/0/extensions/MSFT_lod/ids/1": strings.Replace(lodTestJSON, `"ids":[1,2]`, `"ids":[1,5]`, 1),
+		// screen coverage must be decreasing
+		"/nodes/0/extensions/MSFT_lod": strings.Replace(lodTestJSON, `[0.5,0.2,0.01]`, `[0.5,0.6,0.01]`, 1),
+	} {
+		issues, err := Parser().Extensions(new(MSFTLod)).Reader(strings.NewReader(bad)).Validate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(issues) != 1 || issues[0].Pointer != pointer {
+			t.Errorf("issues %v, expected one issue of '%s'", issues, pointer)
+		}
+	}
+}
+
+func TestScreenCoverage(t *testing.T) {
+	g, err := Parser().Reader(strings.NewReader(`{"asset":{"version":"2.0"}}`)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prim := &MeshPrimitive{Mode: TRIANGLES}
+	mesh := &Mesh{Primitives: []*MeshPrimitive{prim}}
+	g.Meshes = append(g.Meshes, mesh)
+	if _, err := Builder(g, nil).Attribute(prim, POSITION, []mgl32.Vec3{{-1, -1, 0}, {1, 1, 0}}); err != nil {
+		t.Fatal(err)
+	}
+	node := &Node{Mesh: mesh, Scale: mgl32.Vec3{1, 1, 1}, Rotation: mgl32.QuatIdent(), Matrix: mgl32.Ident4()}
+	camera := &PerspectiveCamera{Yfov: mgl32.DegToRad(90), Znear: 0.1, Zfar: 100}
+	// tan(45) = 1, height 2 at distance 2 is half of screen
+	if c := ScreenCoverage(node, mgl32.Ident4(), mgl32.Translate3D(0, 0, -2), camera, image.Pt(100, 100)); !mgl32.FloatEqualThreshold(c, 0.5, 1e-4) {
+		t.Errorf("coverage %v, expected 0.5", c)
+	}
+	// scaled node is twice bigger
+	node.Scale = mgl32.Vec3{2, 2, 2}
+	if c := ScreenCoverage(node, mgl32.Ident4(), mgl32.Translate3D(0, 0, -2), camera, image.Pt(100, 100)); !mgl32.FloatEqualThreshold(c, 1, 1e-4) {
+		t.Errorf("coverage of scaled node %v, expected 1", c)
+	}
+}
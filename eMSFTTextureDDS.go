@@ -0,0 +1,76 @@
+package gltf2
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+)
+
+// https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Vendor/MSFT_texture_dds
+//
+// It is extension of Texture, Source is DDS image used instead of Texture.Source
+type MSFTTextureDDS struct {
+	Source Image
+}
+
+func (s *MSFTTextureDDS) ExtensionName() string {
+	return "MSFT_texture_dds"
+}
+func (s *MSFTTextureDDS) ExtensionSchemes() []string {
+	return []string{SCHEME_TEXTURE}
+}
+func (s *MSFTTextureDDS) Constructor(src []byte) (Specifier, error) {
+	res := new(SpecMSFTTextureDDS)
+	if err := json.Unmarshal(src, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+func (s *MSFTTextureDDS) Encode(ctx *encoderContext) (interface{}, error) {
+	return &SpecMSFTTextureDDS{
+		Source: ctx.Image(s.Source),
+	}, nil
+}
+func (s *MSFTTextureDDS) source() Image {
+	return s.Source
+}
+
+type SpecMSFTTextureDDS struct {
+	Source *SpecGLTFID `json:"source"` // required
+}
+
+func (s *SpecMSFTTextureDDS) Scheme() string {
+	return SCHEME_EXTENSION
+}
+func (s *SpecMSFTTextureDDS) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		fallthrough
+	case LEVEL2:
+		issues.textureExtension(parent, "MSFTTextureDDS")
+		issues.imageMimeType(root, s.Source, ImageDDS, "/source", "MSFTTextureDDS.Source")
+		fallthrough
+	case LEVEL1:
+		if s.Source == nil {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/source", "MSFTTextureDDS.Source required")
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.Source, len(g.Images), "/source", "MSFTTextureDDS.Source")
+		}
+	}
+	return issues.Err()
+}
+func (s *SpecMSFTTextureDDS) To(ctx *parserContext) interface{} {
+	return new(MSFTTextureDDS)
+}
+func (s *SpecMSFTTextureDDS) Link(Root *GLTF, parent interface{}, dst interface{}) error {
+	if !inRange(*s.Source, len(Root.Images)) {
+		return errors.Errorf("MSFTTextureDDS.Source linking fail, %s", *s.Source)
+	}
+	dst.(*MSFTTextureDDS).Source = Root.Images[*s.Source]
+	return nil
+}
+
+func init() {
+	RegisterExtension(new(MSFTTextureDDS))
+}
@@ -5,6 +5,9 @@ const (
 	SCHEME_BUFFER                          = "buffer"
 	SCHEME_BUFFERVIEW                      = "bufferView"
 	SCHEME_ACCESSOR                        = "accessor"
+	SCHEME_ACCESSOR_SPARSE                 = "accessor/sparse"
+	SCHEME_ACCESSOR_SPARSE_INDICES         = "accessor/sparse/indices"
+	SCHEME_ACCESSOR_SPARSE_VALUES          = "accessor/sparse/values"
 	SCHEME_ASSET                           = "asset"
 	SCHEME_CAMERA                          = "camera"
 	SCHEME_CAMERA_PERSPECTIVE              = "camera/perspective"
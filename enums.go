@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/pkg/errors"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -154,6 +156,8 @@ const (
 	Rotation    Path = iota
 	Scale       Path = iota
 	Weights     Path = iota
+	// KHR_animation_pointer, target is KHRAnimationPointer of AnimationChannelTarget
+	Pointer Path = iota
 )
 
 func (s Path) String() string {
@@ -166,6 +170,8 @@ func (s Path) String() string {
 		return "scale"
 	case Weights:
 		return "weights"
+	case Pointer:
+		return "pointer"
 	}
 	return "nil"
 }
@@ -189,6 +195,8 @@ func (s *Path) UnmarshalJSON(data []byte) error {
 		*s = Scale
 	case "weights":
 		*s = Weights
+	case "pointer":
+		*s = Pointer
 	default:
 		return errors.WithMessage(ErrorEnum, fmt.Sprintf("'%s' is invalid Path", v))
 	}
@@ -307,6 +315,12 @@ type MimeType uint8
 const (
 	ImagePNG  MimeType = iota
 	ImageJPEG MimeType = iota
+	// KHR_texture_basisu
+	ImageKTX2 MimeType = iota
+	// EXT_texture_webp
+	ImageWebP MimeType = iota
+	// MSFT_texture_dds
+	ImageDDS MimeType = iota
 )
 
 func (s *MimeType) MarshalJSON() ([]byte, error) {
@@ -320,15 +334,60 @@ func (s *MimeType) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &v); err != nil {
 		return errors.WithMessage(ErrorJSON, err.Error())
 	}
+	mime, ok := mimeTypeFromString(v)
+	if !ok {
+		return errors.WithMessage(ErrorEnum, fmt.Sprintf("'%s' is invalid MimeType", v))
+	}
+	*s = mime
+	return nil
+}
+func mimeTypeFromString(v string) (MimeType, bool) {
 	switch v {
 	case "image/png":
-		*s = ImagePNG
+		return ImagePNG, true
 	case "image/jpeg":
-		*s = ImageJPEG
-	default:
-		return errors.WithMessage(ErrorEnum, fmt.Sprintf("'%s' is invalid MimeType", v))
+		return ImageJPEG, true
+	case "image/ktx2":
+		return ImageKTX2, true
+	case "image/webp":
+		return ImageWebP, true
+	case "image/vnd-ms.dds":
+		return ImageDDS, true
+	}
+	return 0, false
+}
+
+func mimeTypeFromFormat(format string) (MimeType, bool) {
+	switch format {
+	case "png":
+		return ImagePNG, true
+	case "jpeg":
+		return ImageJPEG, true
+	case "ktx2":
+		return ImageKTX2, true
+	case "webp":
+		return ImageWebP, true
+	case "dds":
+		return ImageDDS, true
+	}
+	return 0, false
+}
+
+// Format is image package format name, same as 'image.Decode' result
+func (s MimeType) Format() string {
+	switch s {
+	case ImagePNG:
+		return "png"
+	case ImageJPEG:
+		return "jpeg"
+	case ImageKTX2:
+		return "ktx2"
+	case ImageWebP:
+		return "webp"
+	case ImageDDS:
+		return "dds"
 	}
-	return nil
+	return "nil"
 }
 
 func (s MimeType) String() string {
@@ -337,6 +396,12 @@ func (s MimeType) String() string {
 		return "image/png"
 	case ImageJPEG:
 		return "image/jpeg"
+	case ImageKTX2:
+		return "image/ktx2"
+	case ImageWebP:
+		return "image/webp"
+	case ImageDDS:
+		return "image/vnd-ms.dds"
 	}
 	return "nil"
 }
@@ -453,9 +518,102 @@ const (
 func (s AttributeKey) IsCustom() bool {
 	return strings.HasPrefix(string(s), "_")
 }
-//func (s AttributeKey) AssociateType() ([]ComponentType, []AccessorType) {
+
+// sortedAttributeKeys is for stable iteration of attribute map
+func sortedAttributeKeys(attrs map[AttributeKey]SpecGLTFID) []AttributeKey {
+	keys := make([]AttributeKey, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// sortedAccessorKeys is for stable iteration of runtime attribute map
+func sortedAccessorKeys(attrs map[AttributeKey]*Accessor) []AttributeKey {
+	keys := make([]AttributeKey, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// Semantic is attribute name without set index, ex) TEXCOORD_1 is TEXCOORD
+func (s AttributeKey) Semantic() string {
+	if i := strings.LastIndex(string(s), "_"); i > 0 {
+		if _, err := strconv.Atoi(string(s[i+1:])); err == nil {
+			return string(s[:i])
+		}
+	}
+	return string(s)
+}
+
+// AttributeFormat is component type of attribute accessor
+type AttributeFormat struct {
+	ComponentType ComponentType
+	Normalized    bool
+}
+
+func (s AttributeFormat) String() string {
+	if s.Normalized {
+		return s.ComponentType.String() + " normalized"
+	}
+	return s.ComponentType.String()
+}
+
+// AssociateType is allowed accessor type and format of attribute, nil for custom or unknown attribute
 //
-//}
+// target is morph target attribute, quantized is KHR_mesh_quantization rule
+func (s AttributeKey) AssociateType(target, quantized bool) ([]AccessorType, []AttributeFormat) {
+	var (
+		f32  = AttributeFormat{FLOAT, false}
+		i8   = AttributeFormat{BYTE, false}
+		i8n  = AttributeFormat{BYTE, true}
+		u8   = AttributeFormat{UNSIGNED_BYTE, false}
+		u8n  = AttributeFormat{UNSIGNED_BYTE, true}
+		i16  = AttributeFormat{SHORT, false}
+		i16n = AttributeFormat{SHORT, true}
+		u16  = AttributeFormat{UNSIGNED_SHORT, false}
+		u16n = AttributeFormat{UNSIGNED_SHORT, true}
+	)
+	switch s.Semantic() {
+	case "POSITION":
+		switch {
+		case !quantized:
+			return []AccessorType{VEC3}, []AttributeFormat{f32}
+		case target:
+			return []AccessorType{VEC3}, []AttributeFormat{f32, i8, i8n, i16, i16n}
+		}
+		return []AccessorType{VEC3}, []AttributeFormat{f32, i8, i8n, u8, u8n, i16, i16n, u16, u16n}
+	case "NORMAL":
+		if quantized {
+			return []AccessorType{VEC3}, []AttributeFormat{f32, i8n, i16n}
+		}
+		return []AccessorType{VEC3}, []AttributeFormat{f32}
+	case "TANGENT":
+		types := []AccessorType{VEC4}
+		if target {
+			types = []AccessorType{VEC3}
+		}
+		if quantized {
+			return types, []AttributeFormat{f32, i8n, i16n}
+		}
+		return types, []AttributeFormat{f32}
+	case "TEXCOORD":
+		if quantized {
+			return []AccessorType{VEC2}, []AttributeFormat{f32, i8, i8n, u8, u8n, i16, i16n, u16, u16n}
+		}
+		return []AccessorType{VEC2}, []AttributeFormat{f32, u8n, u16n}
+	case "COLOR":
+		return []AccessorType{VEC3, VEC4}, []AttributeFormat{f32, u8n, u16n}
+	case "JOINTS":
+		return []AccessorType{VEC4}, []AttributeFormat{u8, u16}
+	case "WEIGHTS":
+		return []AccessorType{VEC4}, []AttributeFormat{f32, u8n, u16n}
+	}
+	return nil, nil
+}
 
 type MagFilter int32
 
@@ -3,12 +3,16 @@ package gltf2
 import "github.com/pkg/errors"
 
 var (
-	ErrorJSON         = errors.New("Json parsing fail")
-	ErrorEnum         = errors.New("Enum parsing fail")
-	ErrorGLTFSpec     = errors.New("Specifier fail")
-	ErrorGLTFLink     = errors.New("glTFid link not found")
-	ErrorTask         = errors.New("Task fail")
-	ErrorParser       = errors.New("Parser error")
-	ErrorExtension    = errors.New("ExtensionKey error")
-	ErrorParserOption = errors.New("Parser option error")
+	ErrorJSON          = errors.New("Json parsing fail")
+	ErrorGLB           = errors.New("GLB container parsing fail")
+	ErrorKTX2          = errors.New("KTX2 container parsing fail")
+	ErrorEnum          = errors.New("Enum parsing fail")
+	ErrorGLTFSpec      = errors.New("Specifier fail")
+	ErrorGLTFLink      = errors.New("glTFid link not found")
+	ErrorTask          = errors.New("Task fail")
+	ErrorParser        = errors.New("Parser error")
+	ErrorExtension     = errors.New("ExtensionKey error")
+	ErrorParserOption  = errors.New("Parser option error")
+	ErrorEncoder       = errors.New("Encoder error")
+	ErrorEncoderOption = errors.New("Encoder option error")
 )
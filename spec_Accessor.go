@@ -15,10 +15,10 @@ type Accessor struct {
 	ComponentType ComponentType
 	Max           []float32
 	Min           []float32
-	// TODO : Sparse        *AccessorSparse `json:"sparse,omitempty"`
-	Name       string
-	Extensions *Extensions
-	Extras     *Extras
+	Sparse        *AccessorSparse
+	Name          string
+	Extensions    *Extensions
+	Extras        *Extras
 	// None spec
 	UserData interface{}
 }
@@ -31,25 +31,150 @@ func (s *Accessor) SetExtension(extensions *Extensions) {
 	s.Extensions = extensions
 }
 
+// ElementSize is byte size of one element without ByteStride
+//
+// Matrix columns are aligned to 4 bytes, so MAT2 of BYTE is 8 bytes, MAT3 of SHORT is 24 bytes
+func (s *Accessor) ElementSize() int {
+	columns, _, columnSize := s.layout()
+	return columns * columnSize
+}
+
+// layout is how components are placed in one element, non matrix type is single column
+func (s *Accessor) layout() (columns, rows, columnSize int) {
+	switch s.Type {
+	case MAT2:
+		columns, rows = 2, 2
+	case MAT3:
+		columns, rows = 3, 3
+	case MAT4:
+		columns, rows = 4, 4
+	default:
+		return 1, s.Type.Count(), s.Type.Count() * s.ComponentType.Size()
+	}
+	columnSize = rows * s.ComponentType.Size()
+	return columns, rows, columnSize + (4-columnSize%4)%4
+}
+
+// ByteStride is distance between elements in BufferView, ElementSize if BufferView is tightly packed
+func (s *Accessor) ByteStride() int {
+	if s.BufferView != nil && s.BufferView.ByteStride != 0 {
+		return s.BufferView.ByteStride
+	}
+	return s.ElementSize()
+}
+
+// view is BufferView data from Accessor.ByteOffset, checked that every element is inside of it
+func (s *Accessor) view() ([]byte, int, error) {
+	bts, err := s.BufferView.Load()
+	if err != nil {
+		return nil, 0, err
+	}
+	size, stride := s.ElementSize(), s.ByteStride()
+	if stride < size {
+		return nil, 0, errors.Errorf("BufferView.ByteStride '%d' is smaller than Accessor element size '%d'", stride, size)
+	}
+	if s.Count < 1 {
+		return nil, stride, nil
+	}
+	if need := s.ByteOffset + stride*(s.Count-1) + size; s.ByteOffset < 0 || need > len(bts) {
+		return nil, 0, errors.Errorf("Accessor need %d bytes from BufferView, but BufferView.ByteLength is %d", need, len(bts))
+	}
+	return bts[s.ByteOffset:], stride, nil
+}
+
+// RawMap is tightly packed element data, Count * ElementSize bytes
+//
+// If BufferView is not interleaved and there is no Sparse, it is slice of Buffer, otherwise elements are copied into new slice.
+// Accessor without BufferView is zero filled.
+func (s *Accessor) RawMap() ([]byte, error) {
+	size := s.ElementSize()
+	var res []byte
+	if s.BufferView == nil {
+		res = make([]byte, s.Count*size)
+	} else {
+		bts, stride, err := s.view()
+		if err != nil {
+			return nil, err
+		}
+		if stride == size && s.Sparse == nil {
+			return bts[:s.Count*size], nil
+		}
+		res = make([]byte, s.Count*size)
+		for i := 0; i < s.Count; i++ {
+			copy(res[i*size:(i+1)*size], bts[i*stride:])
+		}
+	}
+	if s.Sparse != nil {
+		if err := s.sparse(res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// sparse substitute elements of packed data
+func (s *Accessor) sparse(packed []byte) error {
+	if s.Sparse.Indices == nil || s.Sparse.Values == nil {
+		return errors.New("AccessorSparse.Indices, AccessorSparse.Values required")
+	}
+	size := s.ElementSize()
+	indices, err := s.Sparse.Indices.Load(s.Sparse.Count)
+	if err != nil {
+		return err
+	}
+	values, err := s.Sparse.Values.Load(s.Sparse.Count * size)
+	if err != nil {
+		return err
+	}
+	for i, index := range indices {
+		if index >= s.Count {
+			return errors.Errorf("AccessorSparse.Indices[%d] '%d' out of Accessor.Count '%d'", i, index, s.Count)
+		}
+		copy(packed[index*size:(index+1)*size], values[i*size:])
+	}
+	return nil
+}
+
+// ForEach call fn for each element in order, elem is ElementSize bytes
+//
+// elem is slice of Buffer without copy if there is no Sparse, fn must not keep it.
+// Stop and return error if fn return error
+func (s *Accessor) ForEach(fn func(i int, elem []byte) error) (err error) {
+	var (
+		bts    []byte
+		stride int
+		size   = s.ElementSize()
+	)
+	if s.BufferView == nil || s.Sparse != nil {
+		bts, err = s.RawMap()
+		stride = size
+	} else {
+		bts, stride, err = s.view()
+	}
+	if err != nil {
+		return err
+	}
+	for i := 0; i < s.Count; i++ {
+		if err = fn(i, bts[i*stride:i*stride+size]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // [ Unsafe ] 		: careful to use
 // [ Reflect ] 		: using reflect, it can be cause performance issue
 //
 // out_ptrslice 	: Pointer Slice Type for reading accessor
 // typeSafety 		: typeSafety option, if enable, checking type safety by using reflect
 //
+// Interleaved BufferView is packed by RawMap, so result slice is not shared with Buffer.
+//
 // ex) data, err := <Accessor>.SliceMapping(new([][3]float), true)
 //     slice := data.([][3]float)
-func (s *Accessor) RawMap() ([]byte, error) {
-	bts, err := s.BufferView.Load()
-	if err != nil {
-		return nil, err
-	}
-	bts = bts[s.ByteOffset:s.ByteOffset + s.Count * s.Type.Count() * s.ComponentType.Size()]
-	return bts, nil
-}
 func (s *Accessor) SliceMapping(out_ptrslice interface{}, typeSafety, componentSafety bool) (interface{}, error) {
-	if s.BufferView.ByteStride != 0 && s.BufferView.ByteStride != s.ComponentType.Size()*s.Type.Count() {
-		return nil, errors.New("SliceMapping ByteStride support not available")
+	if s.ElementSize() != s.ComponentType.Size()*s.Type.Count() {
+		return nil, errors.Errorf("SliceMapping %s of %s has column padding, use RawMap or ForEach", s.Type, s.ComponentType)
 	}
 	bts, err := s.RawMap()
 	if err != nil {
@@ -175,18 +300,18 @@ func (s *Accessor) MustSliceMapping(out_ptrslice interface{}, typeSafety, compon
 }
 
 type SpecAccessor struct {
-	BufferView    *SpecGLTFID     `json:"bufferView,omitempty"` //
-	ByteOffset    *int            `json:"byteOffset,omitempty"` // default(0), minimum(0), dependency(bufferView)
-	ComponentType *ComponentType  `json:"componentType"`        // required
-	Normalized    *bool           `json:"normalized,omitempty"` // default(false)
-	Count         *int            `json:"count"`                // required, minimum(1)
-	Type          *AccessorType   `json:"type"`                 // required
-	Max           []float32       `json:"max,omitempty"`        // rangeitem(1, 16)
-	Min           []float32       `json:"min,omitempty"`        // rangeitem(1, 16)
-	Sparse        *AccessorSparse `json:"sparse,omitempty"`
-	Name          *string         `json:"name,omitempty"`
-	Extensions    *SpecExtensions `json:"extensions,omitempty"`
-	Extras        *Extras         `json:"extras,omitempty"`
+	BufferView    *SpecGLTFID         `json:"bufferView,omitempty"` //
+	ByteOffset    *int                `json:"byteOffset,omitempty"` // default(0), minimum(0), dependency(bufferView)
+	ComponentType *ComponentType      `json:"componentType"`        // required
+	Normalized    *bool               `json:"normalized,omitempty"` // default(false)
+	Count         *int                `json:"count"`                // required, minimum(1)
+	Type          *AccessorType       `json:"type"`                 // required
+	Max           []float32           `json:"max,omitempty"`        // rangeitem(1, 16)
+	Min           []float32           `json:"min,omitempty"`        // rangeitem(1, 16)
+	Sparse        *SpecAccessorSparse `json:"sparse,omitempty"`
+	Name          *string             `json:"name,omitempty"`
+	Extensions    *SpecExtensions     `json:"extensions,omitempty"`
+	Extras        *Extras             `json:"extras,omitempty"`
 }
 
 func (s *SpecAccessor) SpecExtension() *SpecExtensions {
@@ -196,35 +321,39 @@ func (s *SpecAccessor) Scheme() string {
 	return SCHEME_ACCESSOR
 }
 func (s *SpecAccessor) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		if s.ByteOffset != nil && *s.ByteOffset < 0 {
-			return errors.Errorf("Accessor.ByteOffset minimum(0)")
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/byteOffset", "Accessor.ByteOffset minimum(0)")
 		}
 		fallthrough
 	case LEVEL2:
 		if s.Max != nil && (1 > len(s.Max) || len(s.Max) > 16) {
-			return errors.Errorf("Accessor.Max rangeitem(1, 16)")
+			issues.add(LEVEL2, CODE_ARRAY_LENGTH_NOT_IN_RANGE, "/max", "Accessor.Max rangeitem(1, 16)")
 		}
 		if s.Min != nil && (len(s.Min) < 1 || len(s.Min) > 16) {
-			return errors.Errorf("Accessor.Min rangeitem(1, 16)")
+			issues.add(LEVEL2, CODE_ARRAY_LENGTH_NOT_IN_RANGE, "/min", "Accessor.Min rangeitem(1, 16)")
 		}
 		fallthrough
 	case LEVEL1:
 		if s.ByteOffset != nil && s.BufferView == nil {
-			return errors.Errorf("Accessor.ByteOffset dependency(bufferView)")
+			issues.add(LEVEL1, CODE_UNSATISFIED_DEPENDENCY, "/byteOffset", "Accessor.ByteOffset dependency(bufferView)")
 		}
 		if s.Count == nil {
-			return errors.Errorf("Accessor.Count required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/count", "Accessor.Count required")
 		}
 		if s.ComponentType == nil {
-			return errors.Errorf("Accessor.ComponentType required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/componentType", "Accessor.ComponentType required")
 		}
 		if s.Type == nil {
-			return errors.Errorf("Accessor.AccessorType required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/type", "Accessor.AccessorType required")
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.BufferView, len(g.BufferViews), "/bufferView", "Accessor.BufferView")
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecAccessor) To(ctx *parserContext) interface{} {
 	res := new(Accessor)
@@ -243,7 +372,6 @@ func (s *SpecAccessor) To(ctx *parserContext) interface{} {
 	res.Type = *s.Type
 	res.Max = s.Max
 	res.Min = s.Min
-	// s.Sparse
 	if s.Name == nil {
 		res.Name = ""
 	} else {
@@ -262,6 +390,25 @@ func (s *SpecAccessor) Link(Root *GLTF, parent interface{}, dst interface{}) err
 	return nil
 }
 
+func (s *SpecAccessor) GetChild(i int) Specifier {
+	return s.Sparse
+}
+func (s *SpecAccessor) SetChild(i int, dst, object interface{}) {
+	dst.(*Accessor).Sparse = object.(*AccessorSparse)
+}
+func (s *SpecAccessor) ChildPointer(i int) string {
+	return "/sparse"
+}
+func (s *SpecAccessor) LenChild() int {
+	if s.Sparse == nil {
+		return 0
+	}
+	return 1
+}
+func (s *SpecAccessor) ImpleGetChild(i int, dst interface{}) interface{} {
+	return dst.(*Accessor).Sparse
+}
+
 func inKind(test reflect.Kind, set ...reflect.Kind) bool {
 	for _, v := range set {
 		if v == test {
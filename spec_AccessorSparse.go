@@ -1,40 +1,110 @@
 package gltf2
 
-import (
-	"encoding/json"
-	"github.com/pkg/errors"
-)
-
+// https://github.com/KhronosGroup/glTF/tree/master/specification/2.0#sparse-accessors
+// Implementation Note : Accessor.RawMap apply it, reader don't need to care about sparse
 type AccessorSparse struct {
-	Count   int                   `json:"count"`  // required, minimum(0)
-	Indices AccessorSparseIndices `json:"indice"` // required
-	Values  AccessorSparseValues  `json:"values"` // required
+	Count      int
+	Indices    *AccessorSparseIndices
+	Values     *AccessorSparseValues
+	Extensions *Extensions
+	Extras     *Extras
+	// None spec
+	UserData interface{}
+}
+
+func (s *AccessorSparse) GetExtension() *Extensions {
+	return s.Extensions
+}
+
+func (s *AccessorSparse) SetExtension(extensions *Extensions) {
+	s.Extensions = extensions
+}
+
+type SpecAccessorSparse struct {
+	Count      *int                       `json:"count"`   // required, minimum(1)
+	Indices    *SpecAccessorSparseIndices `json:"indices"` // required
+	Values     *SpecAccessorSparseValues  `json:"values"`  // required
+	Extensions *SpecExtensions            `json:"extensions,omitempty"`
+	Extras     *Extras                    `json:"extras,omitempty"`
+}
+
+func (s *SpecAccessorSparse) SpecExtension() *SpecExtensions {
+	return s.Extensions
+}
+func (s *SpecAccessorSparse) Scheme() string {
+	return SCHEME_ACCESSOR_SPARSE
+}
+func (s *SpecAccessorSparse) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		if s.Count != nil && *s.Count < 1 {
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/count", "AccessorSparse.Count minimum(1), but got '%d'", *s.Count)
+		}
+		fallthrough
+	case LEVEL2:
+		fallthrough
+	case LEVEL1:
+		if s.Count == nil {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/count", "AccessorSparse.Count required")
+		}
+		if s.Indices == nil {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/indices", "AccessorSparse.Indices required")
+		}
+		if s.Values == nil {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/values", "AccessorSparse.Values required")
+		}
+		if accessor, ok := parent.(*SpecAccessor); ok && s.Count != nil && accessor.Count != nil && *s.Count > *accessor.Count {
+			issues.add(LEVEL1, CODE_VALUE_NOT_IN_RANGE, "/count", "AccessorSparse.Count '%d' bigger than Accessor.Count '%d'", *s.Count, *accessor.Count)
+		}
+	}
+	return issues.Err()
+}
+func (s *SpecAccessorSparse) To(ctx *parserContext) interface{} {
+	res := new(AccessorSparse)
+	res.Count = *s.Count
+	res.Extras = s.Extras
+	return res
 }
 
-func (s *AccessorSparse) UnmarshalJSON(data []byte) error {
-	type Temp struct {
-		Count   *int                   `json:"count"`  // required, minimum(0)
-		Indices *AccessorSparseIndices `json:"indice"` // required
-		Values  *AccessorSparseValues  `json:"values"` // required
+func (s *SpecAccessorSparse) GetChild(i int) Specifier {
+	return s.Children()[i]
+}
+func (s *SpecAccessorSparse) SetChild(i int, dst, object interface{}) {
+	switch s.Children()[i].(type) {
+	case *SpecAccessorSparseIndices:
+		dst.(*AccessorSparse).Indices = object.(*AccessorSparseIndices)
+	case *SpecAccessorSparseValues:
+		dst.(*AccessorSparse).Values = object.(*AccessorSparseValues)
 	}
-	var temp Temp
-	// Parse
-	if err := json.Unmarshal(data, &temp); err != nil {
-		return errors.WithMessage(ErrorJSON, err.Error())
+}
+func (s *SpecAccessorSparse) ChildPointer(i int) string {
+	switch s.Children()[i].(type) {
+	case *SpecAccessorSparseIndices:
+		return "/indices"
+	case *SpecAccessorSparseValues:
+		return "/values"
 	}
-	// Spec
-	if temp.Count == nil {
-		return errors.WithMessage(ErrorGLTFSpec, "AccessorSparse.Count required")
+	return ""
+}
+func (s *SpecAccessorSparse) LenChild() int {
+	return len(s.Children())
+}
+func (s *SpecAccessorSparse) Children() (res []Specifier) {
+	if s.Indices != nil {
+		res = append(res, s.Indices)
 	}
-	if temp.Indices == nil {
-		return errors.WithMessage(ErrorGLTFSpec, "AccessorSparse.Indices required")
+	if s.Values != nil {
+		res = append(res, s.Values)
 	}
-	if temp.Values == nil {
-		return errors.WithMessage(ErrorGLTFSpec, "AccessorSparse.Values required")
+	return res
+}
+func (s *SpecAccessorSparse) ImpleGetChild(i int, dst interface{}) interface{} {
+	switch s.Children()[i].(type) {
+	case *SpecAccessorSparseIndices:
+		return dst.(*AccessorSparse).Indices
+	case *SpecAccessorSparseValues:
+		return dst.(*AccessorSparse).Values
 	}
-	//_Setup
-	s.Count = *temp.Count
-	s.Indices = *temp.Indices
-	s.Values = *temp.Values
 	return nil
 }
@@ -1,48 +1,106 @@
 package gltf2
 
 import (
-	"encoding/json"
 	"github.com/pkg/errors"
 )
 
 type AccessorSparseIndices struct {
-	BufferView    SpecGLTFID    `json:"bufferView"`           // required
-	ByteOffset    int           `json:"byteOffset,omitempty"` // default(0), minimum(0)
-	ComponentType ComponentType `json:"componentType"`        // required
-	Extensions    *Extensions   `json:"extensions,omitempty"`
-	Extras        *Extras       `json:"extras,omitempty"`
+	BufferView    *BufferView // Linking
+	ByteOffset    int         // default 0
+	ComponentType ComponentType
+	Extensions    *Extensions
+	Extras        *Extras
+	// None spec
+	UserData interface{}
 }
 
-func (s *AccessorSparseIndices) UnmarshalJSON(data []byte) error {
-	type Temp struct {
-		BufferView    *SpecGLTFID    `json:"bufferView"`           // required
-		ByteOffset    int            `json:"byteOffset,omitempty"` // default(0), minimum(0)
-		ComponentType *ComponentType `json:"componentType"`        // required
-		Extensions    *Extensions    `json:"extensions,omitempty"`
-		Extras        *Extras        `json:"extras,omitempty"`
+func (s *AccessorSparseIndices) GetExtension() *Extensions {
+	return s.Extensions
+}
+
+func (s *AccessorSparseIndices) SetExtension(extensions *Extensions) {
+	s.Extensions = extensions
+}
+
+// Load is indices of substituted elements, strictly increasing
+func (s *AccessorSparseIndices) Load(count int) ([]int, error) {
+	bts, err := s.BufferView.Load()
+	if err != nil {
+		return nil, err
 	}
-	var temp = Temp{
-		ByteOffset: 0,
+	size := s.ComponentType.Size()
+	if need := s.ByteOffset + count*size; s.ByteOffset < 0 || need > len(bts) {
+		return nil, errors.Errorf("AccessorSparseIndices need %d bytes from BufferView, but BufferView.ByteLength is %d", need, len(bts))
 	}
-	// Parse
-	if err := json.Unmarshal(data, &temp); err != nil {
-		return errors.WithMessage(ErrorJSON, err.Error())
+	bts = bts[s.ByteOffset:]
+	res := make([]int, count)
+	for i := range res {
+		switch s.ComponentType {
+		case UNSIGNED_BYTE:
+			res[i] = int(bts[i])
+		case UNSIGNED_SHORT:
+			res[i] = int(uint16(bts[2*i]) | uint16(bts[2*i+1])<<8)
+		case UNSIGNED_INT:
+			res[i] = int(uint32(bts[4*i]) | uint32(bts[4*i+1])<<8 | uint32(bts[4*i+2])<<16 | uint32(bts[4*i+3])<<24)
+		default:
+			return nil, errors.Errorf("AccessorSparseIndices.ComponentType '%s' not supported", s.ComponentType)
+		}
 	}
-	// Spec
-	if temp.BufferView == nil {
-		return errors.WithMessage(ErrorGLTFSpec, "AccessorSparseIndices.BufferView required")
+	return res, nil
+}
+
+type SpecAccessorSparseIndices struct {
+	BufferView    *SpecGLTFID     `json:"bufferView"`           // required
+	ByteOffset    *int            `json:"byteOffset,omitempty"` // default(0), minimum(0)
+	ComponentType *ComponentType  `json:"componentType"`        // required, enum(UNSIGNED_BYTE, UNSIGNED_SHORT, UNSIGNED_INT)
+	Extensions    *SpecExtensions `json:"extensions,omitempty"`
+	Extras        *Extras         `json:"extras,omitempty"`
+}
+
+func (s *SpecAccessorSparseIndices) SpecExtension() *SpecExtensions {
+	return s.Extensions
+}
+func (s *SpecAccessorSparseIndices) Scheme() string {
+	return SCHEME_ACCESSOR_SPARSE_INDICES
+}
+func (s *SpecAccessorSparseIndices) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		if s.ByteOffset != nil && *s.ByteOffset < 0 {
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/byteOffset", "AccessorSparseIndices.ByteOffset minimum(0), but got '%d'", *s.ByteOffset)
+		}
+		fallthrough
+	case LEVEL2:
+		fallthrough
+	case LEVEL1:
+		if s.BufferView == nil {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/bufferView", "AccessorSparseIndices.BufferView required")
+		}
+		if s.ComponentType == nil {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/componentType", "AccessorSparseIndices.ComponentType required")
+		} else if *s.ComponentType != UNSIGNED_BYTE && *s.ComponentType != UNSIGNED_SHORT && *s.ComponentType != UNSIGNED_INT {
+			issues.add(LEVEL1, CODE_VALUE_NOT_IN_LIST, "/componentType", "AccessorSparseIndices.ComponentType must be one of UNSIGNED_BYTE, UNSIGNED_SHORT, UNSIGNED_INT, but got '%s'", *s.ComponentType)
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.BufferView, len(g.BufferViews), "/bufferView", "AccessorSparseIndices.BufferView")
+		}
 	}
-	if temp.ComponentType == nil {
-		return errors.WithMessage(ErrorGLTFSpec, "AccessorSparseIndices.ComponentType required")
+	return issues.Err()
+}
+func (s *SpecAccessorSparseIndices) To(ctx *parserContext) interface{} {
+	res := new(AccessorSparseIndices)
+	if s.ByteOffset != nil {
+		res.ByteOffset = *s.ByteOffset
 	}
-	if temp.ByteOffset < 0 {
-		return errors.WithMessage(ErrorGLTFSpec, "AccessorSparseIndices.ByteOffset minimum(0)")
+	res.ComponentType = *s.ComponentType
+	res.Extras = s.Extras
+	return res
+}
+func (s *SpecAccessorSparseIndices) Link(Root *GLTF, parent interface{}, dst interface{}) error {
+	if !inRange(*s.BufferView, len(Root.BufferViews)) {
+		return errors.Errorf("AccessorSparseIndices.BufferView linking fail")
 	}
-	// _Setup
-	s.BufferView = *temp.BufferView
-	s.ByteOffset = temp.ByteOffset
-	s.ComponentType = *temp.ComponentType
-	s.Extensions = temp.Extensions
-	s.Extras = temp.Extras
+	dst.(*AccessorSparseIndices).BufferView = Root.BufferViews[*s.BufferView]
 	return nil
 }
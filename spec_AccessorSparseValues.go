@@ -1,42 +1,84 @@
 package gltf2
 
 import (
-	"encoding/json"
 	"github.com/pkg/errors"
 )
 
+// Values are tightly packed elements of owner Accessor's Type, ComponentType
 type AccessorSparseValues struct {
-	BufferView SpecGLTFID  `json:"bufferView"`           // required
-	ByteOffset *int        `json:"byteOffset,omitempty"` // default(0), minimum(0)
-	Extensions *Extensions `json:"extensions,omitempty"`
-	Extras     *Extras     `json:"extras,omitempty"`
+	BufferView *BufferView // Linking
+	ByteOffset int         // default 0
+	Extensions *Extensions
+	Extras     *Extras
+	// None spec
+	UserData interface{}
 }
 
-func (s *AccessorSparseValues) UnmarshalJSON(data []byte) error {
-	type Temp struct {
-		BufferView *SpecGLTFID `json:"bufferView"`           // required
-		ByteOffset *int        `json:"byteOffset,omitempty"` // default(0), minimum(0)
-		Extensions *Extensions `json:"extensions,omitempty"`
-		Extras     *Extras     `json:"extras,omitempty"`
-	}
-	var temp Temp
-	// Parse
-	if err := json.Unmarshal(data, &temp); err != nil {
-		return errors.WithMessage(ErrorJSON, err.Error())
+func (s *AccessorSparseValues) GetExtension() *Extensions {
+	return s.Extensions
+}
+
+func (s *AccessorSparseValues) SetExtension(extensions *Extensions) {
+	s.Extensions = extensions
+}
+
+// Load is size bytes of values, size is AccessorSparse.Count * Accessor.ElementSize
+func (s *AccessorSparseValues) Load(size int) ([]byte, error) {
+	bts, err := s.BufferView.Load()
+	if err != nil {
+		return nil, err
 	}
-	// Spec
-	if temp.BufferView == nil {
-		return errors.WithMessage(ErrorGLTFSpec, "AccessorSparseIndices.BufferView required")
+	if need := s.ByteOffset + size; s.ByteOffset < 0 || need > len(bts) {
+		return nil, errors.Errorf("AccessorSparseValues need %d bytes from BufferView, but BufferView.ByteLength is %d", need, len(bts))
 	}
-	if temp.ByteOffset != nil {
-		if *temp.ByteOffset < 0 {
-			return errors.WithMessage(ErrorGLTFSpec, "AccessorSparseIndices.ByteOffset minimum(0)")
+	return bts[s.ByteOffset : s.ByteOffset+size], nil
+}
+
+type SpecAccessorSparseValues struct {
+	BufferView *SpecGLTFID     `json:"bufferView"`           // required
+	ByteOffset *int            `json:"byteOffset,omitempty"` // default(0), minimum(0)
+	Extensions *SpecExtensions `json:"extensions,omitempty"`
+	Extras     *Extras         `json:"extras,omitempty"`
+}
+
+func (s *SpecAccessorSparseValues) SpecExtension() *SpecExtensions {
+	return s.Extensions
+}
+func (s *SpecAccessorSparseValues) Scheme() string {
+	return SCHEME_ACCESSOR_SPARSE_VALUES
+}
+func (s *SpecAccessorSparseValues) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
+	switch strictness {
+	case LEVEL3:
+		if s.ByteOffset != nil && *s.ByteOffset < 0 {
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/byteOffset", "AccessorSparseValues.ByteOffset minimum(0), but got '%d'", *s.ByteOffset)
+		}
+		fallthrough
+	case LEVEL2:
+		fallthrough
+	case LEVEL1:
+		if s.BufferView == nil {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/bufferView", "AccessorSparseValues.BufferView required")
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.BufferView, len(g.BufferViews), "/bufferView", "AccessorSparseValues.BufferView")
 		}
 	}
-	// _Setup
-	s.BufferView = *temp.BufferView
-	s.ByteOffset = temp.ByteOffset
-	s.Extensions = temp.Extensions
-	s.Extras = temp.Extras
+	return issues.Err()
+}
+func (s *SpecAccessorSparseValues) To(ctx *parserContext) interface{} {
+	res := new(AccessorSparseValues)
+	if s.ByteOffset != nil {
+		res.ByteOffset = *s.ByteOffset
+	}
+	res.Extras = s.Extras
+	return res
+}
+func (s *SpecAccessorSparseValues) Link(Root *GLTF, parent interface{}, dst interface{}) error {
+	if !inRange(*s.BufferView, len(Root.BufferViews)) {
+		return errors.Errorf("AccessorSparseValues.BufferView linking fail")
+	}
+	dst.(*AccessorSparseValues).BufferView = Root.BufferViews[*s.BufferView]
 	return nil
 }
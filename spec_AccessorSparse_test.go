@@ -0,0 +1,102 @@
+package gltf2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+// accessorSparseTestJSON is 2 sparse accessors, one has no bufferView, another replace values of bufferView 2
+func accessorSparseTestJSON() string {
+	var b bytes.Buffer
+	// sparse indices [1, 3]
+	binary.Write(&b, binary.LittleEndian, []uint16{1, 3})
+	// sparse values [5, 7]
+	binary.Write(&b, binary.LittleEndian, []float32{5, 7})
+	// base values [1, 2, 3, 4]
+	binary.Write(&b, binary.LittleEndian, []float32{1, 2, 3, 4})
+	return accessorTestJSON(b.Bytes(),
+		`{"buffer":0,"byteLength":4},{"buffer":0,"byteOffset":4,"byteLength":8},{"buffer":0,"byteOffset":12,"byteLength":16}`,
+		`{"componentType":5126,"count":4,"type":"SCALAR","sparse":{"count":2,"indices":{"bufferView":0,"componentType":5123},"values":{"bufferView":1}}},
+		{"bufferView":2,"componentType":5126,"count":4,"type":"SCALAR","sparse":{"count":2,"indices":{"bufferView":0,"componentType":5123},"values":{"bufferView":1}}}`,
+	)
+}
+
+func TestAccessorSparse(t *testing.T) {
+	js := accessorSparseTestJSON()
+	issues, err := Parser().Reader(strings.NewReader(js)).Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("issues %v", issues)
+	}
+	g, err := Parser().Reader(strings.NewReader(js)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]float32{{0, 5, 0, 7}, {1, 5, 3, 7}}
+	check := func(g *GLTF) {
+		for i, a := range g.Accessors {
+			if a.Sparse == nil || a.Sparse.Indices.BufferView != g.BufferViews[0] || a.Sparse.Values.BufferView != g.BufferViews[1] {
+				t.Fatalf("accessor %d sparse is not linked", i)
+			}
+			s, err := a.SliceMapping(new([]float32), true, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := s.([]float32)
+			if len(got) != len(expected[i]) {
+				t.Fatalf("accessor %d %v, expected %v", i, got, expected[i])
+			}
+			for j := range got {
+				if got[j] != expected[i][j] {
+					t.Errorf("accessor %d %v, expected %v", i, got, expected[i])
+					break
+				}
+			}
+		}
+		// sparse values don't change base bufferView
+		base, err := g.BufferViews[2].Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var b bytes.Buffer
+		binary.Write(&b, binary.LittleEndian, []float32{1, 2, 3, 4})
+		if !bytes.Equal(base, b.Bytes()) {
+			t.Errorf("bufferView 2 is changed, %v", base)
+		}
+	}
+	check(g)
+	g2 := accessorTestRoundTrip(t, g)
+	if g2.Accessors[0].BufferView != nil {
+		t.Error("accessor 0 has bufferView after encode")
+	}
+	check(g2)
+}
+
+func TestAccessorSparseIssues(t *testing.T) {
+	js := accessorSparseTestJSON()
+	for pointer, bad := range map[string]string{
+		"/accessors/0/sparse/count":                 strings.Replace(js, `"count":2`, `"count":5`, 1),
+		"/accessors/0/sparse/indices/bufferView":    strings.Replace(js, `"indices":{"bufferView":0`, `"indices":{"bufferView":9`, 1),
+		"/accessors/0/sparse/values/bufferView":     strings.Replace(js, `"values":{"bufferView":1`, `"values":{"bufferView":9`, 1),
+		"/accessors/0/sparse/indices/componentType": strings.Replace(js, `"componentType":5123`, `"componentType":5126`, 1),
+	} {
+		issues, err := Parser().Reader(strings.NewReader(bad)).Validate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var found bool
+		for _, v := range issues {
+			found = found || v.Pointer == pointer
+		}
+		if !found {
+			t.Errorf("issue of '%s' is not reported, %v", pointer, issues)
+		}
+		if _, err := Parser().Reader(strings.NewReader(bad)).Parse(); err == nil {
+			t.Errorf("%s : parse must fail", pointer)
+		}
+	}
+}
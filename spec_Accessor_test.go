@@ -0,0 +1,110 @@
+package gltf2
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// accessorTestJSON is glTF of one data URI buffer, bufferViews and accessors are given as JSON
+func accessorTestJSON(data []byte, bufferViews, accessors string) string {
+	return fmt.Sprintf(`{
+	"asset":{"version":"2.0"},
+	"buffers":[{"byteLength":%d,"uri":"data:application/octet-stream;base64,%s"}],
+	"bufferViews":[%s],
+	"accessors":[%s]
+}`, len(data), base64.StdEncoding.EncodeToString(data), bufferViews, accessors)
+}
+
+// accessorTestRoundTrip encode g with data URI and parse it again
+func accessorTestRoundTrip(t *testing.T, g *GLTF) *GLTF {
+	var out bytes.Buffer
+	if err := Encoder(&out).DataURI().Encode(g); err != nil {
+		t.Fatal(err)
+	}
+	res, err := Parser().Reader(&out).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestAccessorStride(t *testing.T) {
+	// interleaved position(VEC3) and uv(VEC2) of float, stride 20, 3 vertices
+	var values []float32
+	for i := 0; i < 3; i++ {
+		for c := 0; c < 5; c++ {
+			values = append(values, float32(i*10+c))
+		}
+	}
+	var b bytes.Buffer
+	binary.Write(&b, binary.LittleEndian, values)
+	js := accessorTestJSON(b.Bytes(),
+		`{"buffer":0,"byteLength":60,"byteStride":20,"target":34962}`,
+		`{"bufferView":0,"componentType":5126,"count":3,"type":"VEC3","min":[0,1,2],"max":[20,21,22]},
+		{"bufferView":0,"byteOffset":12,"componentType":5126,"count":3,"type":"VEC2"}`,
+	)
+	g, err := Parser().Reader(strings.NewReader(js)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	check := func(g *GLTF) {
+		pos, err := g.Accessors[0].SliceMapping(new([][3]float32), true, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := pos.([][3]float32); len(got) != 3 || got[0] != [3]float32{0, 1, 2} || got[2] != [3]float32{20, 21, 22} {
+			t.Errorf("position %v", got)
+		}
+		uv, err := g.Accessors[1].SliceMapping(new([][2]float32), true, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := uv.([][2]float32); len(got) != 3 || got[0] != [2]float32{3, 4} || got[1] != [2]float32{13, 14} || got[2] != [2]float32{23, 24} {
+			t.Errorf("uv %v", got)
+		}
+		// ForEach visit elements only, not stride padding
+		var n int
+		if err := g.Accessors[1].ForEach(func(i int, e []byte) error {
+			n += len(e)
+			return nil
+		}); err != nil || n != 24 {
+			t.Errorf("ForEach visit %d bytes, %v, expected 24", n, err)
+		}
+	}
+	check(g)
+	g2 := accessorTestRoundTrip(t, g)
+	if g2.BufferViews[0].ByteStride != 20 || g2.Accessors[1].ByteOffset != 12 {
+		t.Errorf("byteStride %d, byteOffset %d are not kept", g2.BufferViews[0].ByteStride, g2.Accessors[1].ByteOffset)
+	}
+	check(g2)
+	// last element over bufferView
+	g.Accessors[1].Count = 4
+	if _, err := g.Accessors[1].RawMap(); err == nil {
+		t.Error("accessor over bufferView must fail")
+	}
+}
+
+func TestAccessorElementSize(t *testing.T) {
+	// every column of matrix is aligned to 4 bytes
+	for _, c := range []struct {
+		typ      AccessorType
+		ct       ComponentType
+		expected int
+	}{
+		{MAT2, BYTE, 8},
+		{MAT3, BYTE, 12},
+		{MAT3, SHORT, 24},
+		{MAT4, SHORT, 32},
+		{MAT2, FLOAT, 16},
+		{VEC3, UNSIGNED_BYTE, 3},
+	} {
+		a := &Accessor{Type: c.typ, ComponentType: c.ct}
+		if got := a.ElementSize(); got != c.expected {
+			t.Errorf("%v %v element size %d, expected %d", c.typ, c.ct, got, c.expected)
+		}
+	}
+}
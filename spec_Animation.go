@@ -1,8 +1,6 @@
 package gltf2
 
-import (
-	"github.com/pkg/errors"
-)
+import "fmt"
 
 type Animation struct {
 	Channels   []*AnimationChannel `json:"channels"` // required, minItem(1)
@@ -37,20 +35,21 @@ func (s *SpecAnimation) Scheme() string {
 	return SCHEME_ANIMATION
 }
 func (s *SpecAnimation) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
 	case LEVEL2:
 		fallthrough
 	case LEVEL1:
-		if s.Channels == nil {
-			return errors.WithMessage(ErrorGLTFSpec, "NewPlayer.Channels required")
+		if len(s.Channels) == 0 {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/channels", "Animation.Channels required")
 		}
-		if s.Samplers == nil {
-			return errors.WithMessage(ErrorGLTFSpec, "NewPlayer.Samplers required")
+		if len(s.Samplers) == 0 {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/samplers", "Animation.Samplers required")
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecAnimation) To(ctx *parserContext) interface{} {
 	res := new(Animation)
@@ -73,6 +72,13 @@ func (s *SpecAnimation) SetChild(i int, dst, object interface{}) {
 		dst.(*Animation).Samplers[i-chleng] = object.(*AnimationSampler)
 	}
 }
+func (s *SpecAnimation) ChildPointer(i int) string {
+	if chleng := len(s.Channels); i < chleng {
+		return fmt.Sprintf("/channels/%d", i)
+	} else {
+		return fmt.Sprintf("/samplers/%d", i-chleng)
+	}
+}
 func (s *SpecAnimation) LenChild() int {
 	return len(s.Channels) + len(s.Samplers)
 }
@@ -35,6 +35,7 @@ func (s *SpecAnimationChannel) Scheme() string {
 	return SCHEME_ANIMATION_CHANNEL
 }
 func (s *SpecAnimationChannel) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
@@ -42,13 +43,17 @@ func (s *SpecAnimationChannel) Syntax(strictness Strictness, root Specifier, par
 		fallthrough
 	case LEVEL1:
 		if s.Sampler == nil {
-			return errors.WithMessage(ErrorGLTFSpec, "AnimationChannel.Sampler required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/sampler", "AnimationChannel.Sampler required")
 		}
 		if s.Target == nil {
-			return errors.WithMessage(ErrorGLTFSpec, "AnimationChannel.Target required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/target", "AnimationChannel.Target required")
+		}
+		// sampler is index of parent animation samplers
+		if anim, ok := parent.(*SpecAnimation); ok {
+			issues.reference(s.Sampler, len(anim.Samplers), "/sampler", "AnimationChannel.Sampler")
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecAnimationChannel) To(ctx *parserContext) interface{} {
 	res := new(AnimationChannel)
@@ -69,6 +74,9 @@ func (s *SpecAnimationChannel) GetChild(i int) Specifier {
 func (s *SpecAnimationChannel) SetChild(i int, dst, object interface{}) {
 	dst.(*AnimationChannel).Target = object.(*AnimationChannelTarget)
 }
+func (s *SpecAnimationChannel) ChildPointer(i int) string {
+	return "/target"
+}
 func (s *SpecAnimationChannel) LenChild() int {
 	if s.Target == nil {
 		return 0
@@ -19,7 +19,7 @@ func (s *AnimationChannelTarget) SetExtension(extensions *Extensions) {
 }
 
 type SpecAnimationChannelTarget struct {
-	Node       *SpecGLTFID     `json:"node"`
+	Node       *SpecGLTFID     `json:"node,omitempty"`
 	Path       *Path           `json:"path"` // required
 	Extensions *SpecExtensions `json:"extensions,omitempty"`
 	Extras     *Extras         `json:"extras,omitempty"`
@@ -32,6 +32,7 @@ func (s *SpecAnimationChannelTarget) Scheme() string {
 	return SCHEME_ANIMATION_CHANNEL_TARGET
 }
 func (s *SpecAnimationChannelTarget) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
@@ -42,10 +43,21 @@ func (s *SpecAnimationChannelTarget) Syntax(strictness Strictness, root Specifie
 		fallthrough
 	case LEVEL1:
 		if s.Path == nil {
-			return errors.Errorf("AnimationChannelTarget.Path required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/path", "AnimationChannelTarget.Path required")
+		}
+		if s.Path != nil && *s.Path == Pointer {
+			if s.Node != nil {
+				issues.add(LEVEL1, CODE_UNSATISFIED_DEPENDENCY, "/node", "AnimationChannelTarget.Node must be undefined for Path 'pointer'")
+			}
+			if s.Extensions == nil || (*s.Extensions)[new(KHRAnimationPointer).ExtensionName()] == nil {
+				issues.add(LEVEL1, CODE_UNSATISFIED_DEPENDENCY, "/extensions", "AnimationChannelTarget.Path 'pointer' need KHRAnimationPointer")
+			}
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.Node, len(g.Nodes), "/node", "AnimationChannelTarget.Node")
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecAnimationChannelTarget) To(ctx *parserContext) interface{} {
 	res := new(AnimationChannelTarget)
@@ -23,9 +23,9 @@ func (s *AnimationSampler) SetExtension(extensions *Extensions) {
 }
 
 type SpecAnimationSampler struct {
-	Input         *SpecGLTFID     `json:"input"`         // required
-	Interpolation *Interpolation  `json:"interpolation"` // default(LINEAR)
-	Output        *SpecGLTFID     `json:"output"`        // required, AnimationSampler.Output -> Accessor.ComponentType must(FLOAT or normalized integer)
+	Input         *SpecGLTFID     `json:"input"`                   // required
+	Interpolation *Interpolation  `json:"interpolation,omitempty"` // default(LINEAR)
+	Output        *SpecGLTFID     `json:"output"`                  // required, AnimationSampler.Output -> Accessor.ComponentType must(FLOAT or normalized integer)
 	Extensions    *SpecExtensions `json:"extensions,omitempty"`
 	Extras        *Extras         `json:"extras,omitempty"`
 }
@@ -37,6 +37,7 @@ func (s *SpecAnimationSampler) Scheme() string {
 	return SCHEME_ANIMATION_SAMPLER
 }
 func (s *SpecAnimationSampler) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
@@ -44,20 +45,23 @@ func (s *SpecAnimationSampler) Syntax(strictness Strictness, root Specifier, par
 		fallthrough
 	case LEVEL1:
 		if s.Input == nil {
-			return errors.Errorf("AnimationSampler.Input required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/input", "AnimationSampler.Input required")
 		}
 		if s.Output == nil {
-			return errors.Errorf("AnimationSampler.Output required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/output", "AnimationSampler.Output required")
 		}
-		if s.Output != nil && inRange(*s.Output, len(root.(*SpecGLTF).Accessors)) {
-
-			if acc := root.(*SpecGLTF).Accessors[*s.Output]; !((acc.ComponentType != nil && *acc.ComponentType == FLOAT) ||
-				(acc.Normalized != nil && *acc.Normalized)) {
-				return errors.Errorf("AnimationSampler.Output -> Accessor.ComponentType must(FLOAT or normalized integer)")
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.Input, len(g.Accessors), "/input", "AnimationSampler.Input")
+			issues.reference(s.Output, len(g.Accessors), "/output", "AnimationSampler.Output")
+			if s.Output != nil && inRange(*s.Output, len(g.Accessors)) {
+				if acc := g.Accessors[*s.Output]; !((acc.ComponentType != nil && *acc.ComponentType == FLOAT) ||
+					(acc.Normalized != nil && *acc.Normalized)) {
+					issues.add(LEVEL1, CODE_VALUE_NOT_IN_LIST, "/output", "AnimationSampler.Output -> Accessor.ComponentType must(FLOAT or normalized integer)")
+				}
 			}
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecAnimationSampler) To(ctx *parserContext) interface{} {
 	res := new(AnimationSampler)
@@ -4,8 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/iamGreedy/essence/version"
-
-	"github.com/pkg/errors"
 )
 
 type Asset struct {
@@ -50,6 +48,7 @@ func (s *SpecAsset) Scheme() string {
 	return SCHEME_ASSET
 }
 func (s *SpecAsset) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
@@ -57,10 +56,10 @@ func (s *SpecAsset) Syntax(strictness Strictness, root Specifier, parent Specifi
 		fallthrough
 	case LEVEL1:
 		if s.Version == nil {
-			return errors.Errorf("Asset.Version required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/version", "Asset.Version required")
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecAsset) To(ctx *parserContext) interface{} {
 	res := new(Asset)
@@ -1,11 +1,8 @@
 package gltf2
 
 import (
-	"github.com/iamGreedy/essence/req"
 	"github.com/pkg/errors"
 	"io/ioutil"
-	"path"
-	"path/filepath"
 )
 
 // https://github.com/KhronosGroup/glTF/tree/master/specification/2.0#buffers-and-buffer-views
@@ -14,6 +11,10 @@ type Buffer struct {
 	// nullable
 	cache []byte
 	unavailableURI bool
+	// glb BIN chunk, used when URI is nil
+	embedded []byte
+	// nil is working directory
+	resolver Resolver
 	//
 	URI *URI
 	// If it was nil, it automatically assume size
@@ -42,13 +43,28 @@ func (s *Buffer) Load(storeCache bool) (bts []byte, err error) {
 	}
 	// setup 'bts'
 	if s.URI == nil {
-		// check zero filled buffer
-		if s.ByteLength == nil {
-			return nil, errors.New("URI nil")
+		if s.embedded != nil {
+			// glb BIN chunk
+			bts = s.embedded
+		} else {
+			// check zero filled buffer
+			if s.ByteLength == nil {
+				return nil, errors.New("URI nil")
+			}
+			bts = make([]byte, *s.ByteLength)
+		}
+	} else if s.URI.IsDataURI() {
+		// embedded data URI, decode in-process
+		mime, data, err := s.URI.DataURI()
+		if err != nil {
+			return nil, err
+		}
+		if mime != "application/octet-stream" && mime != "application/gltf-buffer" {
+			return nil, errors.Errorf("Buffer data URI mime type '%s' not supported", mime)
 		}
-		bts = make([]byte, *s.ByteLength)
+		bts = data
 	} else {
-		rdc, err := req.Standard.Request(s.URI.Data())
+		rdc, err := resolve(s.resolver, s.URI)
 		if err != nil {
 			return nil, err
 		}
@@ -60,6 +76,9 @@ func (s *Buffer) Load(storeCache bool) (bts []byte, err error) {
 	}
 	// length limit
 	if s.ByteLength != nil {
+		if len(bts) < *s.ByteLength {
+			return nil, errors.Errorf("Buffer.ByteLength is %d, but only %d bytes available", *s.ByteLength, len(bts))
+		}
 		bts = bts[:*s.ByteLength]
 	}
 	// cache
@@ -69,6 +88,10 @@ func (s *Buffer) Load(storeCache bool) (bts []byte, err error) {
 	}
 	return bts, nil
 }
+// SetResolver change how URI is loaded
+func (s *Buffer) SetResolver(resolver Resolver) {
+	s.resolver = resolver
+}
 func (s *Buffer) Modify() ([]byte, error) {
 	bts, err := s.Load(true)
 	if err != nil {
@@ -77,6 +100,31 @@ func (s *Buffer) Modify() ([]byte, error) {
 	s.unavailableURI = true
 	return bts, nil
 }
+// Append data at end of buffer, it return byte offset of data
+//
+// Offset is aligned to align bytes by zero padding. Buffer become modified like Modify, new Buffer without URI start empty
+func (s *Buffer) Append(data []byte, align int) (offset int, err error) {
+	var bts []byte
+	if s.URI == nil && s.embedded == nil && s.ByteLength == nil && !s.IsCached() {
+		bts = []byte{}
+	} else if bts, err = s.Modify(); err != nil {
+		return 0, err
+	}
+	if align > 1 {
+		bts = append(bts, make([]byte, (align-len(bts)%align)%align)...)
+	}
+	offset = len(bts)
+	bts = append(bts, data...)
+	length := len(bts)
+	s.cache = bts
+	s.ByteLength = &length
+	s.unavailableURI = true
+	return offset, nil
+}
+// IsEmbedded report buffer is glb BIN chunk
+func (s *Buffer) IsEmbedded() bool {
+	return s.URI == nil && s.embedded != nil
+}
 func (s *Buffer) Cache() []byte {
 	return s.cache
 }
@@ -88,8 +136,8 @@ func (s *Buffer) IsCached() bool {
 }
 
 type SpecBuffer struct {
-	URI        *URI            `json:"URI, omitempty"`
-	ByteLength *int            `json:"ByteLength"` // required, min(1)
+	URI        *URI            `json:"uri,omitempty"` // nil for glb BIN chunk
+	ByteLength *int            `json:"byteLength"`    // required, min(1)
 	Name       *string         `json:"name,omitempty"`
 	Extensions *SpecExtensions `json:"extensions,omitempty"`
 	Extras     *Extras         `json:"extras,omitempty"`
@@ -102,36 +150,29 @@ func (s *SpecBuffer) Scheme() string {
 	return SCHEME_BUFFER
 }
 func (s *SpecBuffer) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
-		if *s.ByteLength < 1 {
-			return errors.New("Buffer.ByteLength min(1)")
+		if s.ByteLength != nil && *s.ByteLength < 1 {
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/byteLength", "Buffer.ByteLength min(1)")
 		}
 		fallthrough
 	case LEVEL2:
 		fallthrough
 	case LEVEL1:
 		if s.ByteLength == nil {
-			return errors.New("Buffer.ByteLength required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/byteLength", "Buffer.ByteLength required")
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecBuffer) To(ctx *parserContext) interface{} {
 	res := new(Buffer)
 	res.URI = s.URI
-	if res.URI != nil {
-		switch res.URI.Scheme {
-		case "":
-			fallthrough
-		case "file":
-			dir := ctx.Directory()
-			if dir == "" {
-				dir = "."
-			}
-			res.URI.Path = filepath.Join(dir, filepath.FromSlash(path.Clean("/"+res.URI.Path)))
-		}
+	if res.URI == nil {
+		res.embedded = ctx.binaryChunkOf(s)
 	}
+	res.resolver = ctx.Resolver()
 	res.ByteLength = s.ByteLength
 	if s.Name != nil {
 		res.Name = *s.Name
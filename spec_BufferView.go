@@ -30,8 +30,13 @@ func (s *BufferView) SetExtension(extensions *Extensions) {
 	s.Extensions = extensions
 }
 
+// Load return data of bufferView, EXT_meshopt_compression data is decoded
 func (s *BufferView) Load() ([]byte, error) {
-
+	if s.Extensions != nil {
+		if ext, ok := s.Extensions.Get(new(EXTMeshoptCompression)).(*EXTMeshoptCompression); ok && ext.Buffer != nil {
+			return ext.Load()
+		}
+	}
 	var bts []byte
 	if s.Buffer.IsCached() {
 		bts = s.Buffer.Cache()
@@ -53,11 +58,11 @@ func (s *BufferView) LoadReader() (io.Reader, error) {
 }
 
 type SpecBufferView struct {
-	Buffer     *SpecGLTFID     `json:"buffer"`     // required
-	ByteOffset *int            `json:"byteOffset"` // default(0), min(0)
-	ByteLength *int            `json:"ByteLength"` // required, min(1)
-	ByteStride *int            `json:"byteStride"` // range(4, 252, step=4) ! default(0) : not spec, but can be
-	Target     *BufferType     `json:"target"`     //
+	Buffer     *SpecGLTFID     `json:"buffer"`               // required
+	ByteOffset *int            `json:"byteOffset,omitempty"` // default(0), min(0)
+	ByteLength *int            `json:"byteLength"`           // required, min(1)
+	ByteStride *int            `json:"byteStride,omitempty"` // range(4, 252, step=4) ! default(0) : not spec, but can be
+	Target     *BufferType     `json:"target,omitempty"`     //
 	Name       *string         `json:"name,omitempty"`
 	Extensions *SpecExtensions `json:"extensions,omitempty"`
 	Extras     *Extras         `json:"extras,omitempty"`
@@ -70,16 +75,17 @@ func (s *SpecBufferView) Scheme() string {
 	return SCHEME_BUFFERVIEW
 }
 func (s *SpecBufferView) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
-		if s.ByteStride != nil && *s.ByteStride < 4 && *s.ByteStride > 252 && *s.ByteStride%4 == 0 {
-			return errors.Errorf("BufferView.ByteStride range(4, 252, step=4), but got '%d'", *s.ByteStride)
+		if s.ByteStride != nil && (*s.ByteStride < 4 || *s.ByteStride > 252 || *s.ByteStride%4 != 0) {
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/byteStride", "BufferView.ByteStride range(4, 252, step=4), but got '%d'", *s.ByteStride)
 		}
 		if s.ByteLength != nil && *s.ByteLength < 1 {
-			return errors.Errorf("BufferView.ByteLength min(1), but got '%d'", *s.ByteLength)
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/byteLength", "BufferView.ByteLength min(1), but got '%d'", *s.ByteLength)
 		}
 		if s.ByteOffset != nil && *s.ByteOffset < 0 {
-			return errors.Errorf("BufferView.ByteOffset min(0), but got '%d'", *s.ByteOffset)
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/byteOffset", "BufferView.ByteOffset min(0), but got '%d'", *s.ByteOffset)
 		}
 		fallthrough
 	case LEVEL2:
@@ -87,13 +93,16 @@ func (s *SpecBufferView) Syntax(strictness Strictness, root Specifier, parent Sp
 		fallthrough
 	case LEVEL1:
 		if s.Buffer == nil {
-			return errors.Errorf("BufferView.Buffer required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/buffer", "BufferView.Buffer required")
 		}
 		if s.ByteLength == nil {
-			return errors.Errorf("BufferView.ByteLength required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/byteLength", "BufferView.ByteLength required")
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.Buffer, len(g.Buffers), "/buffer", "BufferView.Buffer")
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecBufferView) To(ctx *parserContext) interface{} {
 	res := new(BufferView)
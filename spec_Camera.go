@@ -2,7 +2,6 @@ package gltf2
 
 import (
 	"github.com/go-gl/mathgl/mgl32"
-	"github.com/pkg/errors"
 	"image"
 )
 
@@ -30,9 +29,9 @@ type CameraSetting interface {
 }
 
 type SpecCamera struct {
-	Type         *CameraType             `json:"type"`         // required
-	Orthographic *SpecCameraOrthographic `json:"orthographic"` // link(type)
-	Perspective  *SpecCameraPerspective  `json:"perspective"`  // link(type)
+	Type         *CameraType             `json:"type"`                   // required
+	Orthographic *SpecCameraOrthographic `json:"orthographic,omitempty"` // link(type)
+	Perspective  *SpecCameraPerspective  `json:"perspective,omitempty"`  // link(type)
 
 	Extensions *SpecExtensions `json:"extensions,omitempty"`
 	Extras     *Extras         `json:"extras,omitempty"`
@@ -43,14 +42,27 @@ func (s *SpecCamera) SpecExtension() *SpecExtensions {
 }
 
 func (s *SpecCamera) GetChild(i int) Specifier {
+	if s.Type == nil {
+		return nil
+	}
 	switch *s.Type {
 	case Orthographic:
-		return s.Orthographic
+		if s.Orthographic != nil {
+			return s.Orthographic
+		}
 	case Perspective:
-		return s.Perspective
+		if s.Perspective != nil {
+			return s.Perspective
+		}
 	}
 	return nil
 }
+func (s *SpecCamera) ChildPointer(i int) string {
+	if s.Type != nil && *s.Type == Orthographic {
+		return "/orthographic"
+	}
+	return "/perspective"
+}
 func (s *SpecCamera) SetChild(i int, dst, object interface{}) {
 	switch *s.Type {
 	case Orthographic:
@@ -70,6 +82,7 @@ func (s *SpecCamera) Scheme() string {
 	return SCHEME_CAMERA
 }
 func (s *SpecCamera) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
@@ -77,10 +90,21 @@ func (s *SpecCamera) Syntax(strictness Strictness, root Specifier, parent Specif
 		fallthrough
 	case LEVEL1:
 		if s.Type == nil {
-			return errors.Errorf("CameraSetting.AccessorType required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/type", "CameraSetting.AccessorType required")
+		} else {
+			switch *s.Type {
+			case Orthographic:
+				if s.Orthographic == nil {
+					issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/orthographic", "Camera.Orthographic required by type")
+				}
+			case Perspective:
+				if s.Perspective == nil {
+					issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/perspective", "Camera.Perspective required by type")
+				}
+			}
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecCamera) To(ctx *parserContext) interface{} {
 	//switch *s.Type {
@@ -2,7 +2,6 @@ package gltf2
 
 import (
 	"github.com/go-gl/mathgl/mgl32"
-	"github.com/pkg/errors"
 	"image"
 )
 
@@ -57,42 +56,43 @@ func (s *SpecCameraOrthographic) Scheme() string {
 	return SCHEME_CAMERA_ORTHOGRAPHIC
 }
 func (s *SpecCameraOrthographic) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
 	case LEVEL2:
 		if s.Xmag != nil && *s.Xmag == 0.0 {
-			return errors.WithMessage(ErrorGLTFSpec, "OrthographicCamera.Xmag not(0.0)")
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/xmag", "OrthographicCamera.Xmag not(0.0)")
 		}
 		if s.Ymag != nil && *s.Ymag == 0.0 {
-			return errors.WithMessage(ErrorGLTFSpec, "OrthographicCamera.Ymag not(0.0)")
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/ymag", "OrthographicCamera.Ymag not(0.0)")
 		}
 
 		if s.Znear != nil && *s.Znear < 0.0 {
-			return errors.WithMessage(ErrorGLTFSpec, "OrthographicCamera.Znear minimum(0.0)")
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/znear", "OrthographicCamera.Znear minimum(0.0)")
 		}
 		if s.Zfar != nil && *s.Zfar <= 0.0 {
-			return errors.WithMessage(ErrorGLTFSpec, "OrthographicCamera.Zfar larger(0.0)")
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/zfar", "OrthographicCamera.Zfar larger(0.0)")
 		}
 		if s.Znear != nil && s.Zfar != nil && *s.Znear > *s.Zfar {
-			return errors.WithMessage(ErrorGLTFSpec, "OrthographicCamera.Zfar larger(znear)")
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/zfar", "OrthographicCamera.Zfar larger(znear)")
 		}
 		fallthrough
 	case LEVEL1:
 		if s.Xmag == nil {
-			return errors.WithMessage(ErrorGLTFSpec, "OrthographicCamera.Xmag required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/xmag", "OrthographicCamera.Xmag required")
 		}
 		if s.Ymag == nil {
-			return errors.WithMessage(ErrorGLTFSpec, "OrthographicCamera.Ymag required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/ymag", "OrthographicCamera.Ymag required")
 		}
 		if s.Znear == nil {
-			return errors.WithMessage(ErrorGLTFSpec, "OrthographicCamera.Znear required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/znear", "OrthographicCamera.Znear required")
 		}
 		if s.Zfar == nil {
-			return errors.WithMessage(ErrorGLTFSpec, "OrthographicCamera.Zfar required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/zfar", "OrthographicCamera.Zfar required")
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecCameraOrthographic) To(ctx *parserContext) interface{} {
 	res := new(OrthographicCamera)
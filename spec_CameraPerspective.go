@@ -2,7 +2,6 @@ package gltf2
 
 import (
 	"github.com/go-gl/mathgl/mgl32"
-	"github.com/pkg/errors"
 	"image"
 	"math"
 )
@@ -44,10 +43,10 @@ func (s *PerspectiveCamera) CameraType() CameraType {
 }
 
 type SpecCameraPerspective struct {
-	AspectRatio *float32        `json:"aspectRatio"` // larger(0.0)
-	Yfov        *float32        `json:"yfov"`        // required
-	Znear       *float32        `json:"znear"`       // required, larger(0.0)
-	Zfar        *float32        `json:"zfar"`        // larger(0.0) larger(znear) : not spec but need
+	AspectRatio *float32        `json:"aspectRatio,omitempty"` // larger(0.0)
+	Yfov        *float32        `json:"yfov"`                  // required
+	Znear       *float32        `json:"znear"`                 // required, larger(0.0)
+	Zfar        *float32        `json:"zfar,omitempty"`        // larger(0.0) larger(znear) : not spec but need
 	Extensions  *SpecExtensions `json:"extensions,omitempty"`
 	Extras      *Extras         `json:"extras,omitempty"`
 }
@@ -60,32 +59,33 @@ func (s *SpecCameraPerspective) Scheme() string {
 	return SCHEME_CAMERA_PERSPECTIVE
 }
 func (s *SpecCameraPerspective) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
 	case LEVEL2:
 		if s.AspectRatio != nil && *s.AspectRatio <= 0.0 {
-			return errors.WithMessage(ErrorGLTFSpec, "CameraPerspective.AspectRatio larger(0.0)")
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/aspectRatio", "CameraPerspective.AspectRatio larger(0.0)")
 		}
 		if s.Zfar != nil && *s.Zfar <= 0.0 {
-			return errors.WithMessage(ErrorGLTFSpec, "CameraPerspective.Zfar larger(0.0)")
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/zfar", "CameraPerspective.Zfar larger(0.0)")
 		}
-		if s.Zfar != nil && *s.Znear > *s.Zfar {
-			return errors.WithMessage(ErrorGLTFSpec, "CameraPerspective.Zfar larger(znear)")
+		if s.Zfar != nil && s.Znear != nil && *s.Znear > *s.Zfar {
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/zfar", "CameraPerspective.Zfar larger(znear)")
 		}
 		if s.Znear != nil && *s.Znear <= 0.0 {
-			return errors.WithMessage(ErrorGLTFSpec, "CameraPerspective.Znear larger(0.0)")
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/znear", "CameraPerspective.Znear larger(0.0)")
 		}
 		fallthrough
 	case LEVEL1:
 		if s.Yfov == nil {
-			return errors.WithMessage(ErrorGLTFSpec, "CameraPerspective.Yfov required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/yfov", "CameraPerspective.Yfov required")
 		}
 		if s.Znear == nil {
-			return errors.WithMessage(ErrorGLTFSpec, "CameraPerspective.Znear required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/znear", "CameraPerspective.Znear required")
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecCameraPerspective) To(ctx *parserContext) interface{} {
 	res := new(PerspectiveCamera)
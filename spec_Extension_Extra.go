@@ -18,12 +18,20 @@ type SpecExtensions map[string]*jsonRawString
 type jsonRawString struct {
 	src  []byte
 	data Specifier
+	// ExtensionType which construct data
+	ext ExtensionType
 }
 
 func (s *jsonRawString) String() string {
 	return string(s.src)
 }
 
+func (s *jsonRawString) MarshalJSON() ([]byte, error) {
+	if s.src == nil {
+		return []byte("null"), nil
+	}
+	return s.src, nil
+}
 func (s *jsonRawString) UnmarshalJSON(src []byte) error {
 	s.src = src
 	return nil
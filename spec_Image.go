@@ -1,14 +1,15 @@
 package gltf2
 
 import (
-	"github.com/iamGreedy/essence/req"
+	"bytes"
 	"github.com/pkg/errors"
+	_ "golang.org/x/image/webp"
 	"image"
 	"image/draw"
-	"image/jpeg"
-	"image/png"
-	"path"
-	"path/filepath"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"io/ioutil"
 )
 
 type Image interface {
@@ -30,6 +31,8 @@ type URIImage struct {
 	cache *image.RGBA
 	//
 	URI *URI
+	// nil is working directory
+	resolver Resolver
 	//
 	name       string
 	extensions *Extensions
@@ -67,19 +70,31 @@ func (s *URIImage) Load(useCache bool) (img *image.RGBA, err error) {
 		return s.Cache(), nil
 	}
 	// setup 'img'
-	rdc, err := req.Standard.Request(s.URI.Data())
-	if err != nil {
-		return nil, err
-	}
-	defer rdc.Close()
-	// image decode
-	temp, _, err := image.Decode(rdc)
-	if err != nil {
-		return nil, err
+	if s.URI.IsDataURI() {
+		// embedded data URI, decode in-process
+		mimestr, data, err := s.URI.DataURI()
+		if err != nil {
+			return nil, err
+		}
+		mime, ok := mimeTypeFromString(mimestr)
+		if !ok {
+			return nil, errors.Errorf("Image data URI mime type '%s' not supported", mimestr)
+		}
+		img, err = decodeImage(bytes.NewReader(data), &mime)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		rdc, err := resolve(s.resolver, s.URI)
+		if err != nil {
+			return nil, err
+		}
+		defer rdc.Close()
+		img, err = decodeImage(rdc, nil)
+		if err != nil {
+			return nil, err
+		}
 	}
-	// image move
-	img = image.NewRGBA(temp.Bounds())
-	draw.Draw(img, img.Rect, temp, temp.Bounds().Min, draw.Src)
 	// cache
 	if useCache {
 		// setup cache
@@ -87,6 +102,25 @@ func (s *URIImage) Load(useCache bool) (img *image.RGBA, err error) {
 	}
 	return img, nil
 }
+
+// LoadRaw load encoded image file(png, jpeg) without decoding
+func (s *URIImage) LoadRaw() ([]byte, error) {
+	if s.URI.IsDataURI() {
+		_, data, err := s.URI.DataURI()
+		return data, err
+	}
+	rdc, err := resolve(s.resolver, s.URI)
+	if err != nil {
+		return nil, err
+	}
+	defer rdc.Close()
+	return ioutil.ReadAll(rdc)
+}
+
+// SetResolver change how URI is loaded
+func (s *URIImage) SetResolver(resolver Resolver) {
+	s.resolver = resolver
+}
 func (s *URIImage) Cache() *image.RGBA {
 	return s.cache
 }
@@ -136,29 +170,27 @@ func (s *BufferImage) Extras() *Extras {
 	return s.extras
 }
 func (s *BufferImage) Load(useCache bool) (img *image.RGBA, err error) {
+	if s.IsCached() {
+		return s.Cache(), nil
+	}
 	rd, err := s.BufferView.LoadReader()
 	if err != nil {
 		return nil, err
 	}
-	var temp image.Image
-	switch s.Mime {
-	case ImagePNG:
-		temp, err = png.Decode(rd)
-		if err != nil {
-			return nil, err
-		}
-	case ImageJPEG:
-		temp, err = jpeg.Decode(rd)
-		if err != nil {
-			return nil, err
-		}
+	img, err = decodeImage(rd, &s.Mime)
+	if err != nil {
+		return nil, err
+	}
+	// cache
+	if useCache {
+		s.cache = img
 	}
-	//
-	img = image.NewRGBA(temp.Bounds())
-	draw.Draw(img, img.Rect, temp, temp.Bounds().Min, draw.Src)
-	//
 	return img, nil
+}
 
+// LoadRaw load encoded image file without decoding
+func (s *BufferImage) LoadRaw() ([]byte, error) {
+	return s.BufferView.Load()
 }
 func (s *BufferImage) Cache() *image.RGBA {
 	return s.cache
@@ -170,10 +202,27 @@ func (s *BufferImage) IsCached() bool {
 	return s.cache != nil
 }
 
+// decodeImage decode image to RGBA
+//
+// if mime is not nil, decoded format must be same as declared mime type
+func decodeImage(rd io.Reader, mime *MimeType) (*image.RGBA, error) {
+	temp, format, err := image.Decode(rd)
+	if err != nil {
+		return nil, err
+	}
+	if mime != nil && mime.Format() != format {
+		return nil, errors.Errorf("Image declared as '%s', but decoded as '%s'", mime.String(), format)
+	}
+	// image move
+	img := image.NewRGBA(temp.Bounds())
+	draw.Draw(img, img.Rect, temp, temp.Bounds().Min, draw.Src)
+	return img, nil
+}
+
 type SpecImage struct {
-	URI        *URI            `json:"URI"`        // exclusive_require(URI, bufferView)
-	MimeType   *MimeType       `json:"mimeType"`   //
-	BufferView *SpecGLTFID     `json:"bufferView"` // exclusive_require(URI, bufferView), dependency(MimeType)
+	URI        *URI            `json:"uri,omitempty"`        // exclusive_require(URI, bufferView)
+	MimeType   *MimeType       `json:"mimeType,omitempty"`   //
+	BufferView *SpecGLTFID     `json:"bufferView,omitempty"` // exclusive_require(URI, bufferView), dependency(MimeType)
 	Name       *string         `json:"name,omitempty"`
 	Extensions *SpecExtensions `json:"extensions,omitempty"`
 	Extras     *Extras         `json:"extras,omitempty"`
@@ -187,6 +236,7 @@ func (s *SpecImage) Scheme() string {
 	return SCHEME_IMAGE
 }
 func (s *SpecImage) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
@@ -194,30 +244,22 @@ func (s *SpecImage) Syntax(strictness Strictness, root Specifier, parent Specifi
 		fallthrough
 	case LEVEL1:
 		if (s.URI != nil && s.BufferView != nil) || (s.URI == nil && s.BufferView == nil) {
-			return errors.Errorf("Image must have one of 'Image.URI' or 'Image.bufferView'")
+			issues.add(LEVEL1, CODE_ONE_OF_MISMATCH, "", "Image must have one of 'Image.URI' or 'Image.bufferView'")
 		}
 		if s.BufferView != nil && s.MimeType == nil {
-			return errors.Errorf("Image.bufferView dependency(MimeType)")
+			issues.add(LEVEL1, CODE_UNSATISFIED_DEPENDENCY, "/mimeType", "Image.bufferView dependency(MimeType)")
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.BufferView, len(g.BufferViews), "/bufferView", "Image.BufferView")
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecImage) To(ctx *parserContext) interface{} {
 	if s.URI != nil {
 		res := new(URIImage)
 		res.URI = s.URI
-		if res.URI != nil {
-			switch res.URI.Scheme {
-			case "":
-				fallthrough
-			case "file":
-				dir := ctx.Directory()
-				if dir == "" {
-					dir = "."
-				}
-				res.URI.Path = filepath.Join(dir, filepath.FromSlash(path.Clean("/"+res.URI.Path)))
-			}
-		}
+		res.resolver = ctx.Resolver()
 		if s.Name != nil {
 			res.name = *s.Name
 		}
@@ -239,7 +281,7 @@ func (s *SpecImage) To(ctx *parserContext) interface{} {
 	panic("Unreachable")
 }
 func (s *SpecImage) Link(Root *GLTF, parent interface{}, dst interface{}) error {
-	if bi, ok := dst.(BufferImage); ok {
+	if bi, ok := dst.(*BufferImage); ok {
 		if !inRange(*s.BufferView, len(Root.BufferViews)) {
 			return errors.Errorf("Image.BufferView linking fail")
 		}
@@ -247,3 +289,16 @@ func (s *SpecImage) Link(Root *GLTF, parent interface{}, dst interface{}) error
 	}
 	return nil
 }
+
+// detectMimeType find MimeType of encoded image file by its header
+func detectMimeType(data []byte) (MimeType, error) {
+	_, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	mime, ok := mimeTypeFromFormat(format)
+	if !ok {
+		return 0, errors.Errorf("Image format '%s' not supported", format)
+	}
+	return mime, nil
+}
@@ -2,7 +2,6 @@ package gltf2
 
 import (
 	"github.com/go-gl/mathgl/mgl32"
-	"github.com/pkg/errors"
 )
 
 type Material struct {
@@ -43,18 +42,33 @@ func (s *Material) SetExtension(extensions *Extensions) {
 	s.Extensions = extensions
 }
 
+// EmissiveRadiance is EmissiveFactor multiplied by KHR_materials_emissive_strength, EmissiveTexture is not applied
+func (s *Material) EmissiveRadiance() mgl32.Vec3 {
+	if s.Extensions != nil {
+		if ext, ok := s.Extensions.Get(new(KHRMaterialsEmissiveStrength)).(*KHRMaterialsEmissiveStrength); ok {
+			return s.EmissiveFactor.Mul(ext.EmissiveStrength)
+		}
+	}
+	return s.EmissiveFactor
+}
+
+// IsUnlit report material has KHR_materials_unlit
+func (s *Material) IsUnlit() bool {
+	return s.Extensions != nil && s.Extensions.Get(new(KHRMaterialsUnlit)) != nil
+}
+
 type SpecMaterial struct {
 	Name                 *string                           `json:"name,omitempty"`
 	Extensions           *SpecExtensions                   `json:"extensions,omitempty"`
 	Extras               *Extras                           `json:"extras,omitempty"`
-	PBRMetallicRoughness *SpecMaterialPBRMetallicRoughness `json:"pbrMetallicRoughness"`
-	NormalTexture        *SpecMaterialNormalTextureInfo    `json:"normalTexture"`
-	OcclusionTexture     *SpecMaterialOcclusionTextureInfo `json:"occlusionTexture"`
-	EmissiveFactor       *mgl32.Vec3                       `json:"emissiveFactor"`  // default([0.0, 0.0, 0.0], validate(f32Color)
-	EmissiveTexture      *SpecTextureInfo                  `json:"emissiveTexture"` //
-	AlphaMode            *AlphaMode                        `json:"alphaMode"`       // default(OPAQUE)
-	AlphaCutoff          *float32                          `json:"alphaCutoff"`     // default(0.5), minimum(0.0), dependency(AlphaMode) ! ignore(dependency) : cause default
-	DoubleSided          *bool                             `json:"doubleSided"`     // default(false)
+	PBRMetallicRoughness *SpecMaterialPBRMetallicRoughness `json:"pbrMetallicRoughness,omitempty"`
+	NormalTexture        *SpecMaterialNormalTextureInfo    `json:"normalTexture,omitempty"`
+	OcclusionTexture     *SpecMaterialOcclusionTextureInfo `json:"occlusionTexture,omitempty"`
+	EmissiveFactor       *mgl32.Vec3                       `json:"emissiveFactor,omitempty"`  // default([0.0, 0.0, 0.0], validate(f32Color)
+	EmissiveTexture      *SpecTextureInfo                  `json:"emissiveTexture,omitempty"` //
+	AlphaMode            *AlphaMode                        `json:"alphaMode,omitempty"`       // default(OPAQUE)
+	AlphaCutoff          *float32                          `json:"alphaCutoff,omitempty"`     // default(0.5), minimum(0.0), dependency(AlphaMode) ! ignore(dependency) : cause default
+	DoubleSided          *bool                             `json:"doubleSided,omitempty"`     // default(false)
 }
 
 func (s *SpecMaterial) SpecExtension() *SpecExtensions {
@@ -64,25 +78,26 @@ func (s *SpecMaterial) Scheme() string {
 	return SCHEME_MATERIAL
 }
 func (s *SpecMaterial) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		// it was normally LEVEL1 strictness, but AlphaMode has default so it was LEVEL3
 		if s.AlphaCutoff != nil && s.AlphaMode == nil {
-			return errors.WithMessage(ErrorGLTFSpec, "Material.AlphaCutoff dependency(AlphaMode)")
+			issues.add(LEVEL3, CODE_UNSATISFIED_DEPENDENCY, "/alphaCutoff", "Material.AlphaCutoff dependency(AlphaMode)")
 		}
 		fallthrough
 	case LEVEL2:
-		if s.EmissiveFactor != nil && isValidF32Color3(*s.EmissiveFactor) {
-			return errors.WithMessage(ErrorGLTFSpec, "Material.EmissiveFactor validate(f32Color)")
+		if s.EmissiveFactor != nil && !isValidF32Color3(*s.EmissiveFactor) {
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/emissiveFactor", "Material.EmissiveFactor validate(f32Color)")
 		}
 		if s.AlphaCutoff != nil && *s.AlphaCutoff < 0.0 {
-			return errors.WithMessage(ErrorGLTFSpec, "Material.AlphaCutoff minimum(0.0)")
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/alphaCutoff", "Material.AlphaCutoff minimum(0.0)")
 		}
 		fallthrough
 	case LEVEL1:
 
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecMaterial) To(ctx *parserContext) interface{} {
 	res := new(Material)
@@ -130,6 +145,19 @@ func (s *SpecMaterial) SetChild(i int, dst, object interface{}) {
 		}
 	}
 }
+func (s *SpecMaterial) ChildPointer(i int) string {
+	switch s.Children()[i].(type) {
+	case *SpecMaterialPBRMetallicRoughness:
+		return "/pbrMetallicRoughness"
+	case *SpecMaterialNormalTextureInfo:
+		return "/normalTexture"
+	case *SpecMaterialOcclusionTextureInfo:
+		return "/occlusionTexture"
+	case *SpecTextureInfo:
+		return "/emissiveTexture"
+	}
+	return ""
+}
 func (s *SpecMaterial) LenChild() int {
 	return len(s.Children())
 }
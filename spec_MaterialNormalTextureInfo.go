@@ -21,9 +21,9 @@ func (s *MaterialNormalTextureInfo) SetExtension(extensions *Extensions) {
 }
 
 type SpecMaterialNormalTextureInfo struct {
-	Index      *SpecGLTFID     `json:"index"`    // required, minimum(0)
-	TexCoord   *IndexTexCoord  `json:"texCoord"` // default(0), minimum(0)
-	Scale      *float32        `json:"scale"`    // default(1.0), minimum(0)
+	Index      *SpecGLTFID     `json:"index"`              // required, minimum(0)
+	TexCoord   *IndexTexCoord  `json:"texCoord,omitempty"` // default(0), minimum(0)
+	Scale      *float32        `json:"scale,omitempty"`    // default(1.0), minimum(0)
 	Extensions *SpecExtensions `json:"extensions,omitempty"`
 	Extras     *Extras         `json:"extras,omitempty"`
 }
@@ -35,21 +35,24 @@ func (s *SpecMaterialNormalTextureInfo) Scheme() string {
 	return SCHEME_MATERIAL_NORMAL_TEXTUREINFO
 }
 func (s *SpecMaterialNormalTextureInfo) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
-
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		if s.Scale != nil && *s.Scale < 0.0 {
-			return errors.Errorf("MaterialNormalTextureInfo.Scale min(0.0)")
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/scale", "MaterialNormalTextureInfo.Scale min(0.0)")
 		}
 		fallthrough
 	case LEVEL2:
 		fallthrough
 	case LEVEL1:
 		if s.Index == nil {
-			return errors.Errorf("MaterialNormalTextureInfo.Index required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/index", "MaterialNormalTextureInfo.Index required")
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.Index, len(g.Textures), "/index", "MaterialNormalTextureInfo.Index")
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecMaterialNormalTextureInfo) To(ctx *parserContext) interface{} {
 	res := new(MaterialNormalTextureInfo)
@@ -21,9 +21,9 @@ func (s *MaterialOcclusionTextureInfo) SetExtension(extensions *Extensions) {
 }
 
 type SpecMaterialOcclusionTextureInfo struct {
-	Index      *SpecGLTFID     `json:"index"`    // required, minimum(0)
-	TexCoord   *IndexTexCoord  `json:"texCoord"` // default(0), minimum(0)
-	Strength   *float32        `json:"scale"`    // default(1.0), range(0.0, 1.0)
+	Index      *SpecGLTFID     `json:"index"`              // required, minimum(0)
+	TexCoord   *IndexTexCoord  `json:"texCoord,omitempty"` // default(0), minimum(0)
+	Strength   *float32        `json:"strength,omitempty"` // default(1.0), range(0.0, 1.0)
 	Extensions *SpecExtensions `json:"extensions,omitempty"`
 	Extras     *Extras         `json:"extras,omitempty"`
 }
@@ -36,21 +36,24 @@ func (s *SpecMaterialOcclusionTextureInfo) Scheme() string {
 	return SCHEME_MATERIAL_OCCLUSION_TEXTUREINFO
 }
 func (s *SpecMaterialOcclusionTextureInfo) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
-
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
-		if s.Strength != nil && *s.Strength < 0.0 || *s.Strength > 1.0 {
-			return errors.WithMessage(ErrorGLTFSpec, "MaterialOcclusionTextureInfo.Strength range(0.0, 1.0)")
+		if s.Strength != nil && (*s.Strength < 0.0 || *s.Strength > 1.0) {
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_RANGE, "/strength", "MaterialOcclusionTextureInfo.Strength range(0.0, 1.0)")
 		}
 		fallthrough
 	case LEVEL2:
 		fallthrough
 	case LEVEL1:
 		if s.Index == nil {
-			return errors.WithMessage(ErrorGLTFSpec, "MaterialOcclusionTextureInfo.Index required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/index", "MaterialOcclusionTextureInfo.Index required")
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.Index, len(g.Textures), "/index", "MaterialOcclusionTextureInfo.Index")
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecMaterialOcclusionTextureInfo) To(ctx *parserContext) interface{} {
 	res := new(MaterialOcclusionTextureInfo)
@@ -60,7 +63,7 @@ func (s *SpecMaterialOcclusionTextureInfo) To(ctx *parserContext) interface{} {
 		res.TexCoord = *s.TexCoord
 	}
 	if s.Strength == nil {
-		res.Strength = 0
+		res.Strength = 1.0
 	} else {
 		res.Strength = *s.Strength
 	}
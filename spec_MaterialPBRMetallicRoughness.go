@@ -2,7 +2,6 @@ package gltf2
 
 import (
 	"github.com/go-gl/mathgl/mgl32"
-	"github.com/pkg/errors"
 )
 
 type MaterialPBRMetallicRoughness struct {
@@ -24,11 +23,11 @@ func (s *MaterialPBRMetallicRoughness) SetExtension(extensions *Extensions) {
 }
 
 type SpecMaterialPBRMetallicRoughness struct {
-	BaseColorFactor          *mgl32.Vec4      `json:"baseColorFactor"` // default([1.0, 1.0, 1.0, 1.0]), fixedItem(4), eachItemRange(0.0, 0.1)
-	MetallicFactor           *float32         `json:"metallicFactor"`  // default(1.0), range(0.0, 1.0)
-	RoughnessFactor          *float32         `json:"roughnessFactor"` // default(1.0), range(0.0, 1.0)
-	BaseColorTexture         *SpecTextureInfo `json:"baseColorTexture"`
-	MetallicRoughnessTexture *SpecTextureInfo `json:"metallicRoughnessTexture"`
+	BaseColorFactor          *mgl32.Vec4      `json:"baseColorFactor,omitempty"` // default([1.0, 1.0, 1.0, 1.0]), fixedItem(4), eachItemRange(0.0, 0.1)
+	MetallicFactor           *float32         `json:"metallicFactor,omitempty"`  // default(1.0), range(0.0, 1.0)
+	RoughnessFactor          *float32         `json:"roughnessFactor,omitempty"` // default(1.0), range(0.0, 1.0)
+	BaseColorTexture         *SpecTextureInfo `json:"baseColorTexture,omitempty"`
+	MetallicRoughnessTexture *SpecTextureInfo `json:"metallicRoughnessTexture,omitempty"`
 	Extensions               *SpecExtensions  `json:"extensions,omitempty"`
 	Extras                   *Extras          `json:"extras,omitempty"`
 }
@@ -59,6 +58,12 @@ func (s *SpecMaterialPBRMetallicRoughness) SetChild(i int, dst, object interface
 		}
 	}
 }
+func (s *SpecMaterialPBRMetallicRoughness) ChildPointer(i int) string {
+	if s.Children()[i] == Specifier(s.BaseColorTexture) {
+		return "/baseColorTexture"
+	}
+	return "/metallicRoughnessTexture"
+}
 func (s *SpecMaterialPBRMetallicRoughness) LenChild() int {
 	return len(s.Children())
 }
@@ -97,24 +102,24 @@ func (s *SpecMaterialPBRMetallicRoughness) Scheme() string {
 	return SCHEME_MATERIAL_PBR_METALLIC_ROUGHNESS
 }
 func (s *SpecMaterialPBRMetallicRoughness) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
-
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
 	case LEVEL2:
-		if s.BaseColorFactor != nil && isValidF32Color4(*s.BaseColorFactor) {
-			return errors.WithMessage(ErrorGLTFSpec, "MaterialPBRMetallicRoughness.BaseColorFactor validate(f32Color4)")
+		if s.BaseColorFactor != nil && !isValidF32Color4(*s.BaseColorFactor) {
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/baseColorFactor", "MaterialPBRMetallicRoughness.BaseColorFactor validate(f32Color4)")
 		}
 		if s.MetallicFactor != nil && (*s.MetallicFactor < 0.0 || *s.MetallicFactor > 1.0) {
-			return errors.WithMessage(ErrorGLTFSpec, "MaterialPBRMetallicRoughness.MetallicFactor range(0.0, 1.0)")
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/metallicFactor", "MaterialPBRMetallicRoughness.MetallicFactor range(0.0, 1.0)")
 		}
 		if s.RoughnessFactor != nil && (*s.RoughnessFactor < 0.0 || *s.RoughnessFactor > 1.0) {
-			return errors.WithMessage(ErrorGLTFSpec, "MaterialPBRMetallicRoughness.RoughnessFactor range(0.0, 1.0)")
+			issues.add(LEVEL2, CODE_VALUE_NOT_IN_RANGE, "/roughnessFactor", "MaterialPBRMetallicRoughness.RoughnessFactor range(0.0, 1.0)")
 		}
 		fallthrough
 	case LEVEL1:
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecMaterialPBRMetallicRoughness) To(ctx *parserContext) interface{} {
 	res := new(MaterialPBRMetallicRoughness)
@@ -1,8 +1,6 @@
 package gltf2
 
-import (
-	"github.com/pkg/errors"
-)
+import "fmt"
 
 type Mesh struct {
 	Primitives []*MeshPrimitive
@@ -24,8 +22,8 @@ func (s *Mesh) SetExtension(extensions *Extensions) {
 }
 
 type SpecMesh struct {
-	Primitives []SpecMeshPrimitive `json:"primitives"` // required, minItem(1)
-	Weights    []float32           `json:"weights"`    // minItem(1)
+	Primitives []SpecMeshPrimitive `json:"primitives"`        // required, minItem(1)
+	Weights    []float32           `json:"weights,omitempty"` // minItem(1)
 	Name       *string             `json:"name,omitempty"`
 	Extensions *SpecExtensions     `json:"extensions,omitempty"`
 	Extras     *Extras             `json:"extras,omitempty"`
@@ -38,6 +36,7 @@ func (s *SpecMesh) Scheme() string {
 	return SCHEME_MESH
 }
 func (s *SpecMesh) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
@@ -45,11 +44,11 @@ func (s *SpecMesh) Syntax(strictness Strictness, root Specifier, parent Specifie
 
 		fallthrough
 	case LEVEL1:
-		if len(s.Primitives) < 0 {
-			return errors.Errorf("SpecMeshPrimitive.Primitives required")
+		if len(s.Primitives) == 0 {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/primitives", "Mesh.Primitives required")
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecMesh) To(ctx *parserContext) interface{} {
 	res := new(Mesh)
@@ -67,6 +66,9 @@ func (s *SpecMesh) GetChild(i int) Specifier {
 func (s *SpecMesh) SetChild(i int, dst, object interface{}) {
 	dst.(*Mesh).Primitives[i] = object.(*MeshPrimitive)
 }
+func (s *SpecMesh) ChildPointer(i int) string {
+	return fmt.Sprintf("/primitives/%d", i)
+}
 func (s *SpecMesh) LenChild() int {
 	return len(s.Primitives)
 }
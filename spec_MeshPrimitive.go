@@ -1,6 +1,7 @@
 package gltf2
 
 import (
+	"fmt"
 	"github.com/pkg/errors"
 )
 
@@ -26,11 +27,11 @@ func (s *MeshPrimitive) SetExtension(extensions *Extensions) {
 }
 
 type SpecMeshPrimitive struct {
-	Attributes map[AttributeKey]SpecGLTFID   `json:"attributes"` // required, minItem(1)
-	Indices    *SpecGLTFID                   `json:"indices"`    //
-	Material   *SpecGLTFID                   `json:"material"`   //
-	Mode       *Mode                         `json:"mode"`       // default(TRIANGLES)
-	Targets    []map[AttributeKey]SpecGLTFID `json:"targets"`    // [*]allow(POSITION, NORMAL, TANGENT)
+	Attributes map[AttributeKey]SpecGLTFID   `json:"attributes"`         // required, minItem(1)
+	Indices    *SpecGLTFID                   `json:"indices,omitempty"`  //
+	Material   *SpecGLTFID                   `json:"material,omitempty"` //
+	Mode       *Mode                         `json:"mode,omitempty"`     // default(TRIANGLES)
+	Targets    []map[AttributeKey]SpecGLTFID `json:"targets,omitempty"`  // [*]allow(POSITION, NORMAL, TANGENT)
 	Extensions *SpecExtensions               `json:"extensions,omitempty"`
 	Extras     *Extras                       `json:"extras,omitempty"`
 }
@@ -42,16 +43,28 @@ func (s *SpecMeshPrimitive) Scheme() string {
 	return SCHEME_MESH_PRIMITIVE
 }
 func (s *SpecMeshPrimitive) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
 	case LEVEL2:
+		if g, ok := root.(*SpecGLTF); ok {
+			quantized := g.usesExtension(new(KHRMeshQuantization).ExtensionName())
+			for _, k := range sortedAttributeKeys(s.Attributes) {
+				issues.attributeFormat(g, k, s.Attributes[k], false, quantized, "/attributes/"+escapePointer(string(k)))
+			}
+			for i, target := range s.Targets {
+				for _, k := range sortedAttributeKeys(target) {
+					issues.attributeFormat(g, k, target[k], true, quantized, fmt.Sprintf("/targets/%d/%s", i, escapePointer(string(k))))
+				}
+			}
+		}
 		fallthrough
 	case LEVEL1:
-		if len(s.Attributes) < 0 {
-			return errors.Errorf("MeshPrimitive.Attributes required")
+		if len(s.Attributes) == 0 {
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/attributes", "MeshPrimitive.Attributes required")
 		}
-		for _, target := range s.Targets {
+		for i, target := range s.Targets {
 			count := 0
 			if _, ok := target[POSITION]; ok {
 				count++
@@ -63,11 +76,54 @@ func (s *SpecMeshPrimitive) Syntax(strictness Strictness, root Specifier, parent
 				count++
 			}
 			if len(target) != count {
-				return errors.Errorf("MeshPrimitive.Targets [*]allow(POSITION, NORMAL, TANGENT)")
+				issues.add(LEVEL1, CODE_VALUE_NOT_IN_LIST, fmt.Sprintf("/targets/%d", i), "MeshPrimitive.Targets [*]allow(POSITION, NORMAL, TANGENT)")
+			}
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			for _, k := range sortedAttributeKeys(s.Attributes) {
+				id := s.Attributes[k]
+				issues.reference(&id, len(g.Accessors), "/attributes/"+escapePointer(string(k)), "MeshPrimitive.Attributes")
+			}
+			issues.reference(s.Indices, len(g.Accessors), "/indices", "MeshPrimitive.Indices")
+			issues.reference(s.Material, len(g.Materials), "/material", "MeshPrimitive.Material")
+			for i, target := range s.Targets {
+				for _, k := range sortedAttributeKeys(target) {
+					id := target[k]
+					issues.reference(&id, len(g.Accessors), fmt.Sprintf("/targets/%d/%s", i, escapePointer(string(k))), "MeshPrimitive.Targets")
+				}
 			}
 		}
 	}
-	return nil
+	return issues.Err()
+}
+
+// attributeFormat add issue if accessor of attribute has not allowed type or component type
+func (s *Issues) attributeFormat(g *SpecGLTF, key AttributeKey, id SpecGLTFID, target, quantized bool, pointer string) {
+	if !inRange(id, len(g.Accessors)) {
+		return
+	}
+	accessor := g.Accessors[id]
+	if accessor.Type == nil || accessor.ComponentType == nil {
+		return
+	}
+	types, formats := key.AssociateType(target, quantized)
+	if types == nil {
+		return
+	}
+	format := AttributeFormat{ComponentType: *accessor.ComponentType}
+	if accessor.Normalized != nil {
+		format.Normalized = *accessor.Normalized
+	}
+	var typeOk, formatOk bool
+	for _, v := range types {
+		typeOk = typeOk || v == *accessor.Type
+	}
+	for _, v := range formats {
+		formatOk = formatOk || v == format
+	}
+	if !typeOk || !formatOk {
+		s.add(LEVEL2, CODE_VALUE_NOT_IN_LIST, pointer, "MeshPrimitive attribute '%s' allow(%v of %v), but got '%s of %s'", key, types, formats, *accessor.Type, format)
+	}
 }
 func (s *SpecMeshPrimitive) To(ctx *parserContext) interface{} {
 	res := new(MeshPrimitive)
@@ -1,6 +1,7 @@
 package gltf2
 
 import (
+	"fmt"
 	"github.com/go-gl/mathgl/mgl32"
 	"github.com/pkg/errors"
 )
@@ -42,15 +43,15 @@ func (s *Node) Transform() mgl32.Mat4 {
 }
 
 type SpecNode struct {
-	Camera      *SpecGLTFID     `json:"camera"`
-	Children    []SpecGLTFID    `json:"children"`    // unique, minItem(1)
-	Skin        *SpecGLTFID     `json:"skin"`        // dependancy(Mesh)
-	Matrix      *mgl32.Mat4     `json:"matrix"`      // default(mgl32.Ident4()), exclusive(Translation, Rotation, Scale)
-	Rotation    *mgl32.Vec4     `json:"rotation"`    // default(mgl32.Vec4{0,0,0,1})
-	Scale       *mgl32.Vec3     `json:"scale"`       // default(mgl32.Vec{1,1,1})
-	Translation *mgl32.Vec3     `json:"translation"` // default(mgl32.Vec{0,0,0})
-	Weights     []float32       `json:"weights"`     // minItem(1), dependancy(Mesh)
-	Mesh        *SpecGLTFID     `json:"mesh"`        //
+	Camera      *SpecGLTFID     `json:"camera,omitempty"`
+	Children    []SpecGLTFID    `json:"children,omitempty"`    // unique, minItem(1)
+	Skin        *SpecGLTFID     `json:"skin,omitempty"`        // dependancy(Mesh)
+	Matrix      *mgl32.Mat4     `json:"matrix,omitempty"`      // default(mgl32.Ident4()), exclusive(Translation, Rotation, Scale)
+	Rotation    *mgl32.Vec4     `json:"rotation,omitempty"`    // default(mgl32.Vec4{0,0,0,1})
+	Scale       *mgl32.Vec3     `json:"scale,omitempty"`       // default(mgl32.Vec{1,1,1})
+	Translation *mgl32.Vec3     `json:"translation,omitempty"` // default(mgl32.Vec{0,0,0})
+	Weights     []float32       `json:"weights,omitempty"`     // minItem(1), dependancy(Mesh)
+	Mesh        *SpecGLTFID     `json:"mesh,omitempty"`        //
 	Name        *string         `json:"name,omitempty"`
 	Extensions  *SpecExtensions `json:"extensions,omitempty"`
 	Extras      *Extras         `json:"extras,omitempty"`
@@ -64,26 +65,35 @@ func (s *SpecNode) Scheme() string {
 	return SCHEME_NODE
 }
 func (s *SpecNode) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
 	case LEVEL2:
 		if s.Matrix != nil && (s.Translation != nil || s.Rotation != nil || s.Scale != nil) {
-			return errors.Errorf("Node.Matrix exclusive(Translation, Rotation, Scale)")
+			issues.add(LEVEL2, CODE_ONE_OF_MISMATCH, "/matrix", "Node.Matrix exclusive(Translation, Rotation, Scale)")
 		}
 		fallthrough
 	case LEVEL1:
 		if is, i := isUniqueGLTFID(s.Children...); !is {
-			return errors.Errorf("Node.Children not unique '%d'", i)
+			issues.add(LEVEL1, CODE_DUPLICATE_ELEMENTS, "/children", "Node.Children not unique '%d'", i)
 		}
 		if s.Skin != nil && s.Mesh == nil {
-			return errors.WithMessage(ErrorGLTFSpec, "Node.Skin dependancy(Mesh)")
+			issues.add(LEVEL1, CODE_UNSATISFIED_DEPENDENCY, "/skin", "Node.Skin dependancy(Mesh)")
 		}
 		if s.Weights != nil && s.Mesh == nil {
-			return errors.WithMessage(ErrorGLTFSpec, "Node.Weights dependancy(Mesh)")
+			issues.add(LEVEL1, CODE_UNSATISFIED_DEPENDENCY, "/weights", "Node.Weights dependancy(Mesh)")
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.Camera, len(g.Cameras), "/camera", "Node.Camera")
+			for i := range s.Children {
+				issues.reference(&s.Children[i], len(g.Nodes), fmt.Sprintf("/children/%d", i), "Node.Children")
+			}
+			issues.reference(s.Skin, len(g.Skins), "/skin", "Node.Skin")
+			issues.reference(s.Mesh, len(g.Meshes), "/mesh", "Node.Mesh")
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecNode) To(ctx *parserContext) interface{} {
 	res := new(Node)
@@ -105,7 +115,8 @@ func (s *SpecNode) To(ctx *parserContext) interface{} {
 	if s.Rotation == nil {
 		res.Rotation = mgl32.QuatIdent()
 	} else {
-		res.Rotation = mgl32.QuatRotate(s.Rotation[3], s.Rotation.Vec3())
+		// unit quaternion (x, y, z, w)
+		res.Rotation = mgl32.Quat{W: s.Rotation[3], V: s.Rotation.Vec3()}
 	}
 
 	if s.Scale == nil {
@@ -32,10 +32,10 @@ func DefaultSampler() *Sampler {
 }
 
 type SpecSampler struct {
-	MagFilter *MagFilter `json:"magFilter"` // notspec default(MAG_LINEAR) : [https://www.khronos.org/registry/OpenGL-Refpages/gl4/html/glTexParameter.xhtm]
-	MinFilter *MinFilter `json:"minFilter"` // notspec default(MIN_NEAREST_MIPMAP_LINEAR) : [https://www.khronos.org/registry/OpenGL-Refpages/gl4/html/glTexParameter.xhtm]
-	WrapS     *Wrap      `json:"wrapS"`     // notspec default(REPEAT) : [https://www.khronos.org/registry/OpenGL-Refpages/gl4/html/glTexParameter.xhtm]
-	WrapT     *Wrap      `json:"wrapT"`     // notspec default(REPEAT) : [https://www.khronos.org/registry/OpenGL-Refpages/gl4/html/glTexParameter.xhtm]
+	MagFilter *MagFilter `json:"magFilter,omitempty"` // notspec default(MAG_LINEAR) : [https://www.khronos.org/registry/OpenGL-Refpages/gl4/html/glTexParameter.xhtm]
+	MinFilter *MinFilter `json:"minFilter,omitempty"` // notspec default(MIN_NEAREST_MIPMAP_LINEAR) : [https://www.khronos.org/registry/OpenGL-Refpages/gl4/html/glTexParameter.xhtm]
+	WrapS     *Wrap      `json:"wrapS,omitempty"`     // notspec default(REPEAT) : [https://www.khronos.org/registry/OpenGL-Refpages/gl4/html/glTexParameter.xhtm]
+	WrapT     *Wrap      `json:"wrapT,omitempty"`     // notspec default(REPEAT) : [https://www.khronos.org/registry/OpenGL-Refpages/gl4/html/glTexParameter.xhtm]
 
 	Name       *string         `json:"name,omitempty"`
 	Extensions *SpecExtensions `json:"extensions,omitempty"`
@@ -49,6 +49,7 @@ func (s *SpecSampler) Scheme() string {
 	return SCHEME_SAMPLER
 }
 func (s *SpecSampler) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
@@ -56,7 +57,7 @@ func (s *SpecSampler) Syntax(strictness Strictness, root Specifier, parent Speci
 		fallthrough
 	case LEVEL1:
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecSampler) To(ctx *parserContext) interface{} {
 	res := new(Sampler)
@@ -1,6 +1,9 @@
 package gltf2
 
-import "github.com/pkg/errors"
+import (
+	"fmt"
+	"github.com/pkg/errors"
+)
 
 // https://github.com/KhronosGroup/glTF/blob/master/specification/2.0/schema/scene.schema.json
 type Scene struct {
@@ -22,7 +25,7 @@ func (s *Scene) SetExtension(extensions *Extensions) {
 }
 
 type SpecScene struct {
-	Nodes      []SpecGLTFID    `json:"nodes"` // unique, minItem(1)
+	Nodes      []SpecGLTFID    `json:"nodes,omitempty"` // unique, minItem(1)
 	Name       *string         `json:"name,omitempty"`
 	Extensions *SpecExtensions `json:"extensions,omitempty"`
 	Extras     *Extras         `json:"extras,omitempty"`
@@ -35,6 +38,7 @@ func (s *SpecScene) Scheme() string {
 	return SCHEME_SCENE
 }
 func (s *SpecScene) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
@@ -42,10 +46,15 @@ func (s *SpecScene) Syntax(strictness Strictness, root Specifier, parent Specifi
 		fallthrough
 	case LEVEL1:
 		if is, id := isUniqueGLTFID(s.Nodes...); !is {
-			return errors.Errorf("Scene.Nodes unique '%d'", id)
+			issues.add(LEVEL1, CODE_DUPLICATE_ELEMENTS, "/nodes", "Scene.Nodes unique '%d'", id)
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			for i := range s.Nodes {
+				issues.reference(&s.Nodes[i], len(g.Nodes), fmt.Sprintf("/nodes/%d", i), "Scene.Nodes")
+			}
 		}
 	}
-	return nil
+	return issues.Err()
 
 }
 func (s *SpecScene) To(ctx *parserContext) interface{} {
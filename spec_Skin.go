@@ -1,6 +1,7 @@
 package gltf2
 
 import (
+	"fmt"
 	"github.com/pkg/errors"
 )
 
@@ -25,9 +26,9 @@ func (s *Skin) GetExtension() *Extensions {
 }
 
 type SpecSkin struct {
-	InverseBindMatrices *SpecGLTFID     `json:"inverseBindMatrices"` // When undefined, it is Ident4x4 matrix
-	Skeleton            *SpecGLTFID     `json:"skeleton"`            // When undefined, joints transforms resolve to scene root.
-	Joints              []SpecGLTFID    `json:"joints"`              // require(min = 1), unique
+	InverseBindMatrices *SpecGLTFID     `json:"inverseBindMatrices,omitempty"` // When undefined, it is Ident4x4 matrix
+	Skeleton            *SpecGLTFID     `json:"skeleton,omitempty"`            // When undefined, joints transforms resolve to scene root.
+	Joints              []SpecGLTFID    `json:"joints"`                        // require(min = 1), unique
 	Name                *string         `json:"name,omitempty"`
 	Extensions          *SpecExtensions `json:"extensions,omitempty"`
 	Extras              *Extras         `json:"extras,omitempty"`
@@ -63,6 +64,7 @@ func (s *SpecSkin) Scheme() string {
 }
 
 func (s *SpecSkin) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
@@ -70,15 +72,22 @@ func (s *SpecSkin) Syntax(strictness Strictness, root Specifier, parent Specifie
 		fallthrough
 	case LEVEL1:
 		if len(s.Joints) == 0 {
-			return errors.Errorf("Skin.Joints require(min = 1)")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/joints", "Skin.Joints require(min = 1)")
 		}
 		if s.Joints != nil {
 			if ok, id := isUniqueGLTFID(s.Joints...); !ok {
-				return errors.Errorf("Skin.Joints unique, but id %d overlap", id)
+				issues.add(LEVEL1, CODE_DUPLICATE_ELEMENTS, "/joints", "Skin.Joints unique, but id %d overlap", id)
+			}
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.InverseBindMatrices, len(g.Accessors), "/inverseBindMatrices", "Skin.InverseBindMatrices")
+			issues.reference(s.Skeleton, len(g.Nodes), "/skeleton", "Skin.Skeleton")
+			for i := range s.Joints {
+				issues.reference(&s.Joints[i], len(g.Nodes), fmt.Sprintf("/joints/%d", i), "Skin.Joints")
 			}
 		}
 	}
-	return nil
+	return issues.Err()
 }
 
 func (s *SpecSkin) To(ctx *parserContext) interface{} {
@@ -1,6 +1,9 @@
 package gltf2
 
-import "github.com/pkg/errors"
+import (
+	"github.com/pkg/errors"
+	"image"
+)
 
 type Texture struct {
 	Sampler    *Sampler    `json:"sampler"`
@@ -21,9 +24,49 @@ func (s *Texture) SetExtension(extensions *Extensions) {
 	s.Extensions = extensions
 }
 
+// textureSources are extensions giving alternate image of texture, first one is preferred
+var textureSources = []ExtensionType{
+	new(KHRTextureBasisu),
+	new(EXTTextureWebp),
+	new(MSFTTextureDDS),
+}
+
+type textureSource interface {
+	source() Image
+}
+
+// Images is alternate images of extensions, then Texture.Source
+func (s *Texture) Images() (res []Image) {
+	if s.Extensions != nil {
+		for _, v := range textureSources {
+			if ext, ok := s.Extensions.Get(v).(textureSource); ok && ext.source() != nil {
+				res = append(res, ext.source())
+			}
+		}
+	}
+	if s.Source != nil {
+		res = append(res, s.Source)
+	}
+	return res
+}
+
+// Load load first image which is loaded without error, alternate image is preferred over Texture.Source
+func (s *Texture) Load(useCache bool) (img *image.RGBA, err error) {
+	images := s.Images()
+	if len(images) == 0 {
+		return nil, errors.New("Texture has no image")
+	}
+	for _, v := range images {
+		if img, err = v.Load(useCache); err == nil {
+			return img, nil
+		}
+	}
+	return nil, err
+}
+
 type SpecTexture struct {
-	Sampler    *SpecGLTFID     `json:"sampler"`
-	Source     *SpecGLTFID     `json:"source"`
+	Sampler    *SpecGLTFID     `json:"sampler,omitempty"`
+	Source     *SpecGLTFID     `json:"source,omitempty"`
 	Name       *string         `json:"name,omitempty"`
 	Extensions *SpecExtensions `json:"extensions,omitempty"`
 	Extras     *Extras         `json:"extras,omitempty"`
@@ -36,14 +79,19 @@ func (s *SpecTexture) Scheme() string {
 	return SCHEME_TEXTURE
 }
 func (s *SpecTexture) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
 	case LEVEL2:
 		fallthrough
 	case LEVEL1:
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.Sampler, len(g.Samplers), "/sampler", "Texture.Sampler")
+			issues.reference(s.Source, len(g.Images), "/source", "Texture.Source")
+		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecTexture) To(ctx *parserContext) interface{} {
 	res := new(Texture)
@@ -64,12 +112,8 @@ func (s *SpecTexture) Link(Root *GLTF, parent interface{}, dst interface{}) erro
 		}
 		dst.(*Texture).Sampler = Root.Samplers[*s.Sampler]
 	}
-	if s.Source == nil {
-		// TODO : https://github.com/KhronosGroup/glTF/tree/master/specification/2.0#texture-data
-		// 		Unclear, what if Source is undefined?
-		//		> maybe default image for Texture source?
-		panic("Undefined works")
-	} else {
+	// undefined source is allowed, extension like KHR_texture_basisu can give image
+	if s.Source != nil {
 		if !inRange(*s.Source, len(Root.Images)) {
 			return errors.Errorf("Texture.Source linking fail")
 		}
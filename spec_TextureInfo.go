@@ -20,8 +20,8 @@ func (s *TextureInfo) SetExtension(extensions *Extensions) {
 }
 
 type SpecTextureInfo struct {
-	Index      *SpecGLTFID     `json:"index"`    // required
-	TexCoord   *IndexTexCoord  `json:"texCoord"` // default(0), minimum(0)
+	Index      *SpecGLTFID     `json:"index"`              // required
+	TexCoord   *IndexTexCoord  `json:"texCoord,omitempty"` // default(0), minimum(0)
 	Extensions *SpecExtensions `json:"extensions,omitempty"`
 	Extras     *Extras         `json:"extras,omitempty"`
 }
@@ -33,6 +33,7 @@ func (s *SpecTextureInfo) Scheme() string {
 	return SCHEME_TEXTURE_INFO
 }
 func (s *SpecTextureInfo) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
@@ -40,10 +41,13 @@ func (s *SpecTextureInfo) Syntax(strictness Strictness, root Specifier, parent S
 		fallthrough
 	case LEVEL1:
 		if s.Index == nil {
-			return errors.WithMessage(ErrorGLTFSpec, "TextureInfo.Index required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/index", "TextureInfo.Index required")
+		}
+		if g, ok := root.(*SpecGLTF); ok {
+			issues.reference(s.Index, len(g.Textures), "/index", "TextureInfo.Index")
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecTextureInfo) To(ctx *parserContext) interface{} {
 	res := new(TextureInfo)
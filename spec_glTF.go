@@ -1,6 +1,7 @@
 package gltf2
 
 import (
+	"fmt"
 	"github.com/pkg/errors"
 )
 
@@ -154,6 +155,15 @@ func (s *SpecGLTF) getSchemeIndex(i int) (scheme string, schemeIndex int) {
 	return
 }
 
+// usesExtension report name is in ExtensionsUsed
+func (s *SpecGLTF) usesExtension(name string) bool {
+	for _, v := range s.ExtensionsUsed {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
 func (s *SpecGLTF) SpecExtension() *SpecExtensions {
 	return s.Extensions
 }
@@ -161,28 +171,31 @@ func (s *SpecGLTF) Scheme() string {
 	return SCHEME_GLTF
 }
 func (s *SpecGLTF) Syntax(strictness Strictness, root Specifier, parent Specifier) error {
+	var issues Issues
 	switch strictness {
 	case LEVEL3:
 		fallthrough
 	case LEVEL2:
 		if ok, data := isUniqueExtension(s.ExtensionsUsed...); !ok {
-			return errors.Errorf("GLTF.ExtensionsUsed is unique, but duplicate item '%s'", data)
+			issues.add(LEVEL2, CODE_DUPLICATE_ELEMENTS, "/extensionsUsed", "GLTF.ExtensionsUsed is unique, but duplicate item '%s'", data)
 		}
 		if ok, data := isUniqueExtension(s.ExtensionsRequired...); !ok {
-			return errors.Errorf("GLTF.ExtensionsUsed is unique, but duplicate item '%s'", data)
+			issues.add(LEVEL2, CODE_DUPLICATE_ELEMENTS, "/extensionsRequired", "GLTF.ExtensionsRequired is unique, but duplicate item '%s'", data)
 		}
 		fallthrough
 	case LEVEL1:
 		if s.Scene != nil {
 			if s.Scenes == nil {
-				return errors.WithMessage(ErrorGLTFSpec, "GLTF.Scene dependency(scenes)")
+				issues.add(LEVEL1, CODE_UNSATISFIED_DEPENDENCY, "/scene", "GLTF.Scene dependency(scenes)")
+			} else {
+				issues.reference(s.Scene, len(s.Scenes), "/scene", "GLTF.Scene")
 			}
 		}
 		if s.Asset == nil {
-			return errors.Errorf("GLTF.Asset required")
+			issues.add(LEVEL1, CODE_UNDEFINED_PROPERTY, "/asset", "GLTF.Asset required")
 		}
 	}
-	return nil
+	return issues.Err()
 }
 func (s *SpecGLTF) To(ctx *parserContext) interface{} {
 	res := new(GLTF)
@@ -222,6 +235,9 @@ func (s *SpecGLTF) GetChild(i int) Specifier {
 	case SCHEME_ACCESSOR:
 		return &s.Accessors[schemeIndex]
 	case SCHEME_ASSET:
+		if s.Asset == nil {
+			return nil
+		}
 		return s.Asset
 	case SCHEME_BUFFER:
 		return &s.Buffers[schemeIndex]
@@ -283,6 +299,40 @@ func (s *SpecGLTF) SetChild(i int, dst, object interface{}) {
 		dst.(*GLTF).Skins[schemeIndex] = object.(*Skin)
 	}
 }
+func (s *SpecGLTF) ChildPointer(i int) string {
+	scheme, schemeIndex := s.getSchemeIndex(i)
+	switch scheme {
+	case SCHEME_ASSET:
+		return "/asset"
+	case SCHEME_ACCESSOR:
+		return fmt.Sprintf("/accessors/%d", schemeIndex)
+	case SCHEME_BUFFER:
+		return fmt.Sprintf("/buffers/%d", schemeIndex)
+	case SCHEME_BUFFERVIEW:
+		return fmt.Sprintf("/bufferViews/%d", schemeIndex)
+	case SCHEME_CAMERA:
+		return fmt.Sprintf("/cameras/%d", schemeIndex)
+	case SCHEME_IMAGE:
+		return fmt.Sprintf("/images/%d", schemeIndex)
+	case SCHEME_MATERIAL:
+		return fmt.Sprintf("/materials/%d", schemeIndex)
+	case SCHEME_MESH:
+		return fmt.Sprintf("/meshes/%d", schemeIndex)
+	case SCHEME_NODE:
+		return fmt.Sprintf("/nodes/%d", schemeIndex)
+	case SCHEME_SAMPLER:
+		return fmt.Sprintf("/samplers/%d", schemeIndex)
+	case SCHEME_SCENE:
+		return fmt.Sprintf("/scenes/%d", schemeIndex)
+	case SCHEME_TEXTURE:
+		return fmt.Sprintf("/textures/%d", schemeIndex)
+	case SCHEME_ANIMATION:
+		return fmt.Sprintf("/animations/%d", schemeIndex)
+	case SCHEME_SKIN:
+		return fmt.Sprintf("/skins/%d", schemeIndex)
+	}
+	return ""
+}
 func (s *SpecGLTF) LenChild() int {
 	if s.cache == nil {
 		s.buildSchemeIndexCache()
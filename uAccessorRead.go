@@ -0,0 +1,201 @@
+package gltf2
+
+import (
+	"encoding/binary"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/pkg/errors"
+	"math"
+)
+
+// ReadFloats read every component of accessor as float32, Count * Type.Count() values
+//
+// Any ComponentType is converted, and Accessor.Normalized integer follows glTF spec
+// ex) normalized BYTE c : max(c / 127.0, -1.0), normalized UNSIGNED_SHORT c : c / 65535.0
+// Matrix is column major like mgl32, column padding is removed
+func ReadFloats(accessor *Accessor) ([]float32, error) {
+	return readFloats(accessor, accessor.Normalized)
+}
+
+func ReadScalarf(accessor *Accessor) ([]float32, error) {
+	if err := readType("ReadScalarf", accessor, SCALAR); err != nil {
+		return nil, err
+	}
+	return ReadFloats(accessor)
+}
+func ReadVec2f(accessor *Accessor) ([]mgl32.Vec2, error) {
+	if err := readType("ReadVec2f", accessor, VEC2); err != nil {
+		return nil, err
+	}
+	fs, err := ReadFloats(accessor)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]mgl32.Vec2, accessor.Count)
+	for i := range res {
+		copy(res[i][:], fs[i*2:])
+	}
+	return res, nil
+}
+func ReadVec3f(accessor *Accessor) ([]mgl32.Vec3, error) {
+	if err := readType("ReadVec3f", accessor, VEC3); err != nil {
+		return nil, err
+	}
+	fs, err := ReadFloats(accessor)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]mgl32.Vec3, accessor.Count)
+	for i := range res {
+		copy(res[i][:], fs[i*3:])
+	}
+	return res, nil
+}
+func ReadVec4f(accessor *Accessor) ([]mgl32.Vec4, error) {
+	if err := readType("ReadVec4f", accessor, VEC4); err != nil {
+		return nil, err
+	}
+	fs, err := ReadFloats(accessor)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]mgl32.Vec4, accessor.Count)
+	for i := range res {
+		copy(res[i][:], fs[i*4:])
+	}
+	return res, nil
+}
+func ReadMat4f(accessor *Accessor) ([]mgl32.Mat4, error) {
+	if err := readType("ReadMat4f", accessor, MAT4); err != nil {
+		return nil, err
+	}
+	fs, err := ReadFloats(accessor)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]mgl32.Mat4, accessor.Count)
+	for i := range res {
+		copy(res[i][:], fs[i*16:])
+	}
+	return res, nil
+}
+
+// ReadIndices read SCALAR accessor of UNSIGNED_BYTE, UNSIGNED_SHORT, UNSIGNED_INT
+func ReadIndices(accessor *Accessor) ([]uint32, error) {
+	if err := readType("ReadIndices", accessor, SCALAR); err != nil {
+		return nil, err
+	}
+	res := make([]uint32, 0, accessor.Count)
+	switch accessor.ComponentType {
+	case UNSIGNED_BYTE:
+		err := accessor.ForEach(func(i int, elem []byte) error {
+			res = append(res, uint32(elem[0]))
+			return nil
+		})
+		return res, err
+	case UNSIGNED_SHORT:
+		err := accessor.ForEach(func(i int, elem []byte) error {
+			res = append(res, uint32(binary.LittleEndian.Uint16(elem)))
+			return nil
+		})
+		return res, err
+	case UNSIGNED_INT:
+		err := accessor.ForEach(func(i int, elem []byte) error {
+			res = append(res, binary.LittleEndian.Uint32(elem))
+			return nil
+		})
+		return res, err
+	}
+	return nil, errors.Errorf("ReadIndices need UNSIGNED_BYTE, UNSIGNED_SHORT, UNSIGNED_INT accessor, but got '%s'", accessor.ComponentType)
+}
+
+func readType(name string, accessor *Accessor, accessorType AccessorType) error {
+	if accessor.Type != accessorType {
+		return errors.Errorf("%s need %s accessor, but got '%s'", name, accessorType, accessor.Type)
+	}
+	return nil
+}
+
+// readFloats without normalize is raw value of component, it is used for Accessor.Min, Accessor.Max
+func readFloats(accessor *Accessor, normalize bool) ([]float32, error) {
+	size := accessor.ComponentType.Size()
+	if size == 0 {
+		return nil, errors.Errorf("Accessor.ComponentType '%d' not supported", accessor.ComponentType)
+	}
+	columns, rows, columnSize := accessor.layout()
+	res := make([]float32, 0, accessor.Count*columns*rows)
+	err := accessor.ForEach(func(i int, elem []byte) error {
+		for c := 0; c < columns; c++ {
+			for r := 0; r < rows; r++ {
+				res = append(res, readComponent(accessor.ComponentType, elem[c*columnSize+r*size:], normalize))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// accessorMinMax is per component bounds of elements for Accessor.Min, Accessor.Max
+func accessorMinMax(accessor *Accessor) (min, max []float32, err error) {
+	components, err := readFloats(accessor, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	n := accessor.Type.Count()
+	min, max = make([]float32, n), make([]float32, n)
+	for i := range min {
+		min[i], max[i] = math.MaxFloat32, -math.MaxFloat32
+	}
+	for i, v := range components {
+		if v < min[i%n] {
+			min[i%n] = v
+		}
+		if v > max[i%n] {
+			max[i%n] = v
+		}
+	}
+	return min, max, nil
+}
+
+func readComponent(componentType ComponentType, bts []byte, normalize bool) float32 {
+	var v float64
+	switch componentType {
+	case BYTE:
+		v = float64(int8(bts[0]))
+	case UNSIGNED_BYTE:
+		v = float64(bts[0])
+	case SHORT:
+		v = float64(int16(binary.LittleEndian.Uint16(bts)))
+	case UNSIGNED_SHORT:
+		v = float64(binary.LittleEndian.Uint16(bts))
+	case UNSIGNED_INT:
+		v = float64(binary.LittleEndian.Uint32(bts))
+	case FLOAT:
+		return math.Float32frombits(binary.LittleEndian.Uint32(bts))
+	default:
+		return 0
+	}
+	if normalize {
+		return normalizeComponent(componentType, v)
+	}
+	return float32(v)
+}
+
+// normalizeComponent is normalized float of raw integer component, ex) Accessor.Min of normalized accessor
+func normalizeComponent(componentType ComponentType, v float64) float32 {
+	switch componentType {
+	case BYTE:
+		return float32(math.Max(v/127., -1))
+	case UNSIGNED_BYTE:
+		return float32(v / 255.)
+	case SHORT:
+		return float32(math.Max(v/32767., -1))
+	case UNSIGNED_SHORT:
+		return float32(v / 65535.)
+	case UNSIGNED_INT:
+		return float32(v / math.MaxUint32)
+	}
+	return float32(v)
+}
@@ -0,0 +1,90 @@
+package gltf2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// accessorReadTestJSON is accessors of normalized BYTE VEC2 in stride 4, UNSIGNED_BYTE MAT2, UNSIGNED_SHORT indices and sparse UNSIGNED_BYTE SCALAR
+func accessorReadTestJSON() string {
+	var b bytes.Buffer
+	b.Write([]byte{127, 0x80, 9, 9, 0, 64, 9, 9})
+	b.Write([]byte{1, 2, 0, 0, 3, 4, 0, 0})
+	binary.Write(&b, binary.LittleEndian, []uint16{1, 258})
+	b.Write([]byte{1, 0, 255, 0})
+	return accessorTestJSON(b.Bytes(),
+		`{"buffer":0,"byteLength":8,"byteStride":4},{"buffer":0,"byteOffset":8,"byteLength":8},{"buffer":0,"byteOffset":16,"byteLength":4},
+		{"buffer":0,"byteOffset":20,"byteLength":1},{"buffer":0,"byteOffset":22,"byteLength":1}`,
+		`{"bufferView":0,"componentType":5120,"normalized":true,"count":2,"type":"VEC2","min":[0,-128],"max":[127,64]},
+		{"bufferView":1,"componentType":5121,"count":1,"type":"MAT2"},
+		{"bufferView":2,"componentType":5123,"count":2,"type":"SCALAR"},
+		{"componentType":5121,"normalized":true,"count":3,"type":"SCALAR","sparse":{"count":1,"indices":{"bufferView":3,"componentType":5121},"values":{"bufferView":4}}}`,
+	)
+}
+
+func TestReadAccessor(t *testing.T) {
+	g, err := Parser().Reader(strings.NewReader(accessorReadTestJSON())).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	check := func(g *GLTF) {
+		v, err := ReadVec2f(g.Accessors[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		// normalized BYTE is max(c / 127, -1)
+		if len(v) != 2 || v[0] != (mgl32.Vec2{1, -1}) || v[1] != (mgl32.Vec2{0, 64. / 127}) {
+			t.Errorf("ReadVec2f %v", v)
+		}
+		if _, err := ReadVec3f(g.Accessors[0]); err == nil {
+			t.Error("ReadVec3f of VEC2 must fail")
+		}
+		// column padding is removed
+		m, err := ReadFloats(g.Accessors[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(m) != 4 || m[0] != 1 || m[1] != 2 || m[2] != 3 || m[3] != 4 {
+			t.Errorf("ReadFloats of MAT2 %v", m)
+		}
+		is, err := ReadIndices(g.Accessors[2])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(is) != 2 || is[0] != 1 || is[1] != 258 {
+			t.Errorf("ReadIndices %v", is)
+		}
+		if _, err := ReadIndices(g.Accessors[0]); err == nil {
+			t.Error("ReadIndices of VEC2 must fail")
+		}
+		s, err := ReadScalarf(g.Accessors[3])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(s) != 3 || s[0] != 0 || s[1] != 1 || s[2] != 0 {
+			t.Errorf("ReadScalarf of sparse %v", s)
+		}
+	}
+	check(g)
+	check(accessorTestRoundTrip(t, g))
+}
+
+func TestReadAccessorMinMax(t *testing.T) {
+	js := strings.Replace(accessorReadTestJSON(), `,"min":[0,-128],"max":[127,64]`, ``, 1)
+	g, err := Parser().Reader(strings.NewReader(js)).Tasks(Tasks.AccessorMinMax).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// missing min and max are filled, not normalized
+	if a := g.Accessors[0]; len(a.Min) != 2 || a.Min[0] != 0 || a.Min[1] != -128 || a.Max[0] != 127 || a.Max[1] != 64 {
+		t.Errorf("min %v max %v, expected [0 -128] [127 64]", a.Min, a.Max)
+	}
+	g2 := accessorTestRoundTrip(t, g)
+	if a := g2.Accessors[0]; len(a.Max) != 2 || a.Max[0] != 127 || a.Max[1] != 64 {
+		t.Errorf("encoded max %v, expected [127 64]", a.Max)
+	}
+}
@@ -0,0 +1,565 @@
+package gltf2
+
+import (
+	"encoding/binary"
+	"github.com/pkg/errors"
+	"image"
+)
+
+// BasisLZ ETC1S transcoder, https://registry.khronos.org/KTX/specs/2.0/ktxspec.v2.html#basislz_gd
+//
+// It follows basis_universal transcoder, ETC1S block is decoded to RGBA directly
+const (
+	basisLZHeaderLength    = 20
+	basisLZImageDescLength = 20
+	// ETC1S_P_FRAME, video frame is not supported
+	basisLZFlagPFrame = 0x02
+	// endpoint palette, delta model is selected by previous color
+	basisColor5Pal0PrevHi = 9
+	basisColor5Pal1PrevHi = 21
+	// endpoint prediction
+	basisEndpointPredRepeatLastSymbol = 256
+	basisEndpointPredCountVLCBits     = 4
+	basisEndpointPredMinRepeatCount   = 3
+	// selector history buffer
+	basisSelectorHistoryRLECountThresh = 3
+	basisSelectorHistoryRLECountTotal  = 64
+	// huffman table
+	basisHuffmanMaxCodeSize  = 16
+	basisHuffmanMaxSymsLog2  = 14
+	basisHuffmanCodeLengths  = 21
+	basisHuffmanSmallZeroRun = 17
+	basisHuffmanBigZeroRun   = 18
+	basisHuffmanSmallRepeat  = 19
+	basisHuffmanBigRepeat    = 20
+)
+
+// code length codes are stored in this order
+var basisHuffmanCodeLengthOrder = [basisHuffmanCodeLengths]int{17, 18, 19, 20, 0, 8, 7, 9, 6, 10, 5, 11, 4, 12, 3, 13, 2, 14, 1, 15, 16}
+
+// ETC1 intensity modifier table
+var etc1IntenTables = [8][4]int{
+	{-8, -2, 2, 8}, {-17, -5, 5, 17}, {-29, -9, 9, 29}, {-42, -13, 13, 42},
+	{-60, -18, 18, 60}, {-80, -24, 24, 80}, {-106, -33, 33, 106}, {-183, -47, 47, 183},
+}
+
+type basisLZImageDesc struct {
+	flags       uint32
+	rgbOffset   uint32
+	rgbLength   uint32
+	alphaOffset uint32
+	alphaLength uint32
+}
+
+type basisEndpoint struct {
+	color5 [3]uint8
+	inten  uint8
+}
+
+// basisSelector is 2 bits selector of each texel, row major
+type basisSelector [4]uint8
+
+func (s basisSelector) at(x, y int) uint8 {
+	return (s[y] >> uint(x*2)) & 3
+}
+
+// basisLZGlobal is decoded supercompression global data
+type basisLZGlobal struct {
+	images    []basisLZImageDesc
+	endpoints []basisEndpoint
+	selectors []basisSelector
+	//
+	endpointPredModel     *basisHuffman
+	deltaEndpointModel    *basisHuffman
+	selectorModel         *basisHuffman
+	selectorHistoryRLE    *basisHuffman
+	selectorHistoryLength int
+}
+
+func readBasisLZGlobal(data []byte, images int) (*basisLZGlobal, error) {
+	if len(data) < basisLZHeaderLength {
+		return nil, errors.New("BasisLZ global data too short")
+	}
+	var (
+		endpointCount   = int(binary.LittleEndian.Uint16(data[0:]))
+		selectorCount   = int(binary.LittleEndian.Uint16(data[2:]))
+		endpointsLength = int(binary.LittleEndian.Uint32(data[4:]))
+		selectorsLength = int(binary.LittleEndian.Uint32(data[8:]))
+		tablesLength    = int(binary.LittleEndian.Uint32(data[12:]))
+		offset          = basisLZHeaderLength + images*basisLZImageDescLength
+	)
+	if offset+endpointsLength+selectorsLength+tablesLength > len(data) {
+		return nil, errors.New("BasisLZ global data too short")
+	}
+	res := &basisLZGlobal{images: make([]basisLZImageDesc, images)}
+	for i := range res.images {
+		desc := data[basisLZHeaderLength+i*basisLZImageDescLength:]
+		res.images[i] = basisLZImageDesc{
+			flags:       binary.LittleEndian.Uint32(desc[0:]),
+			rgbOffset:   binary.LittleEndian.Uint32(desc[4:]),
+			rgbLength:   binary.LittleEndian.Uint32(desc[8:]),
+			alphaOffset: binary.LittleEndian.Uint32(desc[12:]),
+			alphaLength: binary.LittleEndian.Uint32(desc[16:]),
+		}
+	}
+	var err error
+	if res.endpoints, err = readBasisEndpoints(&basisBits{data: data[offset : offset+endpointsLength]}, endpointCount); err != nil {
+		return nil, errors.WithMessage(err, "BasisLZ endpoints")
+	}
+	offset += endpointsLength
+	if res.selectors, err = readBasisSelectors(&basisBits{data: data[offset : offset+selectorsLength]}, selectorCount); err != nil {
+		return nil, errors.WithMessage(err, "BasisLZ selectors")
+	}
+	offset += selectorsLength
+	tables := &basisBits{data: data[offset : offset+tablesLength]}
+	for _, model := range []**basisHuffman{&res.endpointPredModel, &res.deltaEndpointModel, &res.selectorModel, &res.selectorHistoryRLE} {
+		if *model, err = tables.huffmanTable(); err != nil {
+			return nil, errors.WithMessage(err, "BasisLZ tables")
+		}
+	}
+	res.selectorHistoryLength = int(tables.bits(13))
+	return res, nil
+}
+
+// readBasisEndpoints decode endpoint codebook, each endpoint is delta of previous one
+func readBasisEndpoints(bits *basisBits, count int) ([]basisEndpoint, error) {
+	var models [4]*basisHuffman
+	for i := range models {
+		var err error
+		if models[i], err = bits.huffmanTable(); err != nil {
+			return nil, err
+		}
+	}
+	var (
+		grayscale = bits.bits(1) == 1
+		channels  = 3
+		prevColor = [3]int{16, 16, 16}
+		prevInten = 0
+		res       = make([]basisEndpoint, count)
+	)
+	if grayscale {
+		channels = 1
+	}
+	for i := range res {
+		delta, err := bits.huffman(models[3])
+		if err != nil {
+			return nil, err
+		}
+		prevInten = (prevInten + delta) & 7
+		res[i].inten = uint8(prevInten)
+		for c := 0; c < channels; c++ {
+			model := models[2]
+			switch {
+			case prevColor[c] <= basisColor5Pal0PrevHi:
+				model = models[0]
+			case prevColor[c] <= basisColor5Pal1PrevHi:
+				model = models[1]
+			}
+			if delta, err = bits.huffman(model); err != nil {
+				return nil, err
+			}
+			prevColor[c] = (prevColor[c] + delta) & 31
+			res[i].color5[c] = uint8(prevColor[c])
+		}
+		if grayscale {
+			res[i].color5[1], res[i].color5[2] = res[i].color5[0], res[i].color5[0]
+		}
+	}
+	return res, nil
+}
+
+// errBasisGlobalSelectors is global, hybrid selector codebook, the codebook is removed from basis_universal
+var errBasisGlobalSelectors = errors.New("global selector codebook not supported")
+
+// readBasisSelectors decode selector codebook, raw or XOR delta of previous one
+//
+// Global, hybrid codebook refer removed basis_universal codebook, so they can't be decoded
+func readBasisSelectors(bits *basisBits, count int) ([]basisSelector, error) {
+	if bits.bits(1) == 1 {
+		return nil, errBasisGlobalSelectors
+	}
+	if bits.bits(1) == 1 {
+		return nil, errors.WithMessage(errBasisGlobalSelectors, "hybrid")
+	}
+	res := make([]basisSelector, count)
+	if bits.bits(1) == 1 {
+		// raw
+		for i := range res {
+			for j := range res[i] {
+				res[i][j] = uint8(bits.bits(8))
+			}
+		}
+		return res, nil
+	}
+	model, err := bits.huffmanTable()
+	if err != nil {
+		return nil, err
+	}
+	var prev basisSelector
+	for i := range res {
+		for j := range res[i] {
+			if i == 0 {
+				res[i][j] = uint8(bits.bits(8))
+			} else {
+				sym, err := bits.huffman(model)
+				if err != nil {
+					return nil, err
+				}
+				res[i][j] = uint8(sym) ^ prev[j]
+			}
+		}
+		prev = res[i]
+	}
+	return res, nil
+}
+
+// decode ETC1S image of level data, alpha slice become alpha channel
+func (s *basisLZGlobal) decode(level []byte, index, width, height int, alpha bool) (*image.RGBA, error) {
+	if index >= len(s.images) {
+		return nil, errors.Errorf("BasisLZ image %d not found", index)
+	}
+	desc := s.images[index]
+	if desc.flags&basisLZFlagPFrame != 0 {
+		return nil, errors.New("BasisLZ P-frame not supported")
+	}
+	slice := func(offset, length uint32) ([]byte, error) {
+		if uint64(offset)+uint64(length) > uint64(len(level)) {
+			return nil, errors.Errorf("BasisLZ image %d slice out of level", index)
+		}
+		return level[offset : offset+length], nil
+	}
+	res := image.NewRGBA(image.Rect(0, 0, width, height))
+	rgb, err := slice(desc.rgbOffset, desc.rgbLength)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.slice(rgb, res, false); err != nil {
+		return nil, errors.WithMessage(err, "BasisLZ RGB slice")
+	}
+	if !alpha {
+		return res, nil
+	}
+	a, err := slice(desc.alphaOffset, desc.alphaLength)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.slice(a, res, true); err != nil {
+		return nil, errors.WithMessage(err, "BasisLZ alpha slice")
+	}
+	return res, nil
+}
+
+// slice decode ETC1S slice to dst, alpha slice write green channel of block to alpha channel
+func (s *basisLZGlobal) slice(data []byte, dst *image.RGBA, alpha bool) error {
+	type pred struct {
+		endpoint int
+		bits     int
+	}
+	var (
+		width, height = dst.Rect.Dx(), dst.Rect.Dy()
+		blocksX       = (width + 3) / 4
+		blocksY       = (height + 3) / 4
+		bits          = &basisBits{data: data}
+		preds         = [2][]pred{make([]pred, blocksX), make([]pred, blocksX)}
+		history       = newBasisMoveToFront(s.selectorHistoryLength)
+		rleSymbol     = len(s.selectors) + s.selectorHistoryLength
+		//
+		curPredBits, prevPredSym, predRepeat, prevEndpoint, selectorRLE int
+	)
+	for by := 0; by < blocksY; by++ {
+		cur := by & 1
+		for bx := 0; bx < blocksX; bx++ {
+			// 2x2 blocks share 8 bits of endpoint prediction, lower row use upper 4 bits
+			switch {
+			case bx&1 == 0 && by&1 == 0:
+				if predRepeat > 0 {
+					predRepeat--
+					curPredBits = prevPredSym
+				} else {
+					sym, err := bits.huffman(s.endpointPredModel)
+					if err != nil {
+						return err
+					}
+					if sym == basisEndpointPredRepeatLastSymbol {
+						predRepeat = bits.vlc(basisEndpointPredCountVLCBits) + basisEndpointPredMinRepeatCount - 1
+						curPredBits = prevPredSym
+					} else {
+						curPredBits = sym
+						prevPredSym = sym
+					}
+				}
+				preds[cur^1][bx].bits = curPredBits >> 4
+			case bx&1 == 0:
+				curPredBits = preds[cur][bx].bits
+			}
+			var endpoint int
+			switch curPredBits & 3 {
+			case 0:
+				// left
+				if bx == 0 {
+					return errors.New("endpoint left prediction at first column")
+				}
+				endpoint = prevEndpoint
+			case 1:
+				// upper
+				if by == 0 {
+					return errors.New("endpoint upper prediction at first row")
+				}
+				endpoint = preds[cur^1][bx].endpoint
+			case 2:
+				// upper left
+				if bx == 0 || by == 0 {
+					return errors.New("endpoint upper left prediction at first row, column")
+				}
+				endpoint = preds[cur^1][bx-1].endpoint
+			default:
+				delta, err := bits.huffman(s.deltaEndpointModel)
+				if err != nil {
+					return err
+				}
+				if endpoint = delta + prevEndpoint; endpoint >= len(s.endpoints) {
+					endpoint -= len(s.endpoints)
+				}
+			}
+			curPredBits >>= 2
+			if endpoint >= len(s.endpoints) {
+				return errors.Errorf("endpoint %d out of codebook", endpoint)
+			}
+			preds[cur][bx].endpoint = endpoint
+			prevEndpoint = endpoint
+			// selector is codebook index, history index or run of history index 0
+			var sym int
+			if selectorRLE > 0 {
+				selectorRLE--
+				sym = len(s.selectors)
+			} else {
+				var err error
+				if sym, err = bits.huffman(s.selectorModel); err != nil {
+					return err
+				}
+				if sym == rleSymbol {
+					run, err := bits.huffman(s.selectorHistoryRLE)
+					if err != nil {
+						return err
+					}
+					if run == basisSelectorHistoryRLECountTotal-1 {
+						selectorRLE = bits.vlc(7) + basisSelectorHistoryRLECountThresh
+					} else {
+						selectorRLE = run + basisSelectorHistoryRLECountThresh
+					}
+					if selectorRLE > blocksX*blocksY {
+						return errors.New("selector run out of slice")
+					}
+					sym = len(s.selectors)
+					selectorRLE--
+				}
+			}
+			var selector int
+			if sym >= len(s.selectors) {
+				i := sym - len(s.selectors)
+				if i >= len(history.values) {
+					return errors.Errorf("selector history %d out of buffer", i)
+				}
+				selector = history.values[i]
+				history.use(i)
+			} else {
+				selector = sym
+				history.add(selector)
+			}
+			s.block(dst, bx*4, by*4, s.endpoints[endpoint], s.selectors[selector], alpha)
+		}
+	}
+	return nil
+}
+
+// block write ETC1S block colors to dst
+func (s *basisLZGlobal) block(dst *image.RGBA, x, y int, endpoint basisEndpoint, selector basisSelector, alpha bool) {
+	var colors [4][3]uint8
+	for i := range colors {
+		for c := range colors[i] {
+			base := int(endpoint.color5[c])<<3 | int(endpoint.color5[c])>>2
+			v := base + etc1IntenTables[endpoint.inten][i]
+			switch {
+			case v < 0:
+				v = 0
+			case v > 255:
+				v = 255
+			}
+			colors[i][c] = uint8(v)
+		}
+	}
+	for j := 0; j < 4 && y+j < dst.Rect.Dy(); j++ {
+		for i := 0; i < 4 && x+i < dst.Rect.Dx(); i++ {
+			pix := dst.Pix[dst.PixOffset(x+i, y+j):]
+			color := colors[selector.at(i, j)]
+			if alpha {
+				pix[3] = color[1]
+			} else {
+				pix[0], pix[1], pix[2], pix[3] = color[0], color[1], color[2], 0xFF
+			}
+		}
+	}
+}
+
+// basisMoveToFront is approximate move to front buffer of selector history
+type basisMoveToFront struct {
+	values []int
+	rover  int
+}
+
+func newBasisMoveToFront(size int) *basisMoveToFront {
+	return &basisMoveToFront{values: make([]int, size), rover: size / 2}
+}
+func (s *basisMoveToFront) add(v int) {
+	if len(s.values) == 0 {
+		return
+	}
+	s.values[s.rover] = v
+	if s.rover++; s.rover == len(s.values) {
+		s.rover = len(s.values) / 2
+	}
+}
+func (s *basisMoveToFront) use(i int) {
+	if i > 0 {
+		s.values[i/2], s.values[i] = s.values[i], s.values[i/2]
+	}
+}
+
+// basisBits is LSB first bit reader, reading over the end gives 0
+type basisBits struct {
+	data []byte
+	buf  uint64
+	n    uint
+}
+
+func (s *basisBits) bits(n uint) int {
+	for s.n < n {
+		var c byte
+		if len(s.data) > 0 {
+			c, s.data = s.data[0], s.data[1:]
+		}
+		s.buf |= uint64(c) << s.n
+		s.n += 8
+	}
+	res := int(s.buf & (1<<n - 1))
+	s.buf >>= n
+	s.n -= n
+	return res
+}
+
+// vlc is variable length integer of chunk bits, each chunk has one more bit for continuation
+func (s *basisBits) vlc(chunk uint) int {
+	var res int
+	for shift := uint(0); shift < 32; shift += chunk {
+		v := s.bits(chunk + 1)
+		res |= (v & (1<<chunk - 1)) << shift
+		if v&(1<<chunk) == 0 {
+			break
+		}
+	}
+	return res
+}
+
+// basisHuffman is canonical huffman table, codes are stored bit reversed
+type basisHuffman struct {
+	counts  [basisHuffmanMaxCodeSize + 1]int
+	symbols []int
+}
+
+func newBasisHuffman(sizes []int) (*basisHuffman, error) {
+	res := new(basisHuffman)
+	for _, v := range sizes {
+		if v > basisHuffmanMaxCodeSize {
+			return nil, errors.Errorf("huffman code size %d too long", v)
+		}
+		res.counts[v]++
+	}
+	res.counts[0] = 0
+	left := 1
+	for i := 1; i <= basisHuffmanMaxCodeSize; i++ {
+		if left = left<<1 - res.counts[i]; left < 0 {
+			return nil, errors.New("huffman code over subscribed")
+		}
+	}
+	for size := 1; size <= basisHuffmanMaxCodeSize; size++ {
+		for sym, v := range sizes {
+			if v == size {
+				res.symbols = append(res.symbols, sym)
+			}
+		}
+	}
+	if len(res.symbols) == 0 {
+		return nil, errors.New("huffman table is empty")
+	}
+	return res, nil
+}
+
+// huffmanTable read code sizes, they are compressed by code length codes
+func (s *basisBits) huffmanTable() (*basisHuffman, error) {
+	total := s.bits(basisHuffmanMaxSymsLog2)
+	if total == 0 {
+		// table without symbol, decoding with it fail
+		return new(basisHuffman), nil
+	}
+	count := s.bits(5)
+	if count < 1 || count > basisHuffmanCodeLengths {
+		return nil, errors.Errorf("huffman code length code count %d invalid", count)
+	}
+	lengthSizes := make([]int, basisHuffmanCodeLengths)
+	for i := 0; i < count; i++ {
+		lengthSizes[basisHuffmanCodeLengthOrder[i]] = s.bits(3)
+	}
+	lengths, err := newBasisHuffman(lengthSizes)
+	if err != nil {
+		return nil, err
+	}
+	sizes := make([]int, total)
+	for cur := 0; cur < total; {
+		c, err := s.huffman(lengths)
+		if err != nil {
+			return nil, err
+		}
+		var run, size int
+		switch c {
+		case basisHuffmanSmallZeroRun:
+			run = s.bits(3) + 3
+		case basisHuffmanBigZeroRun:
+			run = s.bits(7) + 11
+		case basisHuffmanSmallRepeat, basisHuffmanBigRepeat:
+			if cur == 0 {
+				return nil, errors.New("huffman code size repeat at first")
+			}
+			if size = sizes[cur-1]; c == basisHuffmanSmallRepeat {
+				run = s.bits(2) + 3
+			} else {
+				run = s.bits(6) + 7
+			}
+		default:
+			run, size = 1, c
+		}
+		if cur+run > total {
+			return nil, errors.New("huffman code size run out of table")
+		}
+		for i := 0; i < run; i++ {
+			sizes[cur] = size
+			cur++
+		}
+	}
+	return newBasisHuffman(sizes)
+}
+
+// huffman decode one symbol, first bit is MSB of canonical code
+func (s *basisBits) huffman(table *basisHuffman) (int, error) {
+	var code, first, index int
+	for size := 1; size <= basisHuffmanMaxCodeSize; size++ {
+		code |= s.bits(1)
+		count := table.counts[size]
+		if code-first < count {
+			return table.symbols[index+code-first], nil
+		}
+		index += count
+		first = (first + count) << 1
+		code <<= 1
+	}
+	return 0, errors.New("invalid huffman code")
+}
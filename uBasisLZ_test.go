@@ -0,0 +1,164 @@
+package gltf2
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"strings"
+	"testing"
+)
+
+// table write huffman table of 1<<size symbols, every code size is size
+func (s *ktx2TestBits) table(size int) {
+	s.put(1<<uint(size), basisHuffmanMaxSymsLog2)
+	order := 0
+	for i, v := range basisHuffmanCodeLengthOrder {
+		if v == size {
+			order = i
+		}
+	}
+	s.put(order+1, 5)
+	for i := 0; i < order; i++ {
+		s.put(0, 3)
+	}
+	s.put(1, 3)
+	// code of code size is 0 bit
+	for i := 0; i < 1<<uint(size); i++ {
+		s.code(0, 1)
+	}
+}
+
+// basisTestETC1S is 7x3 ETC1S KTX2 of 2 blocks, left block is endpoint 0 and right block is endpoint 1
+//
+// selectors is first 3 bits of selector codebook, global, hybrid, raw
+func basisTestETC1S(selectors ...int) []byte {
+	var endpoints, codebook, tables, slice ktx2TestBits
+	for _, v := range []int{5, 5, 5, 3} {
+		endpoints.table(v)
+	}
+	endpoints.put(0, 1) // not grayscale
+	// endpoint 0 is inten 3, color (31, 0, 16), endpoint 1 is inten 0, color (0, 31, 16), delta of previous one
+	endpoints.code(3, 3)
+	endpoints.code(15, 5)
+	endpoints.code(16, 5)
+	endpoints.code(0, 5)
+	endpoints.code(5, 3)
+	endpoints.code(1, 5)
+	endpoints.code(31, 5)
+	endpoints.code(0, 5)
+	for _, v := range selectors {
+		codebook.put(v, 1)
+	}
+	// selector 0 is all 0, selector 1 is 0, 1, 2, 3 of each row
+	for _, v := range []int{0, 0, 0, 0, 0xE4, 0xE4, 0xE4, 0xE4} {
+		codebook.put(v, 8)
+	}
+	for _, v := range []int{9, 1, 2, 6} {
+		tables.table(v)
+	}
+	tables.put(0, 13)
+	// endpoint prediction bits of 2x2 blocks, left block is delta 0, right block is left
+	slice.code(15, 9)
+	slice.code(0, 1)
+	slice.code(0, 2)
+	slice.code(1, 1)
+	slice.code(1, 2)
+	var (
+		data = slice.bytes()
+		eb   = endpoints.bytes()
+		sb   = codebook.bytes()
+		tb   = tables.bytes()
+		sgd  = meshoptTestLE(
+			[]uint16{2, 2}, []uint32{uint32(len(eb)), uint32(len(sb)), uint32(len(tb)), 0},
+			// image description, RGB slice and alpha slice use same data
+			[]uint32{0, 0, uint32(len(data)), uint32(len(data)), uint32(len(data))},
+			eb, sb, tb,
+		)
+	)
+	return ktx2TestFile(0, KTX2SupercompressionBasisLZ, KTX2ModelETC1S, [][2]byte{{63, 0}, {63, 15}}, 7, 3, append(data, data...), 0, sgd)
+}
+
+func TestKTX2ETC1S(t *testing.T) {
+	file := basisTestETC1S(0, 0, 1)
+	ktx2, err := DecodeKTX2(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ktx2.DataFormat.Samples) != 2 || ktx2.DataFormat.Samples[1].Channel() != 15 {
+		t.Errorf("samples %v", ktx2.DataFormat.Samples)
+	}
+	if issues := ktx2.Validate(); len(issues) != 0 {
+		t.Errorf("issues %v", issues)
+	}
+	if _, err := ktx2.Level(0); err == nil {
+		t.Error("BasisLZ level must be transcoded")
+	}
+	img, format, err := image.Decode(bytes.NewReader(file))
+	if err != nil || format != "ktx2" {
+		t.Fatal(err, format)
+	}
+	rgba := img.(*image.RGBA)
+	if rgba.Rect.Dx() != 7 || rgba.Rect.Dy() != 3 {
+		t.Fatalf("size %v", rgba.Rect)
+	}
+	// alpha is green of alpha slice
+	ktx2TestPixels(t, rgba, map[[2]int][4]uint8{
+		{0, 0}: {213, 0, 90, 0}, {3, 2}: {213, 0, 90, 0},
+		{4, 0}: {0, 247, 124, 247}, {5, 1}: {0, 253, 130, 253}, {6, 2}: {2, 255, 134, 255},
+	})
+	// truncated data must fail, not panic
+	for i := range file {
+		if k, err := DecodeKTX2(file[:i]); err == nil {
+			k.RGBA(0, 0, 0)
+		}
+	}
+}
+
+// TestKTX2ETC1SGlobalSelectors report global, hybrid selector codebook as issue, it can't be decoded
+func TestKTX2ETC1SGlobalSelectors(t *testing.T) {
+	for name, selectors := range map[string][]int{"global": {1}, "hybrid": {0, 1}} {
+		ktx2, err := DecodeKTX2(basisTestETC1S(selectors...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		issues := ktx2.Validate()
+		if len(issues) != 1 || issues[0].Code != CODE_VALUE_NOT_IN_LIST || issues[0].Pointer != "/supercompressionGlobalData" || issues[0].Scheme != SCHEME_IMAGE {
+			t.Errorf("%s issues %v", name, issues)
+		}
+		if _, err := ktx2.RGBA(0, 0, 0); err == nil {
+			t.Errorf("%s selector codebook must fail", name)
+		}
+	}
+}
+
+func TestKHRTextureBasisu(t *testing.T) {
+	js := `{"asset":{"version":"2.0"},"extensionsUsed":["KHR_texture_basisu"],"extensionsRequired":["KHR_texture_basisu"],
+	"images":[{"uri":"data:image/ktx2;base64,` + base64.StdEncoding.EncodeToString(basisTestETC1S(0, 0, 1)) + `"}],
+	"textures":[{"extensions":{"KHR_texture_basisu":{"source":0}}}]}`
+	g, err := Parser().Extensions(new(KHRTextureBasisu)).Strictness(LEVEL3).Reader(strings.NewReader(js)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ext := g.Textures[0].Extensions.Get(new(KHRTextureBasisu)).(*KHRTextureBasisu)
+	if img, err := ext.Source.Load(false); err != nil || img.Rect.Dx() != 7 {
+		t.Fatalf("load %v", err)
+	}
+	if ktx2, err := ext.KTX2(); err != nil || ktx2.PixelWidth != 7 {
+		t.Fatalf("KTX2 %v", err)
+	}
+	var out bytes.Buffer
+	if err := Encoder(&out).Encode(g); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `"KHR_texture_basisu":{"source":0}`) {
+		t.Errorf("extension is not written, %s", out.String())
+	}
+	bad := strings.Replace(js, `"uri":"data:image/ktx2;base64,`, `"mimeType":"image/png","uri":"data:image/ktx2;base64,`, 1)
+	issues, err := Parser().Extensions(new(KHRTextureBasisu)).Reader(strings.NewReader(bad)).Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Pointer != "/textures/0/extensions/KHR_texture_basisu/source" {
+		t.Errorf("issues %v", issues)
+	}
+}
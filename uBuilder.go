@@ -0,0 +1,213 @@
+package gltf2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/pkg/errors"
+	"reflect"
+)
+
+// builder create Accessor, BufferView from go slice, and append data to Buffer
+//
+// Options(Target, Normalized, Name, Type) are applied to next Accessor only, then reset.
+//
+// ex) Builder(gltf, nil).Name("position").Attribute(primitive, POSITION, []mgl32.Vec3{...})
+type builder struct {
+	gltf   *GLTF
+	buffer *Buffer
+	//
+	target       BufferType
+	normalized   bool
+	name         string
+	accessorType AccessorType
+	hasType      bool
+}
+
+// Builder append data to buffer, nil buffer is new Buffer of gltf
+//
+// If buffer is not in GLTF.Buffers, it is added
+func Builder(gltf *GLTF, buffer *Buffer) *builder {
+	if buffer == nil {
+		buffer = new(Buffer)
+	}
+	var found bool
+	for _, v := range gltf.Buffers {
+		if v == buffer {
+			found = true
+			break
+		}
+	}
+	if !found {
+		gltf.Buffers = append(gltf.Buffers, buffer)
+	}
+	return &builder{gltf: gltf, buffer: buffer}
+}
+
+// Buffer is where data is appended
+func (s *builder) Buffer() *Buffer {
+	return s.buffer
+}
+func (s *builder) Target(target BufferType) *builder {
+	s.target = target
+	return s
+}
+func (s *builder) Normalized() *builder {
+	s.normalized = true
+	return s
+}
+func (s *builder) Name(name string) *builder {
+	s.name = name
+	return s
+}
+
+// Type override AccessorType decided by slice element, ex) [][4]float32 as MAT2
+func (s *builder) Type(accessorType AccessorType) *builder {
+	s.accessorType = accessorType
+	s.hasType = true
+	return s
+}
+func (s *builder) reset() {
+	s.target = NEED_TO_DEFINE_BUFFER
+	s.normalized = false
+	s.name = ""
+	s.hasType = false
+}
+
+// Accessor append data and create Accessor, BufferView of it
+//
+// data is slice of int8, uint8, int16, uint16, uint32, float32 or array of them, ex) []uint16, [][3]float32, []mgl32.Mat4
+// Vertex attribute(ARRAY_BUFFER) element is aligned to 4 bytes by BufferView.ByteStride
+func (s *builder) Accessor(data interface{}) (*Accessor, error) {
+	defer s.reset()
+	rv := reflect.ValueOf(data)
+	if rv.Kind() != reflect.Slice {
+		return nil, errors.Errorf("Builder data must be slice, but got '%T'", data)
+	}
+	var (
+		elemType = rv.Type().Elem()
+		compType = elemType
+		n        = 1
+	)
+	if elemType.Kind() == reflect.Array {
+		compType, n = elemType.Elem(), elemType.Len()
+	}
+	res := &Accessor{
+		Count:      rv.Len(),
+		Normalized: s.normalized,
+		Name:       s.name,
+	}
+	switch compType.Kind() {
+	case reflect.Int8:
+		res.ComponentType = BYTE
+	case reflect.Uint8:
+		res.ComponentType = UNSIGNED_BYTE
+	case reflect.Int16:
+		res.ComponentType = SHORT
+	case reflect.Uint16:
+		res.ComponentType = UNSIGNED_SHORT
+	case reflect.Uint32:
+		res.ComponentType = UNSIGNED_INT
+	case reflect.Float32:
+		res.ComponentType = FLOAT
+	default:
+		return nil, errors.Errorf("Builder data component '%s' not supported", compType)
+	}
+	switch n {
+	case 1:
+		res.Type = SCALAR
+	case 2:
+		res.Type = VEC2
+	case 3:
+		res.Type = VEC3
+	case 4:
+		res.Type = VEC4
+	case 9:
+		res.Type = MAT3
+	case 16:
+		res.Type = MAT4
+	default:
+		return nil, errors.Errorf("Builder data element '%s' not supported", elemType)
+	}
+	if s.hasType {
+		if s.accessorType.Count() != n {
+			return nil, errors.Errorf("Builder type %s need %d components, but element '%s' has %d", s.accessorType, s.accessorType.Count(), elemType, n)
+		}
+		res.Type = s.accessorType
+	}
+	if res.Count < 1 {
+		return nil, errors.New("Builder data is empty")
+	}
+	// little endian packed
+	packed := bytes.NewBuffer(nil)
+	if err := binary.Write(packed, binary.LittleEndian, data); err != nil {
+		return nil, err
+	}
+	var (
+		columns, rows, columnSize = res.layout()
+		size                      = res.ElementSize()
+		stride                    = size
+		tight                     = rows * res.ComponentType.Size()
+	)
+	if s.target == ARRAY_BUFFER {
+		stride += (4 - stride%4) % 4
+	}
+	bts := packed.Bytes()
+	if stride != columns*tight {
+		// place each column, padding is zero
+		src := bts
+		bts = make([]byte, res.Count*stride)
+		for i := 0; i < res.Count; i++ {
+			for c := 0; c < columns; c++ {
+				copy(bts[i*stride+c*columnSize:], src[(i*columns+c)*tight:(i*columns+c+1)*tight])
+			}
+		}
+	}
+	offset, err := s.buffer.Append(bts, 4)
+	if err != nil {
+		return nil, err
+	}
+	res.BufferView = &BufferView{
+		Buffer:     s.buffer,
+		ByteOffset: offset,
+		ByteLength: len(bts),
+		Target:     s.target,
+	}
+	if stride != size {
+		res.BufferView.ByteStride = stride
+	}
+	if res.Min, res.Max, err = accessorMinMax(res); err != nil {
+		return nil, err
+	}
+	s.gltf.BufferViews = append(s.gltf.BufferViews, res.BufferView)
+	s.gltf.Accessors = append(s.gltf.Accessors, res)
+	return res, nil
+}
+
+// Attribute create ARRAY_BUFFER Accessor and set it as primitive attribute
+func (s *builder) Attribute(primitive *MeshPrimitive, key AttributeKey, data interface{}) (*Accessor, error) {
+	res, err := s.Target(ARRAY_BUFFER).Accessor(data)
+	if err != nil {
+		return nil, err
+	}
+	if primitive.Attributes == nil {
+		primitive.Attributes = make(map[AttributeKey]*Accessor)
+	}
+	primitive.Attributes[key] = res
+	return res, nil
+}
+
+// Indices create ELEMENT_ARRAY_BUFFER Accessor and set it as primitive indices, data is []uint8, []uint16 or []uint32
+func (s *builder) Indices(primitive *MeshPrimitive, data interface{}) (*Accessor, error) {
+	switch data.(type) {
+	case []uint8, []uint16, []uint32:
+	default:
+		s.reset()
+		return nil, errors.Errorf("Builder indices must be []uint8, []uint16, []uint32, but got '%T'", data)
+	}
+	res, err := s.Target(ELEMENT_ARRAY_BUFFER).Accessor(data)
+	if err != nil {
+		return nil, err
+	}
+	primitive.Indices = res
+	return res, nil
+}
@@ -0,0 +1,133 @@
+package gltf2
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// builderTestGLTF is one triangle built by builder, indices, position, normalized color and MAT2 accessor
+func builderTestGLTF(t *testing.T) *GLTF {
+	g, err := Parser().Reader(strings.NewReader(`{"asset":{"version":"2.0"}}`)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prim := &MeshPrimitive{Mode: TRIANGLES}
+	g.Meshes = append(g.Meshes, &Mesh{Primitives: []*MeshPrimitive{prim}})
+	g.Nodes = append(g.Nodes, &Node{Mesh: g.Meshes[0]})
+	g.Scenes = append(g.Scenes, &Scene{Nodes: []*Node{g.Nodes[0]}})
+	b := Builder(g, nil)
+	if _, err := b.Indices(prim, []uint16{0, 1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Name("position").Attribute(prim, POSITION, []mgl32.Vec3{{0, 0, 0}, {1, 0, 0}, {0, 2, -1}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Normalized().Attribute(prim, COLOR_0, [][3]uint8{{255, 0, 0}, {0, 255, 0}, {0, 0, 255}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Type(MAT2).Accessor([][4]uint8{{1, 2, 3, 4}}); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// builderTestCheck check accessors of builderTestGLTF
+func builderTestCheck(t *testing.T, g *GLTF) {
+	if len(g.Buffers) != 1 || len(g.Accessors) != 4 || len(g.BufferViews) != 4 {
+		t.Fatalf("%d buffers, %d accessors, %d bufferViews, expected 1, 4, 4", len(g.Buffers), len(g.Accessors), len(g.BufferViews))
+	}
+	prim := g.Meshes[0].Primitives[0]
+	indices, err := ReadIndices(prim.Indices)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indices) != 3 || indices[2] != 2 || prim.Indices.BufferView.Target != ELEMENT_ARRAY_BUFFER {
+		t.Errorf("indices %v, target %v", indices, prim.Indices.BufferView.Target)
+	}
+	pos := prim.Attributes[POSITION]
+	if pos.Name != "position" || pos.BufferView.Target != ARRAY_BUFFER {
+		t.Errorf("position name '%s', target %v", pos.Name, pos.BufferView.Target)
+	}
+	// indices are 6 bytes, next bufferView is aligned to 4 bytes
+	if pos.BufferView.ByteOffset != 8 {
+		t.Errorf("position byteOffset %d, expected 8", pos.BufferView.ByteOffset)
+	}
+	if len(pos.Min) != 3 || len(pos.Max) != 3 || pos.Max[1] != 2 || pos.Min[2] != -1 {
+		t.Errorf("position min %v max %v", pos.Min, pos.Max)
+	}
+	ps, err := ReadVec3f(pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ps[2] != (mgl32.Vec3{0, 2, -1}) {
+		t.Errorf("position %v", ps)
+	}
+	// vertex attribute element is aligned to 4 bytes
+	col := prim.Attributes[COLOR_0]
+	if col.BufferView.ByteStride != 4 || !col.Normalized || col.ComponentType != UNSIGNED_BYTE {
+		t.Errorf("color byteStride %d, normalized %v, componentType %v", col.BufferView.ByteStride, col.Normalized, col.ComponentType)
+	}
+	cs, err := ReadVec3f(col)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cs[1] != (mgl32.Vec3{0, 1, 0}) {
+		t.Errorf("color %v", cs)
+	}
+	// options are reset after Accessor
+	m := g.Accessors[3]
+	if m.Type != MAT2 || m.Normalized || m.Name != "" || m.BufferView.ByteLength != 8 {
+		t.Errorf("matrix type %v, normalized %v, name '%s', byteLength %d", m.Type, m.Normalized, m.Name, m.BufferView.ByteLength)
+	}
+	if fs, err := ReadFloats(m); err != nil || len(fs) != 4 || fs[3] != 4 {
+		t.Errorf("matrix %v %v", fs, err)
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	g := builderTestGLTF(t)
+	builderTestCheck(t, g)
+	for name, encoder := range map[string]func(*bytes.Buffer) *encoder{
+		"glb":      func(b *bytes.Buffer) *encoder { return Encoder(b).GLB() },
+		"data-uri": func(b *bytes.Buffer) *encoder { return Encoder(b).DataURI() },
+	} {
+		var out bytes.Buffer
+		if err := encoder(&out).Encode(g); err != nil {
+			t.Fatal(name, err)
+		}
+		issues, err := Parser().Reader(bytes.NewReader(out.Bytes())).Validate()
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		if len(issues) != 0 {
+			t.Errorf("%s : issues %v", name, issues)
+		}
+		g2, err := Parser().Reader(&out).Parse()
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		builderTestCheck(t, g2)
+	}
+}
+
+func TestBuilderExistingBuffer(t *testing.T) {
+	g := builderTestGLTF(t)
+	// appended to buffer of builderTestGLTF, not new buffer
+	b := Builder(g, g.Buffers[0])
+	a, err := b.Accessor([]float32{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Buffers) != 1 || a.BufferView.Buffer != g.Buffers[0] || a.Type != SCALAR {
+		t.Fatalf("%d buffers, type %v", len(g.Buffers), a.Type)
+	}
+	if _, err := b.Accessor([]string{"a"}); err == nil {
+		t.Error("Accessor of []string must fail")
+	}
+	if _, err := b.Indices(g.Meshes[0].Primitives[0], []float32{0}); err == nil {
+		t.Error("Indices of []float32 must fail")
+	}
+}
@@ -0,0 +1,263 @@
+package gltf2
+
+import (
+	"encoding/binary"
+	"github.com/pkg/errors"
+	"image"
+	"image/color"
+	"io"
+	"io/ioutil"
+	"math/bits"
+)
+
+// https://docs.microsoft.com/en-us/windows/win32/direct3ddds/dx-graphics-dds-pguide
+//
+// Base level of DXT1, DXT3, DXT5(BC1, BC2, BC3) and uncompressed RGB(A) is decoded
+const (
+	ddsMagic        = "DDS "
+	ddsHeaderLength = 128
+	ddsDX10Length   = 20
+	// DDS_PIXELFORMAT.dwFlags
+	ddsAlphaPixels = 0x1
+	ddsFourCC      = 0x4
+	ddsRGB         = 0x40
+	ddsLuminance   = 0x20000
+)
+
+// DXGI_FORMAT of DX10 header
+const (
+	dxgiR8G8B8A8Unorm     = 28
+	dxgiR8G8B8A8UnormSRGB = 29
+	dxgiBC1Unorm          = 71
+	dxgiBC1UnormSRGB      = 72
+	dxgiBC2Unorm          = 74
+	dxgiBC2UnormSRGB      = 75
+	dxgiBC3Unorm          = 77
+	dxgiBC3UnormSRGB      = 78
+	dxgiB8G8R8A8Unorm     = 87
+	dxgiB8G8R8A8UnormSRGB = 91
+)
+
+type ddsFormat uint8
+
+const (
+	ddsFormatMask ddsFormat = iota
+	ddsFormatBC1
+	ddsFormatBC2
+	ddsFormatBC3
+)
+
+type ddsHeader struct {
+	width, height int
+	format        ddsFormat
+	// pixel mask for ddsFormatMask
+	bitCount int
+	masks    [4]uint32
+	// data offset
+	offset int
+}
+
+func readDDSHeader(data []byte) (res ddsHeader, err error) {
+	if len(data) < ddsHeaderLength || string(data[:4]) != ddsMagic {
+		return res, errors.New("DDS invalid magic")
+	}
+	u32 := func(i int) uint32 { return binary.LittleEndian.Uint32(data[i:]) }
+	res.height, res.width = int(u32(12)), int(u32(16))
+	res.offset = ddsHeaderLength
+	flags, fourCC := u32(80), string(data[84:88])
+	switch {
+	case flags&ddsFourCC != 0 && fourCC == "DX10":
+		if len(data) < ddsHeaderLength+ddsDX10Length {
+			return res, errors.New("DDS DX10 header too short")
+		}
+		res.offset += ddsDX10Length
+		switch dxgi := u32(ddsHeaderLength); dxgi {
+		case dxgiBC1Unorm, dxgiBC1UnormSRGB:
+			res.format = ddsFormatBC1
+		case dxgiBC2Unorm, dxgiBC2UnormSRGB:
+			res.format = ddsFormatBC2
+		case dxgiBC3Unorm, dxgiBC3UnormSRGB:
+			res.format = ddsFormatBC3
+		case dxgiR8G8B8A8Unorm, dxgiR8G8B8A8UnormSRGB:
+			res.bitCount, res.masks = 32, [4]uint32{0xFF, 0xFF00, 0xFF0000, 0xFF000000}
+		case dxgiB8G8R8A8Unorm, dxgiB8G8R8A8UnormSRGB:
+			res.bitCount, res.masks = 32, [4]uint32{0xFF0000, 0xFF00, 0xFF, 0xFF000000}
+		default:
+			return res, errors.Errorf("DDS DXGI format %d not supported", dxgi)
+		}
+	case flags&ddsFourCC != 0:
+		switch fourCC {
+		case "DXT1":
+			res.format = ddsFormatBC1
+		case "DXT2", "DXT3":
+			res.format = ddsFormatBC2
+		case "DXT4", "DXT5":
+			res.format = ddsFormatBC3
+		default:
+			return res, errors.Errorf("DDS FourCC '%s' not supported", fourCC)
+		}
+	case flags&(ddsRGB|ddsLuminance) != 0:
+		res.bitCount = int(u32(88))
+		res.masks = [4]uint32{u32(92), u32(96), u32(100), 0}
+		if flags&ddsLuminance != 0 {
+			res.masks[1], res.masks[2] = res.masks[0], res.masks[0]
+		}
+		if flags&ddsAlphaPixels != 0 {
+			res.masks[3] = u32(104)
+		}
+		if res.bitCount%8 != 0 || res.bitCount < 8 || res.bitCount > 32 {
+			return res, errors.Errorf("DDS %d bits pixel not supported", res.bitCount)
+		}
+	default:
+		return res, errors.New("DDS pixel format not supported")
+	}
+	return res, nil
+}
+
+// decodeDDS decode base level of DDS
+func decodeDDS(rd io.Reader) (image.Image, error) {
+	data, err := ioutil.ReadAll(rd)
+	if err != nil {
+		return nil, err
+	}
+	header, err := readDDSHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		w, h = header.width, header.height
+		src  = data[header.offset:]
+		res  = image.NewRGBA(image.Rect(0, 0, w, h))
+	)
+	if header.format == ddsFormatMask {
+		size := header.bitCount / 8
+		if len(src) < w*h*size {
+			return nil, errors.New("DDS data too short")
+		}
+		for i := 0; i < w*h; i++ {
+			var v uint32
+			for j := 0; j < size; j++ {
+				v |= uint32(src[i*size+j]) << uint(j*8)
+			}
+			for c, mask := range header.masks {
+				res.Pix[i*4+c] = ddsChannel(v, mask)
+			}
+		}
+		return res, nil
+	}
+	blockSize := 16
+	if header.format == ddsFormatBC1 {
+		blockSize = 8
+	}
+	bw, bh := (w+3)/4, (h+3)/4
+	if len(src) < bw*bh*blockSize {
+		return nil, errors.New("DDS data too short")
+	}
+	var block [16][4]uint8
+	for by := 0; by < bh; by++ {
+		for bx := 0; bx < bw; bx++ {
+			b := src[(by*bw+bx)*blockSize:]
+			switch header.format {
+			case ddsFormatBC1:
+				decodeBC1(b, &block, true)
+			case ddsFormatBC2:
+				decodeBC1(b[8:], &block, false)
+				for i := 0; i < 16; i++ {
+					a := b[i/2] >> uint(i%2*4) & 0xF
+					block[i][3] = a<<4 | a
+				}
+			case ddsFormatBC3:
+				decodeBC1(b[8:], &block, false)
+				decodeBC3Alpha(b, &block)
+			}
+			for i, c := range block {
+				x, y := bx*4+i%4, by*4+i/4
+				if x < w && y < h {
+					copy(res.Pix[res.PixOffset(x, y):], c[:])
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+// ddsChannel extract masked channel and scale it to 8 bits, 255 if mask is 0 for alpha
+func ddsChannel(v, mask uint32) uint8 {
+	if mask == 0 {
+		return 0xFF
+	}
+	shift := uint(bits.TrailingZeros32(mask))
+	max := mask >> shift
+	return uint8((uint64(v&mask>>shift)*255 + uint64(max)/2) / uint64(max))
+}
+
+// decodeBC1 decode color block, punchthrough alpha is used for BC1 only
+func decodeBC1(b []byte, dst *[16][4]uint8, punchthrough bool) {
+	c0, c1 := binary.LittleEndian.Uint16(b), binary.LittleEndian.Uint16(b[2:])
+	var colors [4][4]uint8
+	colors[0], colors[1] = rgb565(c0), rgb565(c1)
+	for c := 0; c < 3; c++ {
+		x, y := int(colors[0][c]), int(colors[1][c])
+		if c0 > c1 || !punchthrough {
+			colors[2][c] = uint8((2*x + y) / 3)
+			colors[3][c] = uint8((x + 2*y) / 3)
+		} else {
+			colors[2][c] = uint8((x + y) / 2)
+		}
+	}
+	colors[2][3] = 0xFF
+	if c0 > c1 || !punchthrough {
+		colors[3][3] = 0xFF
+	}
+	indices := binary.LittleEndian.Uint32(b[4:])
+	for i := range dst {
+		dst[i] = colors[indices>>uint(i*2)&3]
+	}
+}
+func rgb565(v uint16) [4]uint8 {
+	r, g, b := uint8(v>>11&0x1F), uint8(v>>5&0x3F), uint8(v&0x1F)
+	return [4]uint8{r<<3 | r>>2, g<<2 | g>>4, b<<3 | b>>2, 0xFF}
+}
+
+// decodeBC3Alpha decode interpolated alpha block
+func decodeBC3Alpha(b []byte, dst *[16][4]uint8) {
+	var alphas [8]uint8
+	a0, a1 := int(b[0]), int(b[1])
+	alphas[0], alphas[1] = b[0], b[1]
+	if a0 > a1 {
+		for i := 1; i < 7; i++ {
+			alphas[i+1] = uint8(((7-i)*a0 + i*a1) / 7)
+		}
+	} else {
+		for i := 1; i < 5; i++ {
+			alphas[i+1] = uint8(((5-i)*a0 + i*a1) / 5)
+		}
+		alphas[6], alphas[7] = 0, 0xFF
+	}
+	var indices uint64
+	for i := 0; i < 6; i++ {
+		indices |= uint64(b[2+i]) << uint(i*8)
+	}
+	for i := range dst {
+		dst[i][3] = alphas[indices>>uint(i*3)&7]
+	}
+}
+
+func decodeDDSConfig(rd io.Reader) (image.Config, error) {
+	header := make([]byte, ddsHeaderLength)
+	if _, err := io.ReadFull(rd, header); err != nil {
+		return image.Config{}, err
+	}
+	if string(header[:4]) != ddsMagic {
+		return image.Config{}, errors.New("DDS invalid magic")
+	}
+	return image.Config{
+		ColorModel: color.RGBAModel,
+		Width:      int(binary.LittleEndian.Uint32(header[16:])),
+		Height:     int(binary.LittleEndian.Uint32(header[12:])),
+	}, nil
+}
+
+func init() {
+	image.RegisterFormat("dds", ddsMagic, decodeDDS, decodeDDSConfig)
+}
@@ -0,0 +1,57 @@
+package gltf2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"testing"
+)
+
+// ddsTestFile is DDS of base level only, fourCC is used if pixel format flags has DDPF_FOURCC
+func ddsTestFile(fourCC string, flags uint32, bitCount uint32, masks [4]uint32, w, h int, data []byte) []byte {
+	res := make([]byte, 128)
+	copy(res, "DDS ")
+	binary.LittleEndian.PutUint32(res[4:], 124)
+	binary.LittleEndian.PutUint32(res[12:], uint32(h))
+	binary.LittleEndian.PutUint32(res[16:], uint32(w))
+	binary.LittleEndian.PutUint32(res[76:], 32)
+	binary.LittleEndian.PutUint32(res[80:], flags)
+	copy(res[84:88], fourCC)
+	binary.LittleEndian.PutUint32(res[88:], bitCount)
+	for i, m := range masks {
+		binary.LittleEndian.PutUint32(res[92+i*4:], m)
+	}
+	return append(res, data...)
+}
+
+// ddsTestDXT1 is 4x4 DXT1, color0 red, color1 blue, first row use index 0, 1, 2, 3
+var ddsTestDXT1 = ddsTestFile("DXT1", 0x4, 0, [4]uint32{}, 4, 4, []byte{0x00, 0xF8, 0x1F, 0x00, 0xE4, 0, 0, 0})
+
+func TestDecodeDDS(t *testing.T) {
+	img, format, err := image.Decode(bytes.NewReader(ddsTestDXT1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format != "dds" || img.Bounds().Dx() != 4 || img.Bounds().Dy() != 4 {
+		t.Fatalf("format '%s', bounds %v", format, img.Bounds())
+	}
+	rgba := img.(*image.RGBA)
+	for x, expected := range [][3]uint8{{255, 0, 0}, {0, 0, 255}, {170, 0, 85}, {85, 0, 170}} {
+		if c := rgba.RGBAAt(x, 0); c.R != expected[0] || c.B != expected[2] || c.A != 255 {
+			t.Errorf("pixel (%d, 0) %v, expected %v", x, c, expected)
+		}
+	}
+	// uncompressed BGRA
+	bgra := ddsTestFile("", 0x41, 32, [4]uint32{0xFF0000, 0xFF00, 0xFF, 0xFF000000}, 1, 1, []byte{1, 2, 3, 4})
+	img, _, err = image.Decode(bytes.NewReader(bgra))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pix := img.(*image.RGBA).Pix; !bytes.Equal(pix, []byte{3, 2, 1, 4}) {
+		t.Errorf("BGRA %v, expected [3 2 1 4]", pix)
+	}
+	// truncated data
+	if _, _, err := image.Decode(bytes.NewReader(ddsTestDXT1[:130])); err == nil {
+		t.Error("truncated DDS must fail")
+	}
+}
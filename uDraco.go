@@ -0,0 +1,1036 @@
+package gltf2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/pkg/errors"
+	"math"
+	"reflect"
+)
+
+// Draco bitstream 2.2 decoder, https://google.github.io/draco/spec/
+//
+// It supports sequential and edgebreaker(standard, valence) mesh.
+// Attribute can be generic, integer, quantization or normal(octahedron).
+// Prediction can be difference, parallelogram, multi parallelogram, constrained multi parallelogram,
+// portable texture coordinate or geometric normal, deprecated texture coordinate prediction is not supported.
+const (
+	dracoMeshType          = 1
+	dracoMethodSequential  = 0
+	dracoMethodEdgebreaker = 1
+	dracoMetadataFlag      = 0x8000
+	//
+	dracoCompressedIndices   = 0
+	dracoUncompressedIndices = 1
+	//
+	dracoDecoderGeneric      = 0
+	dracoDecoderInteger      = 1
+	dracoDecoderQuantization = 2
+	dracoDecoderNormals      = 3
+	//
+	dracoPredictionNone                          = -2
+	dracoPredictionDifference                    = 0
+	dracoPredictionParallelogram                 = 1
+	dracoPredictionMultiParallelogram            = 2
+	dracoPredictionTexCoordsDeprecated           = 3
+	dracoPredictionConstrainedMultiParallelogram = 4
+	dracoPredictionTexCoordsPortable             = 5
+	dracoPredictionGeometricNormal               = 6
+	//
+	dracoTransformWrap                          = 1
+	dracoTransformNormalOctahedron              = 2
+	dracoTransformNormalOctahedronCanonicalized = 3
+	//
+	dracoSymbolTagged = 0
+	dracoSymbolRaw    = 1
+	//
+	dracoAttributePosition = 0
+)
+
+// draco data type, DT_INT8(1) ~ DT_BOOL(11)
+const (
+	dracoInt8    = 1
+	dracoUint8   = 2
+	dracoInt16   = 3
+	dracoUint16  = 4
+	dracoInt32   = 5
+	dracoUint32  = 6
+	dracoInt64   = 7
+	dracoUint64  = 8
+	dracoFloat32 = 9
+	dracoFloat64 = 10
+	dracoBool    = 11
+)
+
+var dracoDataTypeSize = [...]int{0, 1, 1, 2, 2, 4, 4, 8, 8, 4, 8, 1}
+
+var errDracoTruncated = errors.New("Draco bitstream is truncated")
+
+type dracoMesh struct {
+	indices   []uint32
+	numPoints int
+	// key is unique id of attribute
+	attributes map[uint32]*dracoAttribute
+}
+
+type dracoAttribute struct {
+	attributeType int
+	dataType      int
+	components    int
+	decoder       int
+	// little endian dataType values, components per value
+	data []byte
+	// integer values of integer, quantization, normal decoder
+	portable []int32
+	// value of each point, nil is same as point
+	entries []int
+}
+
+// dracoSequence is decoding order of attributes decoder
+type dracoSequence struct {
+	// point of each value
+	points []int
+	// value of each point, nil is same as point
+	entries []int
+	// connectivity for mesh prediction, nil if mesh is sequential
+	table    dracoTable
+	encoding *dracoEncoding
+}
+
+func decodeDraco(src []byte) (*dracoMesh, error) {
+	buffer := &dracoBuffer{data: src}
+	magic, err := buffer.next(5)
+	if err != nil {
+		return nil, err
+	}
+	if string(magic) != "DRACO" {
+		return nil, errors.New("Draco bitstream must start with 'DRACO'")
+	}
+	header, err := buffer.next(4)
+	if err != nil {
+		return nil, err
+	}
+	if header[0] != 2 || header[1] != 2 {
+		return nil, errors.Errorf("Draco bitstream version %d.%d not supported, only 2.2", header[0], header[1])
+	}
+	if header[2] != dracoMeshType {
+		return nil, errors.Errorf("Draco encoder type %d is not triangular mesh", header[2])
+	}
+	if header[3] != dracoMethodSequential && header[3] != dracoMethodEdgebreaker {
+		return nil, errors.Errorf("Draco encoder method %d unknown", header[3])
+	}
+	flags, err := buffer.u16()
+	if err != nil {
+		return nil, err
+	}
+	if flags&dracoMetadataFlag != 0 {
+		if err = skipDracoMetadata(buffer); err != nil {
+			return nil, err
+		}
+	}
+	var (
+		res         = &dracoMesh{attributes: make(map[uint32]*dracoAttribute)}
+		edgebreaker *dracoEdgebreaker
+	)
+	if header[3] == dracoMethodSequential {
+		err = res.connectivity(buffer)
+	} else {
+		edgebreaker, err = res.edgebreaker(buffer)
+	}
+	if err != nil {
+		return nil, err
+	}
+	numDecoders, err := buffer.u8()
+	if err != nil {
+		return nil, err
+	}
+	sequences := make([]*dracoSequence, numDecoders)
+	for i := range sequences {
+		if edgebreaker == nil {
+			sequences[i] = &dracoSequence{points: make([]int, res.numPoints)}
+			for j := range sequences[i].points {
+				sequences[i].points[j] = j
+			}
+		} else if sequences[i], err = edgebreaker.attributesDecoder(buffer, i); err != nil {
+			return nil, err
+		}
+	}
+	var (
+		decoders = make([][]*dracoAttribute, numDecoders)
+		position *dracoAttribute
+	)
+	for i := range decoders {
+		numAttributes, err := buffer.varint()
+		if err != nil {
+			return nil, err
+		}
+		for j := uint64(0); j < numAttributes; j++ {
+			desc, err := buffer.next(4)
+			if err != nil {
+				return nil, err
+			}
+			id, err := buffer.varint()
+			if err != nil {
+				return nil, err
+			}
+			attr := &dracoAttribute{attributeType: int(desc[0]), dataType: int(desc[1]), components: int(desc[2]), entries: sequences[i].entries}
+			if attr.dataType < dracoInt8 || attr.dataType > dracoBool || attr.components < 1 {
+				return nil, errors.Errorf("Draco attribute %d has invalid data type %d, components %d", id, attr.dataType, attr.components)
+			}
+			if _, ok := res.attributes[uint32(id)]; ok {
+				return nil, errors.Errorf("Draco attribute %d duplicated", id)
+			}
+			res.attributes[uint32(id)] = attr
+			decoders[i] = append(decoders[i], attr)
+			if attr.attributeType == dracoAttributePosition && position == nil {
+				position = attr
+			}
+		}
+		for _, attr := range decoders[i] {
+			decoder, err := buffer.u8()
+			if err != nil {
+				return nil, err
+			}
+			attr.decoder = int(decoder)
+		}
+	}
+	for i, attrs := range decoders {
+		for _, attr := range attrs {
+			if err = attr.decodePortable(buffer, sequences[i], position); err != nil {
+				return nil, err
+			}
+		}
+		for _, attr := range attrs {
+			if err = attr.decodeTransform(buffer); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, attrs := range decoders {
+		for _, attr := range attrs {
+			attr.expand(res.numPoints)
+		}
+	}
+	return res, nil
+}
+
+func (s *dracoMesh) connectivity(buffer *dracoBuffer) error {
+	numFaces, err := buffer.varint()
+	if err != nil {
+		return err
+	}
+	numPoints, err := buffer.varint()
+	if err != nil {
+		return err
+	}
+	if numFaces > math.MaxUint32/3 || numPoints > math.MaxInt32 {
+		return errors.Errorf("Draco mesh too large, %d faces, %d points", numFaces, numPoints)
+	}
+	method, err := buffer.u8()
+	if err != nil {
+		return err
+	}
+	s.numPoints = int(numPoints)
+	n := int(numFaces) * 3
+	switch method {
+	case dracoCompressedIndices:
+		symbols, err := decodeDracoSymbols(buffer, n, 1)
+		if err != nil {
+			return err
+		}
+		s.indices = make([]uint32, n)
+		var last int64
+		for i, v := range symbols {
+			diff := int64(v >> 1)
+			if v&1 != 0 {
+				diff = -diff
+			}
+			last += diff
+			if last < 0 || last >= int64(numPoints) {
+				return errors.Errorf("Draco index %d out of points %d", last, numPoints)
+			}
+			s.indices[i] = uint32(last)
+		}
+	case dracoUncompressedIndices:
+		if n > len(buffer.data) {
+			return errDracoTruncated
+		}
+		s.indices = make([]uint32, n)
+		for i := range s.indices {
+			var v uint64
+			switch {
+			case numPoints < 1<<8:
+				var b uint8
+				b, err = buffer.u8()
+				v = uint64(b)
+			case numPoints < 1<<16:
+				var b uint16
+				b, err = buffer.u16()
+				v = uint64(b)
+			case numPoints < 1<<21:
+				v, err = buffer.varint()
+			default:
+				var b uint32
+				b, err = buffer.u32()
+				v = uint64(b)
+			}
+			if err != nil {
+				return err
+			}
+			if v >= numPoints {
+				return errors.Errorf("Draco index %d out of points %d", v, numPoints)
+			}
+			s.indices[i] = uint32(v)
+		}
+	default:
+		return errors.Errorf("Draco sequential connectivity method %d unknown", method)
+	}
+	return nil
+}
+
+// portableComponents is number of integer components, octahedron normal is 2
+func (s *dracoAttribute) portableComponents() int {
+	if s.decoder == dracoDecoderNormals {
+		return 2
+	}
+	return s.components
+}
+
+// entry is value index of point
+func (s *dracoAttribute) entry(point int) int {
+	if s.entries == nil {
+		return point
+	}
+	return s.entries[point]
+}
+
+// expand make values per point
+func (s *dracoAttribute) expand(numPoints int) {
+	if s.entries == nil {
+		return
+	}
+	size := s.components * dracoDataTypeSize[s.dataType]
+	data := make([]byte, numPoints*size)
+	for i, entry := range s.entries {
+		copy(data[i*size:(i+1)*size], s.data[entry*size:])
+	}
+	s.data = data
+}
+
+func (s *dracoAttribute) decodePortable(buffer *dracoBuffer, seq *dracoSequence, position *dracoAttribute) error {
+	numValues := len(seq.points)
+	switch s.decoder {
+	case dracoDecoderGeneric:
+		src, err := buffer.next(numValues * s.components * dracoDataTypeSize[s.dataType])
+		if err != nil {
+			return err
+		}
+		s.data = append([]byte(nil), src...)
+		return nil
+	case dracoDecoderInteger:
+		if s.dataType > dracoUint32 {
+			return errors.Errorf("Draco integer decoder can't decode data type %d", s.dataType)
+		}
+	case dracoDecoderQuantization, dracoDecoderNormals:
+		if s.dataType != dracoFloat32 {
+			return errors.Errorf("Draco quantization decoder can't decode data type %d", s.dataType)
+		}
+		if s.decoder == dracoDecoderNormals && s.components != 3 {
+			return errors.Errorf("Draco normal decoder need 3 components, but got %d", s.components)
+		}
+	default:
+		return errors.Errorf("Draco sequential decoder %d unknown", s.decoder)
+	}
+	method, err := buffer.u8()
+	if err != nil {
+		return err
+	}
+	var transform int8
+	if int8(method) != dracoPredictionNone {
+		if int8(method) < dracoPredictionDifference || int8(method) > dracoPredictionGeometricNormal {
+			return errors.Errorf("Draco prediction %d unknown", int8(method))
+		}
+		t, err := buffer.u8()
+		if err != nil {
+			return err
+		}
+		transform = int8(t)
+		switch {
+		case s.decoder == dracoDecoderNormals && (transform == dracoTransformNormalOctahedron || transform == dracoTransformNormalOctahedronCanonicalized):
+		case s.decoder != dracoDecoderNormals && transform == dracoTransformWrap:
+		default:
+			return errors.Errorf("Draco prediction transform %d not supported for decoder %d", transform, s.decoder)
+		}
+	}
+	var (
+		components = s.portableComponents()
+		n          = numValues * components
+		prediction *dracoPrediction
+	)
+	if int8(method) != dracoPredictionNone {
+		if prediction, err = newDracoPrediction(int8(method), transform, seq, components, position); err != nil {
+			return err
+		}
+	}
+	compressed, err := buffer.u8()
+	if err != nil {
+		return err
+	}
+	values := make([]uint32, n)
+	if compressed > 0 {
+		if values, err = decodeDracoSymbols(buffer, n, components); err != nil {
+			return err
+		}
+	} else {
+		size, err := buffer.u8()
+		if err != nil {
+			return err
+		}
+		if size < 1 || size > 4 {
+			return errors.Errorf("Draco integer size %d bytes invalid", size)
+		}
+		src, err := buffer.next(n * int(size))
+		if err != nil {
+			return err
+		}
+		for i := range values {
+			for b := 0; b < int(size); b++ {
+				values[i] |= uint32(src[i*int(size)+b]) << uint(8*b)
+			}
+		}
+	}
+	s.portable = make([]int32, n)
+	// octahedron correction is always positive
+	positive := transform == dracoTransformNormalOctahedron || transform == dracoTransformNormalOctahedronCanonicalized
+	for i, v := range values {
+		if positive {
+			s.portable[i] = int32(v)
+		} else if v&1 == 0 {
+			s.portable[i] = int32(v >> 1)
+		} else {
+			s.portable[i] = -int32(v>>1) - 1
+		}
+	}
+	if prediction == nil {
+		return nil
+	}
+	if err = prediction.decodeData(buffer, transform); err != nil {
+		return err
+	}
+	return prediction.restore(s.portable)
+}
+
+func (s *dracoAttribute) decodeTransform(buffer *dracoBuffer) error {
+	switch s.decoder {
+	case dracoDecoderInteger:
+		size := dracoDataTypeSize[s.dataType]
+		s.data = make([]byte, len(s.portable)*size)
+		for i, v := range s.portable {
+			switch size {
+			case 1:
+				s.data[i] = byte(v)
+			case 2:
+				binary.LittleEndian.PutUint16(s.data[i*2:], uint16(v))
+			case 4:
+				binary.LittleEndian.PutUint32(s.data[i*4:], uint32(v))
+			}
+		}
+	case dracoDecoderQuantization:
+		min := make([]float32, s.components)
+		for i := range min {
+			v, err := buffer.f32()
+			if err != nil {
+				return err
+			}
+			min[i] = v
+		}
+		scale, err := buffer.f32()
+		if err != nil {
+			return err
+		}
+		bits, err := buffer.u8()
+		if err != nil {
+			return err
+		}
+		if bits < 1 || bits > 31 {
+			return errors.Errorf("Draco quantization bits %d invalid", bits)
+		}
+		delta := scale / float32(uint32(1)<<bits-1)
+		s.data = make([]byte, len(s.portable)*4)
+		for i, v := range s.portable {
+			binary.LittleEndian.PutUint32(s.data[i*4:], math.Float32bits(float32(v)*delta+min[i%s.components]))
+		}
+	case dracoDecoderNormals:
+		bits, err := buffer.u8()
+		if err != nil {
+			return err
+		}
+		if bits < 2 || bits > 30 {
+			return errors.Errorf("Draco octahedron quantization bits %d invalid", bits)
+		}
+		box := newDracoOctahedron(uint(bits))
+		s.data = make([]byte, len(s.portable)/2*3*4)
+		for i := 0; i < len(s.portable)/2; i++ {
+			normal := box.unitVector(s.portable[i*2], s.portable[i*2+1])
+			for c := 0; c < 3; c++ {
+				binary.LittleEndian.PutUint32(s.data[(i*3+c)*4:], math.Float32bits(normal[c]))
+			}
+		}
+	}
+	return nil
+}
+
+// slice is go slice of fixed size array, it can be Builder data
+func (s *dracoAttribute) slice(count int) (interface{}, error) {
+	var comp reflect.Type
+	switch s.dataType {
+	case dracoInt8:
+		comp = reflect.TypeOf(int8(0))
+	case dracoUint8:
+		comp = reflect.TypeOf(uint8(0))
+	case dracoInt16:
+		comp = reflect.TypeOf(int16(0))
+	case dracoUint16:
+		comp = reflect.TypeOf(uint16(0))
+	case dracoUint32:
+		comp = reflect.TypeOf(uint32(0))
+	case dracoFloat32:
+		comp = reflect.TypeOf(float32(0))
+	default:
+		return nil, errors.Errorf("Draco data type %d can't be accessor component", s.dataType)
+	}
+	res := reflect.MakeSlice(reflect.SliceOf(reflect.ArrayOf(s.components, comp)), count, count)
+	if err := binary.Read(bytes.NewReader(s.data), binary.LittleEndian, res.Interface()); err != nil {
+		return nil, err
+	}
+	return res.Interface(), nil
+}
+
+// decodeDracoTransform read prediction transform data, and return function which compute original value
+//
+// octahedron transform also return its octahedron, it is used by geometric normal prediction
+func decodeDracoTransform(buffer *dracoBuffer, transform int8) (func(pred, corr, dst []int32), *dracoOctahedron, error) {
+	switch transform {
+	case dracoTransformWrap:
+		min, err := buffer.u32()
+		if err != nil {
+			return nil, nil, err
+		}
+		max, err := buffer.u32()
+		if err != nil {
+			return nil, nil, err
+		}
+		lo, hi := int32(min), int32(max)
+		if lo > hi {
+			return nil, nil, errors.Errorf("Draco wrap transform min %d > max %d", lo, hi)
+		}
+		dif := 1 + hi - lo
+		return func(pred, corr, dst []int32) {
+			for i := range corr {
+				p := pred[i]
+				if p > hi {
+					p = hi
+				} else if p < lo {
+					p = lo
+				}
+				v := p + corr[i]
+				if v > hi {
+					v -= dif
+				} else if v < lo {
+					v += dif
+				}
+				dst[i] = v
+			}
+		}, nil, nil
+	case dracoTransformNormalOctahedron, dracoTransformNormalOctahedronCanonicalized:
+		maxQuantized, err := buffer.u32()
+		if err != nil {
+			return nil, nil, err
+		}
+		// center value is not used
+		if transform == dracoTransformNormalOctahedronCanonicalized {
+			if _, err = buffer.u32(); err != nil {
+				return nil, nil, err
+			}
+		}
+		if int32(maxQuantized) <= 0 || maxQuantized%2 == 0 {
+			return nil, nil, errors.Errorf("Draco octahedron max quantized value %d invalid", int32(maxQuantized))
+		}
+		var bits uint
+		for v := maxQuantized; v > 0; v >>= 1 {
+			bits++
+		}
+		if bits < 2 || bits > 30 {
+			return nil, nil, errors.Errorf("Draco octahedron quantization bits %d invalid", bits)
+		}
+		box := newDracoOctahedron(bits)
+		canonicalized := transform == dracoTransformNormalOctahedronCanonicalized
+		return func(pred, corr, dst []int32) {
+			dst[0], dst[1] = box.original(pred[0], pred[1], corr[0], corr[1], canonicalized)
+		}, box, nil
+	}
+	return nil, nil, errors.Errorf("Draco prediction transform %d unknown", transform)
+}
+
+type dracoOctahedron struct {
+	maxQuantized int32
+	center       int32
+	scale        float32
+}
+
+func newDracoOctahedron(bits uint) *dracoOctahedron {
+	maxQuantized := int32(1)<<bits - 1
+	return &dracoOctahedron{
+		maxQuantized: maxQuantized,
+		center:       (maxQuantized - 1) / 2,
+		scale:        2 / float32(maxQuantized-1),
+	}
+}
+func (s *dracoOctahedron) inDiamond(x, y int32) bool {
+	return abs32(x)+abs32(y) <= s.center
+}
+func (s *dracoOctahedron) invertDiamond(x, y int32) (int32, int32) {
+	var sx, sy int32
+	switch {
+	case x >= 0 && y >= 0:
+		sx, sy = 1, 1
+	case x <= 0 && y <= 0:
+		sx, sy = -1, -1
+	default:
+		sx, sy = 1, 1
+		if x <= 0 {
+			sx = -1
+		}
+		if y <= 0 {
+			sy = -1
+		}
+	}
+	cx, cy := sx*s.center, sy*s.center
+	x, y = 2*x-cx, 2*y-cy
+	if sx*sy >= 0 {
+		x, y = -y, -x
+	} else {
+		x, y = y, x
+	}
+	return (x + cx) / 2, (y + cy) / 2
+}
+func (s *dracoOctahedron) modMax(x int32) int32 {
+	if x > s.center {
+		return x - s.maxQuantized
+	}
+	if x < -s.center {
+		return x + s.maxQuantized
+	}
+	return x
+}
+func (s *dracoOctahedron) original(px, py, cx, cy int32, canonicalized bool) (int32, int32) {
+	px, py = px-s.center, py-s.center
+	inDiamond := s.inDiamond(px, py)
+	if !inDiamond {
+		px, py = s.invertDiamond(px, py)
+	}
+	var rotation int
+	bottomLeft := px == 0 && py == 0 || px < 0 && py <= 0
+	if canonicalized && !bottomLeft {
+		switch {
+		case px == 0 && py > 0, px < 0 && py > 0:
+			rotation = 3
+		case px == 0 && py < 0, px > 0 && py < 0:
+			rotation = 1
+		case px > 0:
+			rotation = 2
+		}
+		px, py = dracoRotate(px, py, rotation)
+	}
+	x, y := s.modMax(px+cx), s.modMax(py+cy)
+	if canonicalized && !bottomLeft {
+		x, y = dracoRotate(x, y, (4-rotation)%4)
+	}
+	if !inDiamond {
+		x, y = s.invertDiamond(x, y)
+	}
+	return x + s.center, y + s.center
+}
+func (s *dracoOctahedron) unitVector(qs, qt int32) [3]float32 {
+	y := float32(qs)*s.scale - 1
+	z := float32(qt)*s.scale - 1
+	x := 1 - float32(math.Abs(float64(y))) - float32(math.Abs(float64(z)))
+	offset := -x
+	if offset < 0 {
+		offset = 0
+	}
+	if y < 0 {
+		y += offset
+	} else {
+		y -= offset
+	}
+	if z < 0 {
+		z += offset
+	} else {
+		z -= offset
+	}
+	norm := x*x + y*y + z*z
+	if norm < 1e-6 {
+		return [3]float32{}
+	}
+	d := float32(1 / math.Sqrt(float64(norm)))
+	return [3]float32{x * d, y * d, z * d}
+}
+
+// canonicalizeVector scale integer vector, so its absolute sum is center
+func (s *dracoOctahedron) canonicalizeVector(v *[3]int32) {
+	sum := int64(abs32(v[0])) + int64(abs32(v[1])) + int64(abs32(v[2]))
+	if sum == 0 {
+		v[0] = s.center
+		return
+	}
+	v[0] = int32(int64(v[0]) * int64(s.center) / sum)
+	v[1] = int32(int64(v[1]) * int64(s.center) / sum)
+	rest := s.center - abs32(v[0]) - abs32(v[1])
+	if v[2] < 0 {
+		rest = -rest
+	}
+	v[2] = rest
+}
+
+// octahedralCoords convert canonicalized integer vector to octahedral coordinates
+func (s *dracoOctahedron) octahedralCoords(v [3]int32) (int32, int32) {
+	var (
+		max  = s.maxQuantized - 1
+		x, y int32
+	)
+	if v[0] >= 0 {
+		x, y = v[1]+s.center, v[2]+s.center
+	} else {
+		x, y = max-abs32(v[2]), max-abs32(v[1])
+		if v[1] < 0 {
+			x = abs32(v[2])
+		}
+		if v[2] < 0 {
+			y = abs32(v[1])
+		}
+	}
+	// coordinates on border are canonicalized
+	switch {
+	case x == 0 && y == 0, x == 0 && y == max, x == max && y == 0:
+		x, y = max, max
+	case x == 0 && y > s.center:
+		y = s.center - (y - s.center)
+	case x == max && y < s.center:
+		y = s.center + (s.center - y)
+	case y == max && x < s.center:
+		x = s.center + (s.center - x)
+	case y == 0 && x > s.center:
+		x = s.center - (x - s.center)
+	}
+	return x, y
+}
+func dracoRotate(x, y int32, rotation int) (int32, int32) {
+	switch rotation {
+	case 1:
+		return y, -x
+	case 2:
+		return -x, -y
+	case 3:
+		return -y, x
+	}
+	return x, y
+}
+func abs32(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func skipDracoMetadata(buffer *dracoBuffer) error {
+	n, err := buffer.varint()
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < n; i++ {
+		if _, err = buffer.varint(); err != nil {
+			return err
+		}
+		if err = skipDracoMetadataElement(buffer, 0); err != nil {
+			return err
+		}
+	}
+	return skipDracoMetadataElement(buffer, 0)
+}
+func skipDracoMetadataElement(buffer *dracoBuffer, depth int) error {
+	if depth > 32 {
+		return errors.New("Draco metadata too deep")
+	}
+	entries, err := buffer.varint()
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < entries; i++ {
+		if err = buffer.skipName(); err != nil {
+			return err
+		}
+		size, err := buffer.varint()
+		if err != nil {
+			return err
+		}
+		if size > uint64(len(buffer.data)) {
+			return errDracoTruncated
+		}
+		if _, err = buffer.next(int(size)); err != nil {
+			return err
+		}
+	}
+	subs, err := buffer.varint()
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < subs; i++ {
+		if err = buffer.skipName(); err != nil {
+			return err
+		}
+		if err = skipDracoMetadataElement(buffer, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeDracoSymbols read n symbols, tagged symbol share bit length for each components
+func decodeDracoSymbols(buffer *dracoBuffer, n, components int) ([]uint32, error) {
+	res := make([]uint32, n)
+	if n == 0 {
+		return res, nil
+	}
+	scheme, err := buffer.u8()
+	if err != nil {
+		return nil, err
+	}
+	switch scheme {
+	case dracoSymbolTagged:
+		tags, err := newDracoRANS(buffer, 5)
+		if err != nil {
+			return nil, err
+		}
+		bits := &dracoBitReader{data: buffer.data[buffer.pos:]}
+		for i := 0; i < n; i += components {
+			length := tags.read()
+			if length > 32 {
+				return nil, errors.Errorf("Draco tagged symbol bit length %d invalid", length)
+			}
+			for j := 0; j < components && i+j < n; j++ {
+				if res[i+j], err = bits.read(int(length)); err != nil {
+					return nil, err
+				}
+			}
+		}
+		buffer.pos += (bits.bit + 7) / 8
+	case dracoSymbolRaw:
+		length, err := buffer.u8()
+		if err != nil {
+			return nil, err
+		}
+		if length < 1 || length > 18 {
+			return nil, errors.Errorf("Draco raw symbol bit length %d invalid", length)
+		}
+		symbols, err := newDracoRANS(buffer, int(length))
+		if err != nil {
+			return nil, err
+		}
+		for i := range res {
+			res[i] = symbols.read()
+		}
+	default:
+		return nil, errors.Errorf("Draco symbol coding %d unknown", scheme)
+	}
+	return res, nil
+}
+
+// dracoRANS is rANS symbol decoder
+type dracoRANS struct {
+	precision uint32
+	probs     []uint32
+	cums      []uint32
+	lut       []uint32
+	data      []byte
+	offset    int
+	state     uint32
+}
+
+// newDracoRANS read probability table and rANS data, precision is decided by bit length of unique symbols
+func newDracoRANS(buffer *dracoBuffer, bitLength int) (*dracoRANS, error) {
+	precision := 3 * bitLength / 2
+	if precision < 12 {
+		precision = 12
+	} else if precision > 20 {
+		precision = 20
+	}
+	res := &dracoRANS{precision: 1 << uint(precision)}
+	n, err := buffer.varint()
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, errors.New("Draco rANS has no symbol")
+	}
+	if n > uint64(res.precision) {
+		return nil, errors.Errorf("Draco rANS has too many symbols %d", n)
+	}
+	res.probs = make([]uint32, n)
+	res.cums = make([]uint32, n)
+	for i := uint64(0); i < n; i++ {
+		b, err := buffer.u8()
+		if err != nil {
+			return nil, err
+		}
+		token := b & 3
+		if token == 3 {
+			// run length of zero probability
+			offset := uint64(b >> 2)
+			if i+offset >= n {
+				return nil, errors.New("Draco rANS zero probability run out of symbols")
+			}
+			i += offset
+			continue
+		}
+		prob := uint32(b >> 2)
+		for e := 0; e < int(token); e++ {
+			extra, err := buffer.u8()
+			if err != nil {
+				return nil, err
+			}
+			prob |= uint32(extra) << uint(8*(e+1)-2)
+		}
+		res.probs[i] = prob
+	}
+	res.lut = make([]uint32, res.precision)
+	var cum uint32
+	for i, prob := range res.probs {
+		res.cums[i] = cum
+		if prob > res.precision-cum {
+			return nil, errors.New("Draco rANS probability overflow")
+		}
+		for j := cum; j < cum+prob; j++ {
+			res.lut[j] = uint32(i)
+		}
+		cum += prob
+	}
+	if cum != res.precision {
+		return nil, errors.Errorf("Draco rANS probability sum %d, but precision %d", cum, res.precision)
+	}
+	size, err := buffer.varint()
+	if err != nil {
+		return nil, err
+	}
+	if size < 1 || size > uint64(len(buffer.data)) {
+		return nil, errDracoTruncated
+	}
+	if res.data, err = buffer.next(int(size)); err != nil {
+		return nil, err
+	}
+	last := res.data[size-1]
+	need := int(last>>6) + 1
+	if need > len(res.data) {
+		return nil, errDracoTruncated
+	}
+	res.offset = len(res.data) - need
+	for i := need - 1; i >= 0; i-- {
+		res.state = res.state<<8 | uint32(res.data[res.offset+i])
+	}
+	res.state &= 1<<uint(8*need-2) - 1
+	res.state += 4 * res.precision
+	if res.state >= 4*res.precision*256 {
+		return nil, errors.New("Draco rANS initial state invalid")
+	}
+	return res, nil
+}
+func (s *dracoRANS) read() uint32 {
+	for s.state < 4*s.precision && s.offset > 0 {
+		s.offset--
+		s.state = s.state<<8 | uint32(s.data[s.offset])
+	}
+	quo, rem := s.state/s.precision, s.state%s.precision
+	symbol := s.lut[rem]
+	s.state = quo*s.probs[symbol] + rem - s.cums[symbol]
+	return symbol
+}
+
+// dracoBitReader read bits from least significant bit of each byte
+type dracoBitReader struct {
+	data []byte
+	bit  int
+}
+
+func (s *dracoBitReader) read(n int) (uint32, error) {
+	var res uint32
+	for i := 0; i < n; i++ {
+		if s.bit>>3 >= len(s.data) {
+			return 0, errDracoTruncated
+		}
+		res |= uint32(s.data[s.bit>>3]>>uint(s.bit&7)&1) << uint(i)
+		s.bit++
+	}
+	return res, nil
+}
+
+// dracoBuffer is little endian reader
+type dracoBuffer struct {
+	data []byte
+	pos  int
+}
+
+func (s *dracoBuffer) next(n int) ([]byte, error) {
+	if n < 0 || n > len(s.data)-s.pos {
+		return nil, errDracoTruncated
+	}
+	res := s.data[s.pos : s.pos+n]
+	s.pos += n
+	return res, nil
+}
+func (s *dracoBuffer) u8() (uint8, error) {
+	bts, err := s.next(1)
+	if err != nil {
+		return 0, err
+	}
+	return bts[0], nil
+}
+func (s *dracoBuffer) u16() (uint16, error) {
+	bts, err := s.next(2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(bts), nil
+}
+func (s *dracoBuffer) u32() (uint32, error) {
+	bts, err := s.next(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(bts), nil
+}
+func (s *dracoBuffer) f32() (float32, error) {
+	v, err := s.u32()
+	return math.Float32frombits(v), err
+}
+func (s *dracoBuffer) varint() (uint64, error) {
+	var res uint64
+	for shift := uint(0); shift < 64; shift += 7 {
+		b, err := s.u8()
+		if err != nil {
+			return 0, err
+		}
+		res |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return res, nil
+		}
+	}
+	return 0, errors.New("Draco varint overflow")
+}
+func (s *dracoBuffer) skipName() error {
+	size, err := s.u8()
+	if err != nil {
+		return err
+	}
+	_, err = s.next(int(size))
+	return err
+}
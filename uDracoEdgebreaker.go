@@ -0,0 +1,1112 @@
+package gltf2
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// Draco edgebreaker connectivity, standard and valence traversal
+//
+// Decoder create faces from reversed traversal symbols, so face, vertex, point order is same as draco decoder.
+const (
+	dracoTraversalStandard = 0
+	dracoTraversalValence  = 2
+	//
+	dracoTopologyC = 0
+	dracoTopologyS = 1
+	dracoTopologyL = 3
+	dracoTopologyR = 5
+	dracoTopologyE = 7
+	//
+	dracoMeshVertexAttribute = 0
+	dracoMeshCornerAttribute = 1
+	//
+	dracoTraversalDepthFirst       = 0
+	dracoTraversalPredictionDegree = 1
+	//
+	dracoValenceMin = 2
+	dracoValenceMax = 7
+)
+
+// valence traversal symbol to topology
+var dracoValenceTopology = [...]uint32{dracoTopologyC, dracoTopologyS, dracoTopologyL, dracoTopologyR, dracoTopologyE}
+
+var errDracoConnectivity = errors.New("Draco edgebreaker connectivity is invalid")
+
+// dracoTable is corner table, corner is index of mesh indices, -1 is invalid corner or vertex
+type dracoTable interface {
+	opposite(c int) int
+	vertex(c int) int
+	leftMost(v int) int
+	numVertices() int
+	numCorners() int
+}
+
+func dracoNext(c int) int {
+	if c < 0 {
+		return -1
+	}
+	if c%3 == 2 {
+		return c - 2
+	}
+	return c + 1
+}
+func dracoPrevious(c int) int {
+	if c < 0 {
+		return -1
+	}
+	if c%3 == 0 {
+		return c + 2
+	}
+	return c - 1
+}
+func dracoSwingLeft(t dracoTable, c int) int {
+	return dracoNext(t.opposite(dracoNext(c)))
+}
+func dracoSwingRight(t dracoTable, c int) int {
+	return dracoPrevious(t.opposite(dracoPrevious(c)))
+}
+func dracoOnBoundary(t dracoTable, v int) bool {
+	c := t.leftMost(v)
+	return c < 0 || dracoSwingLeft(t, c) < 0
+}
+
+// dracoVertexCorners call fn for corners around vertex of start, swing left first and swing right if boundary reached
+func dracoVertexCorners(t dracoTable, start int, fn func(c int) error) error {
+	var (
+		c    = start
+		left = true
+	)
+	for i := 0; c >= 0; i++ {
+		if i > t.numCorners() {
+			return errDracoConnectivity
+		}
+		if err := fn(c); err != nil {
+			return err
+		}
+		if !left {
+			c = dracoSwingRight(t, c)
+			continue
+		}
+		c = dracoSwingLeft(t, c)
+		if c < 0 {
+			c = dracoSwingRight(t, start)
+			left = false
+		} else if c == start {
+			c = -1
+		}
+	}
+	return nil
+}
+
+type dracoCornerTable struct {
+	// vertex of corner
+	vertices []int
+	// opposite corner of corner
+	opposites []int
+	// left most corner of vertex
+	corners []int
+}
+
+func (s *dracoCornerTable) opposite(c int) int {
+	if c < 0 {
+		return -1
+	}
+	return s.opposites[c]
+}
+func (s *dracoCornerTable) vertex(c int) int {
+	if c < 0 {
+		return -1
+	}
+	return s.vertices[c]
+}
+func (s *dracoCornerTable) leftMost(v int) int {
+	if v < 0 {
+		return -1
+	}
+	return s.corners[v]
+}
+func (s *dracoCornerTable) numVertices() int {
+	return len(s.corners)
+}
+func (s *dracoCornerTable) numCorners() int {
+	return len(s.vertices)
+}
+func (s *dracoCornerTable) setOpposite(a, b int) {
+	s.opposites[a] = b
+	s.opposites[b] = a
+}
+func (s *dracoCornerTable) setLeftMost(v, c int) {
+	if v >= 0 {
+		s.corners[v] = c
+	}
+}
+func (s *dracoCornerTable) addVertex() int {
+	s.corners = append(s.corners, -1)
+	return len(s.corners) - 1
+}
+
+// dracoAttributeTable is corner table of attribute, vertex is split by attribute seam edges
+type dracoAttributeTable struct {
+	base *dracoCornerTable
+	// corner is opposite to seam edge
+	seams []bool
+	// base vertex is on seam
+	onSeam   []bool
+	vertices []int
+	corners  []int
+}
+
+func newDracoAttributeTable(base *dracoCornerTable, seams []int) (*dracoAttributeTable, error) {
+	res := &dracoAttributeTable{
+		base:     base,
+		seams:    make([]bool, base.numCorners()),
+		onSeam:   make([]bool, base.numVertices()),
+		vertices: make([]int, base.numCorners()),
+	}
+	for i := range res.vertices {
+		res.vertices[i] = -1
+	}
+	mark := func(c int) {
+		res.seams[c] = true
+		for _, v := range [...]int{base.vertex(dracoNext(c)), base.vertex(dracoPrevious(c))} {
+			if v >= 0 {
+				res.onSeam[v] = true
+			}
+		}
+	}
+	for _, c := range seams {
+		mark(c)
+		if opp := base.opposite(c); opp >= 0 {
+			mark(opp)
+		}
+	}
+	for v := range base.corners {
+		c := base.leftMost(v)
+		if c < 0 {
+			continue
+		}
+		var (
+			vertex = len(res.corners)
+			first  = c
+		)
+		if res.onSeam[v] {
+			// first corner of seam in counter clockwise
+			for i, act := 0, dracoSwingLeft(res, first); act >= 0; i++ {
+				first = act
+				act = dracoSwingLeft(res, act)
+				if act == c || i > len(res.vertices) {
+					return nil, errDracoConnectivity
+				}
+			}
+		}
+		res.vertices[first] = vertex
+		res.corners = append(res.corners, first)
+		for i, act := 0, dracoSwingRight(base, first); act >= 0 && act != first; i, act = i+1, dracoSwingRight(base, act) {
+			if i > len(res.vertices) {
+				return nil, errDracoConnectivity
+			}
+			if res.seams[dracoNext(act)] {
+				vertex = len(res.corners)
+				res.corners = append(res.corners, act)
+			}
+			res.vertices[act] = vertex
+		}
+	}
+	return res, nil
+}
+func (s *dracoAttributeTable) opposite(c int) int {
+	if c < 0 || s.seams[c] {
+		return -1
+	}
+	return s.base.opposites[c]
+}
+func (s *dracoAttributeTable) vertex(c int) int {
+	if c < 0 {
+		return -1
+	}
+	return s.vertices[c]
+}
+func (s *dracoAttributeTable) leftMost(v int) int {
+	if v < 0 {
+		return -1
+	}
+	return s.corners[v]
+}
+func (s *dracoAttributeTable) numVertices() int {
+	return len(s.corners)
+}
+func (s *dracoAttributeTable) numCorners() int {
+	return len(s.vertices)
+}
+
+// dracoEncoding is decoding order of attribute values
+type dracoEncoding struct {
+	vertexToEntry []int
+	entryToCorner []int
+}
+
+// dracoAttributeData is connectivity of attribute which has seams
+type dracoAttributeData struct {
+	decoder  int
+	seams    []int
+	table    *dracoAttributeTable
+	encoding dracoEncoding
+}
+
+// dracoTopologySplit is split event, symbol id is encoder order
+type dracoTopologySplit struct {
+	source, split int
+	// 1 is right face edge, 0 is left face edge
+	edge uint32
+}
+
+type dracoEdgebreaker struct {
+	mesh  *dracoMesh
+	table *dracoCornerTable
+	// vertex is on boundary
+	hole   []bool
+	splits []dracoTopologySplit
+	data   []*dracoAttributeData
+	// position is encoding of attributes decoder without attribute data
+	position        dracoEncoding
+	positionDecoder int
+	// standard traversal symbols
+	symbols *dracoBitReader
+	// valence traversal symbols of each context, processed from back
+	valence  bool
+	contexts [][]uint32
+	active   int
+	last     uint32
+	valences []int
+	//
+	starts *dracoRABS
+	seams  []*dracoRABS
+}
+
+// edgebreaker decode edgebreaker connectivity, mesh indices, number of points are decided
+func (s *dracoMesh) edgebreaker(buffer *dracoBuffer) (*dracoEdgebreaker, error) {
+	traversal, err := buffer.u8()
+	if err != nil {
+		return nil, err
+	}
+	if traversal != dracoTraversalStandard && traversal != dracoTraversalValence {
+		return nil, errors.Errorf("Draco edgebreaker traversal %d not supported, only standard and valence", traversal)
+	}
+	numVertices, err := buffer.varint()
+	if err != nil {
+		return nil, err
+	}
+	numFaces, err := buffer.varint()
+	if err != nil {
+		return nil, err
+	}
+	numData, err := buffer.u8()
+	if err != nil {
+		return nil, err
+	}
+	numSymbols, err := buffer.varint()
+	if err != nil {
+		return nil, err
+	}
+	numSplitSymbols, err := buffer.varint()
+	if err != nil {
+		return nil, err
+	}
+	if numFaces > math.MaxInt32/3 {
+		return nil, errors.Errorf("Draco mesh too large, %d faces", numFaces)
+	}
+	if numSymbols > numFaces || numFaces > numSymbols+numSymbols/3 || numSplitSymbols > numSymbols || numVertices > 3*numFaces {
+		return nil, errors.Errorf("Draco edgebreaker %d faces, %d vertices, %d symbols, %d split symbols invalid", numFaces, numVertices, numSymbols, numSplitSymbols)
+	}
+	var (
+		corners     = int(numFaces) * 3
+		maxVertices = int(numVertices + numSplitSymbols)
+		res         = &dracoEdgebreaker{
+			mesh: s,
+			table: &dracoCornerTable{
+				vertices:  make([]int, corners),
+				opposites: make([]int, corners),
+				corners:   make([]int, 0, maxVertices),
+			},
+			hole:            make([]bool, maxVertices),
+			data:            make([]*dracoAttributeData, numData),
+			positionDecoder: -1,
+			active:          -1,
+		}
+	)
+	for i := 0; i < corners; i++ {
+		res.table.vertices[i] = -1
+		res.table.opposites[i] = -1
+	}
+	for i := range res.hole {
+		res.hole[i] = true
+	}
+	for i := range res.data {
+		res.data[i] = &dracoAttributeData{decoder: -1}
+	}
+	if err = res.decodeSplits(buffer, numFaces); err != nil {
+		return nil, err
+	}
+	if traversal == dracoTraversalStandard {
+		size, err := buffer.varint()
+		if err != nil {
+			return nil, err
+		}
+		if size > uint64(len(buffer.data)) {
+			return nil, errDracoTruncated
+		}
+		bits, err := buffer.next(int(size))
+		if err != nil {
+			return nil, err
+		}
+		res.symbols = &dracoBitReader{data: bits}
+	}
+	if res.starts, err = newDracoRABS(buffer); err != nil {
+		return nil, err
+	}
+	res.seams = make([]*dracoRABS, numData)
+	for i := range res.seams {
+		if res.seams[i], err = newDracoRABS(buffer); err != nil {
+			return nil, err
+		}
+	}
+	if traversal == dracoTraversalValence {
+		res.valence = true
+		res.valences = make([]int, maxVertices)
+		res.contexts = make([][]uint32, dracoValenceMax-dracoValenceMin+1)
+		for i := range res.contexts {
+			n, err := buffer.varint()
+			if err != nil {
+				return nil, err
+			}
+			if n > numFaces {
+				return nil, errors.Errorf("Draco valence context has %d symbols, but %d faces", n, numFaces)
+			}
+			if res.contexts[i], err = decodeDracoSymbols(buffer, int(n), 1); err != nil {
+				return nil, err
+			}
+		}
+	}
+	numConnectivity, err := res.connectivity(int(numSymbols), maxVertices)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.data) > 0 {
+		for c := 0; c < corners; c += 3 {
+			res.decodeSeams(c)
+		}
+	}
+	res.position.init(res.table.numVertices())
+	for _, data := range res.data {
+		if data.table, err = newDracoAttributeTable(res.table, data.seams); err != nil {
+			return nil, err
+		}
+		n := data.table.numVertices()
+		if n < res.table.numVertices() {
+			n = res.table.numVertices()
+		}
+		data.encoding.init(n)
+	}
+	if err = res.assignPoints(numConnectivity); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *dracoEdgebreaker) decodeSplits(buffer *dracoBuffer, numFaces uint64) error {
+	n, err := buffer.varint()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return nil
+	}
+	if n > numFaces {
+		return errors.Errorf("Draco edgebreaker %d topology splits, but %d faces", n, numFaces)
+	}
+	s.splits = make([]dracoTopologySplit, n)
+	var last uint64
+	for i := range s.splits {
+		source, err := buffer.varint()
+		if err != nil {
+			return err
+		}
+		source += last
+		delta, err := buffer.varint()
+		if err != nil {
+			return err
+		}
+		if delta > source || source > math.MaxInt32 {
+			return errors.Errorf("Draco edgebreaker topology split %d invalid", i)
+		}
+		s.splits[i].source, s.splits[i].split = int(source), int(source-delta)
+		last = source
+	}
+	bits := &dracoBitReader{data: buffer.data[buffer.pos:]}
+	for i := range s.splits {
+		if s.splits[i].edge, err = bits.read(1); err != nil {
+			return err
+		}
+	}
+	buffer.pos += (bits.bit + 7) / 8
+	return nil
+}
+
+// topologySplit pop split event of encoder symbol id
+func (s *dracoEdgebreaker) topologySplit(symbol int) (edge uint32, split int, ok bool, err error) {
+	if len(s.splits) == 0 {
+		return 0, 0, false, nil
+	}
+	last := s.splits[len(s.splits)-1]
+	if last.source > symbol {
+		return 0, 0, false, errDracoConnectivity
+	}
+	if last.source != symbol {
+		return 0, 0, false, nil
+	}
+	s.splits = s.splits[:len(s.splits)-1]
+	return last.edge, last.split, true, nil
+}
+
+func (s *dracoEdgebreaker) symbol() (uint32, error) {
+	if !s.valence {
+		symbol, err := s.symbols.read(1)
+		if err != nil || symbol == dracoTopologyC {
+			return symbol, err
+		}
+		suffix, err := s.symbols.read(2)
+		return symbol | suffix<<1, err
+	}
+	if s.active < 0 {
+		// first symbol is always E
+		s.last = dracoTopologyE
+		return s.last, nil
+	}
+	context := s.contexts[s.active]
+	if len(context) == 0 {
+		return 0, errors.New("Draco valence context has no more symbol")
+	}
+	symbol := context[len(context)-1]
+	s.contexts[s.active] = context[:len(context)-1]
+	if int(symbol) >= len(dracoValenceTopology) {
+		return 0, errors.Errorf("Draco valence symbol %d unknown", symbol)
+	}
+	s.last = dracoValenceTopology[symbol]
+	return s.last, nil
+}
+
+// activeCorner update valences and context of valence traversal
+func (s *dracoEdgebreaker) activeCorner(c int) {
+	if !s.valence {
+		return
+	}
+	var (
+		t          = s.table
+		v          = t.vertex(c)
+		next, prev = t.vertex(dracoNext(c)), t.vertex(dracoPrevious(c))
+	)
+	switch s.last {
+	case dracoTopologyC, dracoTopologyS:
+		s.valences[next]++
+		s.valences[prev]++
+	case dracoTopologyR:
+		s.valences[v]++
+		s.valences[next]++
+		s.valences[prev] += 2
+	case dracoTopologyL:
+		s.valences[v]++
+		s.valences[next] += 2
+		s.valences[prev]++
+	case dracoTopologyE:
+		s.valences[v] += 2
+		s.valences[next] += 2
+		s.valences[prev] += 2
+	}
+	valence := s.valences[next]
+	if valence < dracoValenceMin {
+		valence = dracoValenceMin
+	} else if valence > dracoValenceMax {
+		valence = dracoValenceMax
+	}
+	s.active = valence - dracoValenceMin
+}
+
+// connectivity decode symbols to corner table, it returns number of connectivity vertices
+func (s *dracoEdgebreaker) connectivity(numSymbols, maxVertices int) (int, error) {
+	var (
+		t        = s.table
+		active   []int
+		splits   = make(map[int]int)
+		invalids []int
+		numFaces int
+	)
+	for id := 0; id < numSymbols; id++ {
+		var (
+			corner = 3 * numFaces
+			split  bool
+		)
+		numFaces++
+		symbol, err := s.symbol()
+		if err != nil {
+			return 0, err
+		}
+		switch symbol {
+		case dracoTopologyC:
+			if len(active) == 0 {
+				return 0, errDracoConnectivity
+			}
+			a := active[len(active)-1]
+			x := t.vertex(dracoNext(a))
+			b := dracoNext(t.leftMost(x))
+			if a == b || b < 0 || t.opposite(a) >= 0 || t.opposite(b) >= 0 {
+				return 0, errDracoConnectivity
+			}
+			t.setOpposite(a, corner+1)
+			t.setOpposite(b, corner+2)
+			aPrev, bNext := t.vertex(dracoPrevious(a)), t.vertex(dracoNext(b))
+			if x == aPrev || x == bNext {
+				return 0, errDracoConnectivity
+			}
+			t.vertices[corner], t.vertices[corner+1], t.vertices[corner+2] = x, bNext, aPrev
+			t.setLeftMost(aPrev, corner+2)
+			s.hole[x] = false
+			active[len(active)-1] = corner
+		case dracoTopologyR, dracoTopologyL:
+			if len(active) == 0 {
+				return 0, errDracoConnectivity
+			}
+			a := active[len(active)-1]
+			if t.opposite(a) >= 0 {
+				return 0, errDracoConnectivity
+			}
+			opp, l, r := corner+1, corner, corner+2
+			if symbol == dracoTopologyR {
+				opp, l, r = corner+2, corner+1, corner
+			}
+			t.setOpposite(opp, a)
+			v := t.addVertex()
+			if t.numVertices() > maxVertices {
+				return 0, errDracoConnectivity
+			}
+			t.vertices[opp] = v
+			t.setLeftMost(v, opp)
+			vr := t.vertex(dracoPrevious(a))
+			t.vertices[r] = vr
+			t.setLeftMost(vr, r)
+			t.vertices[l] = t.vertex(dracoNext(a))
+			active[len(active)-1] = corner
+			split = true
+		case dracoTopologyS:
+			if len(active) == 0 {
+				return 0, errDracoConnectivity
+			}
+			b := active[len(active)-1]
+			active = active[:len(active)-1]
+			if c, ok := splits[id]; ok {
+				active = append(active, c)
+			}
+			if len(active) == 0 {
+				return 0, errDracoConnectivity
+			}
+			a := active[len(active)-1]
+			if a == b || t.opposite(a) >= 0 || t.opposite(b) >= 0 {
+				return 0, errDracoConnectivity
+			}
+			t.setOpposite(a, corner+2)
+			t.setOpposite(b, corner+1)
+			p := t.vertex(dracoPrevious(a))
+			t.vertices[corner] = p
+			t.vertices[corner+1] = t.vertex(dracoNext(a))
+			bPrev := t.vertex(dracoPrevious(b))
+			t.vertices[corner+2] = bPrev
+			t.setLeftMost(bPrev, corner+2)
+			n := dracoNext(b)
+			vn := t.vertex(n)
+			if p < 0 || vn < 0 {
+				return 0, errDracoConnectivity
+			}
+			if s.valence {
+				s.valences[p] += s.valences[vn]
+			}
+			t.setLeftMost(p, t.leftMost(vn))
+			// vertex n is merged to vertex p
+			for first := n; n >= 0; {
+				t.vertices[n] = p
+				if n = dracoSwingLeft(t, n); n == first {
+					return 0, errDracoConnectivity
+				}
+			}
+			t.setLeftMost(vn, -1)
+			if len(s.data) == 0 {
+				invalids = append(invalids, vn)
+			}
+			active[len(active)-1] = corner
+		case dracoTopologyE:
+			v := t.addVertex()
+			t.vertices[corner] = v
+			t.vertices[corner+1] = t.addVertex()
+			t.vertices[corner+2] = t.addVertex()
+			if t.numVertices() > maxVertices {
+				return 0, errDracoConnectivity
+			}
+			t.setLeftMost(v, corner)
+			t.setLeftMost(v+1, corner+1)
+			t.setLeftMost(v+2, corner+2)
+			active = append(active, corner)
+			split = true
+		default:
+			return 0, errors.Errorf("Draco edgebreaker symbol %d unknown", symbol)
+		}
+		s.activeCorner(active[len(active)-1])
+		if !split {
+			continue
+		}
+		// faces of L, R, E can be connected to S face by topology split event
+		encoderID := numSymbols - id - 1
+		for {
+			edge, encoderSplit, ok, err := s.topologySplit(encoderID)
+			if err != nil {
+				return 0, err
+			}
+			if !ok {
+				break
+			}
+			top := active[len(active)-1]
+			if edge == 1 {
+				splits[numSymbols-encoderSplit-1] = dracoNext(top)
+			} else {
+				splits[numSymbols-encoderSplit-1] = dracoPrevious(top)
+			}
+		}
+	}
+	// start faces, interior start face is connected to faces of active corners
+	for len(active) > 0 {
+		c := active[len(active)-1]
+		active = active[:len(active)-1]
+		if !s.starts.read() {
+			continue
+		}
+		if numFaces >= t.numCorners()/3 {
+			return 0, errDracoConnectivity
+		}
+		n := t.vertex(dracoNext(c))
+		b := dracoNext(t.leftMost(n))
+		x := t.vertex(dracoNext(b))
+		cc := dracoNext(t.leftMost(x))
+		if b < 0 || cc < 0 || c == b || c == cc || b == cc || t.opposite(c) >= 0 || t.opposite(b) >= 0 || t.opposite(cc) >= 0 {
+			return 0, errDracoConnectivity
+		}
+		p := t.vertex(dracoNext(cc))
+		corner := 3 * numFaces
+		numFaces++
+		t.setOpposite(corner, c)
+		t.setOpposite(corner+1, b)
+		t.setOpposite(corner+2, cc)
+		t.vertices[corner], t.vertices[corner+1], t.vertices[corner+2] = x, p, n
+		for _, v := range t.vertices[corner : corner+3] {
+			s.hole[v] = false
+		}
+	}
+	if numFaces != t.numCorners()/3 {
+		return 0, errors.Errorf("Draco edgebreaker decoded %d faces, but %d expected", numFaces, t.numCorners()/3)
+	}
+	// isolated vertex is swapped with last valid vertex, only if there is no attribute data
+	numVertices := t.numVertices()
+	for _, invalid := range invalids {
+		src := numVertices - 1
+		for t.leftMost(src) < 0 {
+			numVertices--
+			src = numVertices - 1
+		}
+		if src < invalid {
+			continue
+		}
+		err := dracoVertexCorners(t, t.leftMost(src), func(c int) error {
+			if t.vertices[c] != src {
+				return errDracoConnectivity
+			}
+			t.vertices[c] = invalid
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+		t.setLeftMost(invalid, t.leftMost(src))
+		t.setLeftMost(src, -1)
+		s.hole[invalid], s.hole[src] = s.hole[src], false
+		numVertices--
+	}
+	return numVertices, nil
+}
+
+// decodeSeams decode attribute seams of face, boundary edge is always seam
+func (s *dracoEdgebreaker) decodeSeams(face int) {
+	for c := face; c < face+3; c++ {
+		opp := s.table.opposite(c)
+		if opp < 0 {
+			for _, data := range s.data {
+				data.seams = append(data.seams, c)
+			}
+			continue
+		}
+		if opp/3 < face/3 {
+			continue
+		}
+		for i, data := range s.data {
+			if s.seams[i].read() {
+				data.seams = append(data.seams, c)
+			}
+		}
+	}
+}
+
+// assignPoints make mesh indices, corners of vertex share point if all attributes are not split by seam
+func (s *dracoEdgebreaker) assignPoints(numVertices int) error {
+	var (
+		t       = s.table
+		indices = make([]uint32, t.numCorners())
+		points  int
+	)
+	s.mesh.indices = indices
+	if len(s.data) == 0 {
+		for c, v := range t.vertices {
+			indices[c] = uint32(v)
+		}
+		s.mesh.numPoints = numVertices
+		return nil
+	}
+	for v := range t.corners {
+		c := t.leftMost(v)
+		if c < 0 {
+			continue
+		}
+		first := c
+		if !s.hole[v] {
+			// interior vertex start from first seam of any attribute
+		find:
+			for _, data := range s.data {
+				if !data.table.onSeam[v] {
+					continue
+				}
+				vertex := data.table.vertex(c)
+				for i, act := 0, dracoSwingRight(t, c); act != c; i, act = i+1, dracoSwingRight(t, act) {
+					if act < 0 || i > len(indices) {
+						return errDracoConnectivity
+					}
+					if data.table.vertex(act) != vertex {
+						first = act
+						break find
+					}
+				}
+			}
+		}
+		indices[first] = uint32(points)
+		points++
+		prev := first
+		for i, c := 0, dracoSwingRight(t, first); c >= 0 && c != first; i, c = i+1, dracoSwingRight(t, c) {
+			if i > len(indices) {
+				return errDracoConnectivity
+			}
+			seam := false
+			for _, data := range s.data {
+				if data.table.vertex(c) != data.table.vertex(prev) {
+					seam = true
+					break
+				}
+			}
+			if seam {
+				indices[c] = uint32(points)
+				points++
+			} else {
+				indices[c] = indices[prev]
+			}
+			prev = c
+		}
+	}
+	s.mesh.numPoints = points
+	return nil
+}
+
+// attributesDecoder read connectivity of attributes decoder, and make its decoding sequence
+func (s *dracoEdgebreaker) attributesDecoder(buffer *dracoBuffer, id int) (*dracoSequence, error) {
+	bts, err := buffer.next(3)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		dataID      = int8(bts[0])
+		decoderType = bts[1]
+		method      = bts[2]
+		data        *dracoAttributeData
+		res         = &dracoSequence{table: s.table, encoding: &s.position}
+	)
+	if method > dracoTraversalPredictionDegree {
+		return nil, errors.Errorf("Draco traversal method %d unknown", method)
+	}
+	if dataID >= 0 {
+		if int(dataID) >= len(s.data) || s.data[dataID].decoder >= 0 {
+			return nil, errors.Errorf("Draco attribute data %d invalid for attributes decoder %d", dataID, id)
+		}
+		data = s.data[dataID]
+		data.decoder = id
+		res.encoding = &data.encoding
+	} else {
+		if s.positionDecoder >= 0 {
+			return nil, errors.Errorf("Draco attributes decoder %d, %d both use position connectivity", s.positionDecoder, id)
+		}
+		s.positionDecoder = id
+	}
+	// per vertex attribute use position connectivity, per corner attribute use attribute connectivity
+	if decoderType != dracoMeshVertexAttribute {
+		if method != dracoTraversalDepthFirst || data == nil {
+			return nil, errors.Errorf("Draco attributes decoder %d, per corner attribute need depth first traversal and attribute data", id)
+		}
+		res.table = data.table
+	}
+	if err = s.traverse(res, method == dracoTraversalPredictionDegree); err != nil {
+		return nil, err
+	}
+	// point to value mapping
+	res.entries = make([]int, s.mesh.numPoints)
+	for c, point := range s.mesh.indices {
+		v := res.table.vertex(c)
+		if v < 0 {
+			return nil, errDracoConnectivity
+		}
+		entry := res.encoding.vertexToEntry[v]
+		if int(point) >= len(res.entries) || entry >= len(res.points) {
+			return nil, errDracoConnectivity
+		}
+		res.entries[point] = entry
+	}
+	return res, nil
+}
+
+// traverse visit vertices by depth first or max prediction degree traversal, visited order is decoding order
+func (s *dracoEdgebreaker) traverse(seq *dracoSequence, degree bool) error {
+	var (
+		t             = seq.table
+		encoding      = seq.encoding
+		visitedFaces  = make([]bool, t.numCorners()/3)
+		visitedVertex = make([]bool, t.numVertices())
+	)
+	faceVisited := func(c int) bool {
+		return c < 0 || visitedFaces[c/3]
+	}
+	visit := func(c int) error {
+		v := t.vertex(c)
+		if v < 0 {
+			return errDracoConnectivity
+		}
+		if visitedVertex[v] {
+			return nil
+		}
+		visitedVertex[v] = true
+		seq.points = append(seq.points, int(s.mesh.indices[c]))
+		encoding.entryToCorner = append(encoding.entryToCorner, c)
+		encoding.vertexToEntry[v] = len(encoding.entryToCorner) - 1
+		return nil
+	}
+	if degree {
+		return dracoDegreeTraversal(t, visitedFaces, visitedVertex, faceVisited, visit)
+	}
+	for f := range visitedFaces {
+		c := 3 * f
+		if faceVisited(c) {
+			continue
+		}
+		if err := visit(dracoNext(c)); err != nil {
+			return err
+		}
+		if err := visit(dracoPrevious(c)); err != nil {
+			return err
+		}
+		stack := []int{c}
+		for len(stack) > 0 {
+			c = stack[len(stack)-1]
+			if faceVisited(c) {
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			for {
+				if c < 0 {
+					return errDracoConnectivity
+				}
+				visitedFaces[c/3] = true
+				v := t.vertex(c)
+				if v < 0 {
+					return errDracoConnectivity
+				}
+				if !visitedVertex[v] {
+					boundary := dracoOnBoundary(t, v)
+					if err := visit(c); err != nil {
+						return err
+					}
+					if !boundary {
+						c = t.opposite(dracoNext(c))
+						continue
+					}
+				}
+				right, left := t.opposite(dracoNext(c)), t.opposite(dracoPrevious(c))
+				if faceVisited(right) {
+					if faceVisited(left) {
+						stack = stack[:len(stack)-1]
+						break
+					}
+					c = left
+				} else {
+					if faceVisited(left) {
+						c = right
+						continue
+					}
+					// right face first, left face later
+					stack[len(stack)-1] = left
+					stack = append(stack, right)
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// dracoDegreeTraversal prefer face whose tip vertex has higher prediction degree
+func dracoDegreeTraversal(t dracoTable, visitedFaces, visitedVertex []bool, faceVisited func(c int) bool, visit func(c int) error) error {
+	const maxPriority = 3
+	var (
+		stacks  [maxPriority][]int
+		best    int
+		degrees = make([]int, t.numVertices())
+	)
+	priority := func(c int) int {
+		v := t.vertex(c)
+		if v < 0 || visitedVertex[v] {
+			return 0
+		}
+		degrees[v]++
+		if degrees[v] > 1 {
+			return 1
+		}
+		return 2
+	}
+	push := func(c, p int) {
+		stacks[p] = append(stacks[p], c)
+		if p < best {
+			best = p
+		}
+	}
+	pop := func() int {
+		for i := best; i < maxPriority; i++ {
+			if n := len(stacks[i]); n > 0 {
+				c := stacks[i][n-1]
+				stacks[i] = stacks[i][:n-1]
+				best = i
+				return c
+			}
+		}
+		return -1
+	}
+	for f := range visitedFaces {
+		c := 3 * f
+		stacks[0] = append(stacks[0], c)
+		best = 0
+		for _, v := range [...]int{dracoNext(c), dracoPrevious(c), c} {
+			if err := visit(v); err != nil {
+				return err
+			}
+		}
+		for c = pop(); c >= 0; c = pop() {
+			if faceVisited(c) {
+				continue
+			}
+			for {
+				visitedFaces[c/3] = true
+				if err := visit(c); err != nil {
+					return err
+				}
+				right, left := t.opposite(dracoNext(c)), t.opposite(dracoPrevious(c))
+				rightVisited := faceVisited(right)
+				if !faceVisited(left) {
+					p := priority(left)
+					if rightVisited && p <= best {
+						c = left
+						continue
+					}
+					push(left, p)
+				}
+				if !rightVisited {
+					p := priority(right)
+					if p <= best {
+						c = right
+						continue
+					}
+					push(right, p)
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (s *dracoEncoding) init(numVertices int) {
+	s.vertexToEntry = make([]int, numVertices)
+	s.entryToCorner = make([]int, 0, numVertices)
+}
+
+// dracoRABS is binary rANS decoder, probability of zero is zero/256
+type dracoRABS struct {
+	zero   uint32
+	data   []byte
+	offset int
+	state  uint32
+}
+
+func newDracoRABS(buffer *dracoBuffer) (*dracoRABS, error) {
+	zero, err := buffer.u8()
+	if err != nil {
+		return nil, err
+	}
+	size, err := buffer.varint()
+	if err != nil {
+		return nil, err
+	}
+	if size < 1 || size > uint64(len(buffer.data)) {
+		return nil, errDracoTruncated
+	}
+	res := &dracoRABS{zero: uint32(zero)}
+	if res.data, err = buffer.next(int(size)); err != nil {
+		return nil, err
+	}
+	need := int(res.data[size-1]>>6) + 1
+	if need > 3 || need > len(res.data) {
+		return nil, errors.New("Draco binary rANS initial state invalid")
+	}
+	res.offset = len(res.data) - need
+	for i := need - 1; i >= 0; i-- {
+		res.state = res.state<<8 | uint32(res.data[res.offset+i])
+	}
+	res.state &= 1<<uint(8*need-2) - 1
+	res.state += 4096
+	if res.state >= 4096*256 {
+		return nil, errors.New("Draco binary rANS initial state invalid")
+	}
+	return res, nil
+}
+func (s *dracoRABS) read() bool {
+	p := 256 - s.zero
+	if s.state < 4096 && s.offset > 0 {
+		s.offset--
+		s.state = s.state<<8 | uint32(s.data[s.offset])
+	}
+	quo, rem := s.state/256, s.state%256
+	xn := quo * p
+	if rem < p {
+		s.state = xn + rem
+		return true
+	}
+	s.state -= xn + p
+	return false
+}
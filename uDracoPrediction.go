@@ -0,0 +1,505 @@
+package gltf2
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// Draco prediction schemes, mesh prediction use decoded neighbor values on edgebreaker connectivity
+//
+// Sequential mesh has no connectivity for prediction, so every prediction is restored as difference like draco decoder.
+const dracoMaxParallelograms = 4
+
+type dracoPrediction struct {
+	method     int8
+	components int
+	seq        *dracoSequence
+	// parent attribute of texture coordinate, geometric normal prediction
+	parent   *dracoAttribute
+	original func(pred, corr, dst []int32)
+	box      *dracoOctahedron
+	// crease edges of constrained multi parallelogram, context is number of parallelograms - 1
+	creases [dracoMaxParallelograms][]bool
+	// orientations of texture coordinate prediction, processed from back
+	orientations []bool
+	// flip bits of geometric normal prediction
+	flips *dracoRABS
+}
+
+func newDracoPrediction(method, transform int8, seq *dracoSequence, components int, position *dracoAttribute) (*dracoPrediction, error) {
+	var (
+		octahedron = transform == dracoTransformNormalOctahedron || transform == dracoTransformNormalOctahedronCanonicalized
+		res        = &dracoPrediction{method: dracoPredictionDifference, components: components, seq: seq}
+	)
+	if seq.table != nil {
+		switch method {
+		case dracoPredictionParallelogram, dracoPredictionMultiParallelogram, dracoPredictionConstrainedMultiParallelogram,
+			dracoPredictionTexCoordsDeprecated, dracoPredictionTexCoordsPortable:
+			if !octahedron {
+				res.method = method
+			}
+		case dracoPredictionGeometricNormal:
+			if octahedron {
+				res.method = method
+			}
+		}
+	}
+	switch res.method {
+	case dracoPredictionTexCoordsDeprecated:
+		return nil, errors.New("Draco deprecated texture coordinate prediction not supported")
+	case dracoPredictionTexCoordsPortable, dracoPredictionGeometricNormal:
+		if position == nil || position.portable == nil || position.components != 3 {
+			return nil, errors.Errorf("Draco prediction %d need decoded integer position", method)
+		}
+		if components != 2 {
+			return nil, errors.Errorf("Draco prediction %d need 2 components, but got %d", method, components)
+		}
+		res.parent = position
+	}
+	return res, nil
+}
+
+// decodeData read prediction data and transform data
+func (s *dracoPrediction) decodeData(buffer *dracoBuffer, transform int8) (err error) {
+	switch s.method {
+	case dracoPredictionConstrainedMultiParallelogram:
+		for i := range s.creases {
+			n, err := buffer.varint()
+			if err != nil {
+				return err
+			}
+			if n > uint64(s.seq.table.numCorners()) {
+				return errors.Errorf("Draco %d crease edges, but %d corners", n, s.seq.table.numCorners())
+			}
+			if n == 0 {
+				continue
+			}
+			bits, err := newDracoRABS(buffer)
+			if err != nil {
+				return err
+			}
+			s.creases[i] = make([]bool, n)
+			for j := range s.creases[i] {
+				s.creases[i][j] = bits.read()
+			}
+		}
+	case dracoPredictionTexCoordsPortable:
+		n, err := buffer.u32()
+		if err != nil {
+			return err
+		}
+		if uint64(n) > uint64(len(s.seq.points)) {
+			return errors.Errorf("Draco %d texture coordinate orientations, but %d values", n, len(s.seq.points))
+		}
+		bits, err := newDracoRABS(buffer)
+		if err != nil {
+			return err
+		}
+		// orientation is delta coded, bit one is same as last
+		s.orientations = make([]bool, n)
+		last := true
+		for i := range s.orientations {
+			if !bits.read() {
+				last = !last
+			}
+			s.orientations[i] = last
+		}
+	}
+	if s.original, s.box, err = decodeDracoTransform(buffer, transform); err != nil {
+		return err
+	}
+	if s.method == dracoPredictionGeometricNormal {
+		s.flips, err = newDracoRABS(buffer)
+	}
+	return err
+}
+
+// restore compute original values from corrections in place
+func (s *dracoPrediction) restore(values []int32) error {
+	var (
+		c = s.components
+		n = len(values) / c
+	)
+	switch s.method {
+	case dracoPredictionDifference:
+		pred := make([]int32, c)
+		for i := 0; i < n; i++ {
+			v := values[i*c : (i+1)*c]
+			s.original(pred, v, v)
+			pred = v
+		}
+		return nil
+	case dracoPredictionTexCoordsPortable:
+		return s.restoreTexCoords(values)
+	case dracoPredictionGeometricNormal:
+		return s.restoreNormals(values)
+	}
+	if n == 0 {
+		return nil
+	}
+	var (
+		t     = s.seq.table
+		preds [dracoMaxParallelograms][]int32
+		pred  = make([]int32, c)
+		// position of crease edges for each context
+		creases [dracoMaxParallelograms]int
+	)
+	for i := range preds {
+		preds[i] = make([]int32, c)
+	}
+	s.original(make([]int32, c), values[:c], values[:c])
+	for p := 1; p < n; p++ {
+		var (
+			start = s.seq.encoding.entryToCorner[p]
+			used  int
+		)
+		switch s.method {
+		case dracoPredictionParallelogram:
+			if s.parallelogram(p, start, values, pred) {
+				used = 1
+			}
+		case dracoPredictionMultiParallelogram:
+			for i := range pred {
+				pred[i] = 0
+			}
+			for i, corner := 0, start; corner >= 0 && i <= t.numCorners(); i++ {
+				if s.parallelogram(p, corner, values, preds[0]) {
+					for j := range pred {
+						pred[j] += preds[0][j]
+					}
+					used++
+				}
+				if corner = dracoSwingRight(t, corner); corner == start {
+					break
+				}
+			}
+		case dracoPredictionConstrainedMultiParallelogram:
+			var (
+				num   int
+				first = true
+			)
+			// swing left first, swing right from start if boundary reached
+			for i, corner := 0, start; corner >= 0 && i <= t.numCorners(); i++ {
+				if s.parallelogram(p, corner, values, preds[num]) {
+					if num++; num == dracoMaxParallelograms {
+						break
+					}
+				}
+				if first {
+					corner = dracoSwingLeft(t, corner)
+				} else {
+					corner = dracoSwingRight(t, corner)
+				}
+				if corner == start {
+					break
+				}
+				if corner < 0 && first {
+					first = false
+					corner = dracoSwingRight(t, start)
+				}
+			}
+			for i := range pred {
+				pred[i] = 0
+			}
+			for i := 0; i < num; i++ {
+				context := num - 1
+				pos := creases[context]
+				creases[context]++
+				if pos >= len(s.creases[context]) {
+					return errors.New("Draco crease edges are not enough")
+				}
+				if !s.creases[context][pos] {
+					for j := range pred {
+						pred[j] += preds[i][j]
+					}
+					used++
+				}
+			}
+		}
+		v := values[p*c : (p+1)*c]
+		if used == 0 {
+			// no parallelogram, last value is prediction
+			s.original(values[(p-1)*c:p*c], v, v)
+			continue
+		}
+		for i := range pred {
+			pred[i] /= int32(used)
+		}
+		s.original(pred, v, v)
+	}
+	return nil
+}
+
+// vertexEntry is value index of vertex, invalid vertex is never decoded
+func (s *dracoPrediction) vertexEntry(v int) int {
+	if v < 0 || v >= len(s.seq.encoding.vertexToEntry) {
+		return math.MaxInt32
+	}
+	return s.seq.encoding.vertexToEntry[v]
+}
+
+// parallelogram predict value of entry by the face opposite to corner, every vertices of the face must be decoded
+func (s *dracoPrediction) parallelogram(entry, corner int, values, pred []int32) bool {
+	t := s.seq.table
+	opp := t.opposite(corner)
+	if opp < 0 {
+		return false
+	}
+	var (
+		o = s.vertexEntry(t.vertex(opp))
+		n = s.vertexEntry(t.vertex(dracoNext(opp)))
+		p = s.vertexEntry(t.vertex(dracoPrevious(opp)))
+		c = s.components
+	)
+	if o >= entry || n >= entry || p >= entry {
+		return false
+	}
+	for i := range pred {
+		pred[i] = int32(int64(values[n*c+i]) + int64(values[p*c+i]) - int64(values[o*c+i]))
+	}
+	return true
+}
+
+// position is integer position of entry, it is found by point of entry
+func (s *dracoPrediction) position(entry int) ([3]int64, error) {
+	var res [3]int64
+	if entry < 0 || entry >= len(s.seq.points) {
+		return res, errDracoConnectivity
+	}
+	point := s.seq.points[entry]
+	if s.parent.entries != nil && point >= len(s.parent.entries) {
+		return res, errDracoConnectivity
+	}
+	i := s.parent.entry(point) * 3
+	if i+3 > len(s.parent.portable) {
+		return res, errDracoConnectivity
+	}
+	for j := range res {
+		res[j] = int64(s.parent.portable[i+j])
+	}
+	return res, nil
+}
+
+// restoreTexCoords restore texture coordinate predicted by projection of triangle positions
+func (s *dracoPrediction) restoreTexCoords(values []int32) error {
+	var (
+		t    = s.seq.table
+		pred = make([]int32, 2)
+	)
+	for entry := 0; entry < len(values)/2; entry++ {
+		var (
+			corner = s.seq.encoding.entryToCorner[entry]
+			next   = s.vertexEntry(t.vertex(dracoNext(corner)))
+			prev   = s.vertexEntry(t.vertex(dracoPrevious(corner)))
+			done   bool
+		)
+		if next < entry && prev < entry {
+			nUV := [2]int64{int64(values[next*2]), int64(values[next*2+1])}
+			pUV := [2]int64{int64(values[prev*2]), int64(values[prev*2+1])}
+			if nUV == pUV {
+				pred[0], pred[1] = int32(pUV[0]), int32(pUV[1])
+				done = true
+			} else {
+				tipPos, err := s.position(entry)
+				if err != nil {
+					return err
+				}
+				nextPos, err := s.position(next)
+				if err != nil {
+					return err
+				}
+				prevPos, err := s.position(prev)
+				if err != nil {
+					return err
+				}
+				if done, err = s.predictTexCoord(tipPos, nextPos, prevPos, nUV, pUV, pred); err != nil {
+					return err
+				}
+			}
+		}
+		if !done {
+			// delta coding from next, previous or last value
+			var offset int
+			if prev < entry {
+				offset = prev * 2
+			}
+			if next < entry {
+				offset = next * 2
+			} else if entry > 0 {
+				offset = (entry - 1) * 2
+			} else {
+				offset = -1
+			}
+			if offset < 0 {
+				pred[0], pred[1] = 0, 0
+			} else {
+				pred[0], pred[1] = values[offset], values[offset+1]
+			}
+		}
+		v := values[entry*2 : entry*2+2]
+		s.original(pred, v, v)
+	}
+	return nil
+}
+
+// predictTexCoord predict uv of tip, it returns false if next and previous position is same
+func (s *dracoPrediction) predictTexCoord(tipPos, nextPos, prevPos [3]int64, nUV, pUV [2]int64, pred []int32) (bool, error) {
+	var (
+		pn     [3]int64
+		cn     [3]int64
+		pnNorm int64
+		cnDot  int64
+	)
+	for i := range pn {
+		pn[i] = prevPos[i] - nextPos[i]
+		cn[i] = tipPos[i] - nextPos[i]
+		pnNorm += pn[i] * pn[i]
+		cnDot += pn[i] * cn[i]
+	}
+	if pnNorm == 0 {
+		return false, nil
+	}
+	pnUV := [2]int64{pUV[0] - nUV[0], pUV[1] - nUV[1]}
+	if uint64(dracoAbsMax(nUV[:])) > math.MaxInt64/uint64(pnNorm) || cnDot > math.MaxInt64/dracoAbsMax(pnUV[:]) || cnDot > math.MaxInt64/dracoAbsMax(pn[:]) {
+		return false, errors.New("Draco texture coordinate prediction overflow")
+	}
+	var (
+		xUV    [2]int64
+		cxNorm int64
+	)
+	for i := range xUV {
+		xUV[i] = nUV[i]*pnNorm + cnDot*pnUV[i]
+	}
+	for i := range pn {
+		d := tipPos[i] - (nextPos[i] + cnDot*pn[i]/pnNorm)
+		cxNorm += d * d
+	}
+	// rotated pn uv, scaled by length of cx * length of pn
+	norm := int64(dracoIntSqrt(uint64(cxNorm) * uint64(pnNorm)))
+	cxUV := [2]int64{pnUV[1] * norm, -pnUV[0] * norm}
+	if len(s.orientations) == 0 {
+		return false, errors.New("Draco texture coordinate orientations are not enough")
+	}
+	orientation := s.orientations[len(s.orientations)-1]
+	s.orientations = s.orientations[:len(s.orientations)-1]
+	for i := range pred {
+		if orientation {
+			pred[i] = int32((xUV[i] + cxUV[i]) / pnNorm)
+		} else {
+			pred[i] = int32((xUV[i] - cxUV[i]) / pnNorm)
+		}
+	}
+	return true, nil
+}
+
+// restoreNormals restore octahedral normal predicted by area weighted normal of faces around vertex
+func (s *dracoPrediction) restoreNormals(values []int32) error {
+	pred := make([]int32, 2)
+	for entry := 0; entry < len(values)/2; entry++ {
+		normal, err := s.areaNormal(s.seq.encoding.entryToCorner[entry])
+		if err != nil {
+			return err
+		}
+		s.box.canonicalizeVector(&normal)
+		if s.flips.read() {
+			for i := range normal {
+				normal[i] = -normal[i]
+			}
+		}
+		pred[0], pred[1] = s.box.octahedralCoords(normal)
+		v := values[entry*2 : entry*2+2]
+		s.original(pred, v, v)
+	}
+	return nil
+}
+
+func (s *dracoPrediction) areaNormal(corner int) ([3]int32, error) {
+	var (
+		t      = s.seq.table
+		normal [3]int64
+	)
+	cornerPosition := func(c int) ([3]int64, error) {
+		return s.position(s.vertexEntry(t.vertex(c)))
+	}
+	center, err := cornerPosition(corner)
+	if err != nil {
+		return [3]int32{}, err
+	}
+	err = dracoVertexCorners(t, corner, func(c int) error {
+		next, err := cornerPosition(dracoNext(c))
+		if err != nil {
+			return err
+		}
+		prev, err := cornerPosition(dracoPrevious(c))
+		if err != nil {
+			return err
+		}
+		for i := range next {
+			next[i] -= center[i]
+			prev[i] -= center[i]
+		}
+		normal[0] += next[1]*prev[2] - next[2]*prev[1]
+		normal[1] += next[2]*prev[0] - next[0]*prev[2]
+		normal[2] += next[0]*prev[1] - next[1]*prev[0]
+		return nil
+	})
+	if err != nil {
+		return [3]int32{}, err
+	}
+	// absolute sum is limited to 1 << 29
+	const upper = 1 << 29
+	if sum := dracoAbsSum(normal[:]); sum > upper {
+		q := sum / upper
+		for i := range normal {
+			normal[i] /= q
+		}
+	}
+	return [3]int32{int32(normal[0]), int32(normal[1]), int32(normal[2])}, nil
+}
+
+func dracoAbs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+func dracoAbsMax(v []int64) int64 {
+	var res int64
+	for _, e := range v {
+		if e = dracoAbs(e); e > res {
+			res = e
+		}
+	}
+	return res
+}
+
+// dracoAbsSum is absolute sum, it is saturated to max int64
+func dracoAbsSum(v []int64) int64 {
+	var res int64
+	for _, e := range v {
+		e = dracoAbs(e)
+		if res > math.MaxInt64-e {
+			return math.MaxInt64
+		}
+		res += e
+	}
+	return res
+}
+
+// dracoIntSqrt is floor of square root
+func dracoIntSqrt(n uint64) uint64 {
+	if n == 0 {
+		return 0
+	}
+	res := uint64(1)
+	for act := n; act >= 2; act /= 4 {
+		res *= 2
+	}
+	for {
+		res = (res + n/res) / 2
+		if res*res <= n {
+			return res
+		}
+	}
+}
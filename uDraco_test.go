@@ -0,0 +1,280 @@
+package gltf2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Draco test streams are written by hand, values below are computed from draco 2.2 decoder algorithm.
+
+// dracoTestLE write bytes as is, others in little endian
+func dracoTestLE(data ...interface{}) []byte {
+	var b bytes.Buffer
+	for _, v := range data {
+		if raw, ok := v.([]byte); ok {
+			b.Write(raw)
+			continue
+		}
+		binary.Write(&b, binary.LittleEndian, v)
+	}
+	return b.Bytes()
+}
+
+// dracoTestState write final state of rANS coder
+func dracoTestState(buf []byte, state uint32) []byte {
+	switch {
+	case state < 1<<6:
+		return append(buf, byte(state))
+	case state < 1<<14:
+		v := 1<<14 + state
+		return append(buf, byte(v), byte(v>>8))
+	case state < 1<<22:
+		v := 2<<22 + state
+		return append(buf, byte(v), byte(v>>8), byte(v>>16))
+	}
+	v := 3<<30 + state
+	return append(buf, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+// dracoTestRANS encode symbols by rANS, bits is precision
+func dracoTestRANS(probs []uint32, bits uint, symbols []uint32) []byte {
+	prec := uint32(1) << bits
+	base := 4 * prec
+	cums := make([]uint32, len(probs))
+	var c uint32
+	for i, p := range probs {
+		cums[i] = c
+		c += p
+	}
+	state := base
+	var buf []byte
+	for i := len(symbols) - 1; i >= 0; i-- {
+		p := probs[symbols[i]]
+		for uint64(state) >= uint64(base/prec)*256*uint64(p) {
+			buf = append(buf, byte(state))
+			state >>= 8
+		}
+		state = (state/p)*prec + state%p + cums[symbols[i]]
+	}
+	return dracoTestState(buf, state-base)
+}
+
+// dracoTestRABS encode bits by binary rANS with probability of zero 1/2, it is prefixed with probability and size
+func dracoTestRABS(bits ...bool) []byte {
+	const zero = 128
+	state := uint32(4096)
+	var buf []byte
+	for i := len(bits) - 1; i >= 0; i-- {
+		ls := uint32(zero)
+		if bits[i] {
+			ls = 256 - zero
+		}
+		if state >= 4096*ls {
+			buf = append(buf, byte(state))
+			state >>= 8
+		}
+		state = state/ls*256 + state%ls
+		if !bits[i] {
+			state += 256 - zero
+		}
+	}
+	buf = dracoTestState(buf, state-4096)
+	return append([]byte{zero, byte(len(buf))}, buf...)
+}
+
+// dracoTestQuad is header of edgebreaker quad, faces are (0, 1, 2), (2, 1, 3) by symbol E, R
+func dracoTestQuad(traversal, numData byte) []byte {
+	return dracoTestLE(
+		[]byte("DRACO"), []byte{2, 2, 1, 1, 0, 0},
+		[]byte{traversal, 4, 2, numData, 2, 0},
+		[]byte{0}, // topology splits
+	)
+}
+
+// dracoTestPosition is 4 positions quantized by 8 bits, decoded order is vertex 1, 2, 0, 3
+//
+// Vertex 3 is predicted by parallelogram of vertex 0, 1, 2, other vertices are difference.
+func dracoTestPosition(method byte, data []byte) []byte {
+	return dracoTestLE(
+		[]byte{1, 0, 9, 3, 0, 0, 2},
+		[]byte{method, 1, 0, 1, 4, 0, 0, 3, 4, 0, 0, 3, 0, 0, 2, 2},
+		data,
+		[]int32{0, 3},
+		[]float32{0, 0, 0, 255}, []byte{8},
+	)
+}
+
+func TestDecodeDraco(t *testing.T) {
+	var (
+		// E, R in standard traversal bits
+		standard = dracoTestLE([]byte{1, 0x2f}, dracoTestRABS(false))
+		// E, R in valence traversal, R is in context of valence 2
+		valence = dracoTestLE(
+			dracoTestRABS(false),
+			[]byte{1, 1, 2, 4, 0x0b, 0x01, 0x40},
+		)
+		quad    = [][3]float32{{0, 0, 0}, {2, 0, 0}, {0, 2, 0}, {2, 3, 1}}
+		symbols = dracoTestRANS([]uint32{0, 0, 0, 4096}, 12, []uint32{3})
+	)
+	valence = dracoTestLE(valence, []byte{byte(len(symbols))}, symbols, []byte{0, 0, 0, 0, 0})
+	cases := []struct {
+		name      string
+		src       []byte
+		indices   string
+		positions interface{}
+	}{
+		{
+			name:    "parallelogram",
+			src:     dracoTestLE(dracoTestQuad(0, 0), standard, []byte{1, 0xff, 0, 0}, dracoTestPosition(1, nil)),
+			indices: "[0 1 2 2 1 3]", positions: quad,
+		},
+		{
+			name:    "multi-parallelogram",
+			src:     dracoTestLE(dracoTestQuad(0, 0), standard, []byte{1, 0xff, 0, 0}, dracoTestPosition(2, nil)),
+			indices: "[0 1 2 2 1 3]", positions: quad,
+		},
+		{
+			name: "constrained-multi-parallelogram",
+			src: dracoTestLE(dracoTestQuad(0, 0), standard, []byte{1, 0xff, 0, 0},
+				dracoTestPosition(4, dracoTestLE([]byte{1}, dracoTestRABS(false), []byte{0, 0, 0}))),
+			indices: "[0 1 2 2 1 3]", positions: quad,
+		},
+		{
+			name:    "valence",
+			src:     dracoTestLE(dracoTestQuad(2, 0), valence, []byte{1, 0xff, 0, 0}, dracoTestPosition(1, nil)),
+			indices: "[0 1 2 2 1 3]", positions: quad,
+		},
+		{
+			name:    "prediction-degree",
+			src:     dracoTestLE(dracoTestQuad(0, 0), standard, []byte{1, 0xff, 0, 1}, dracoTestPosition(1, nil)),
+			indices: "[0 1 2 2 1 3]", positions: quad,
+		},
+		{
+			// closed tetrahedron by E, R, C and interior start face, parallelogram prediction is clamped by wrap
+			name: "interior",
+			src: dracoTestLE(
+				[]byte("DRACO"), []byte{2, 2, 1, 1, 0, 0},
+				[]byte{0, 4, 4, 0, 3, 0, 0},
+				[]byte{1, 0x2f}, dracoTestRABS(true),
+				[]byte{1, 0xff, 0, 0},
+				[]byte{1, 0, 9, 3, 0, 0, 2},
+				[]byte{1, 1, 0, 1, 4, 0, 0, 3, 4, 0, 0, 3, 0, 0, 3, 4},
+				[]int32{0, 2},
+				[]float32{0, 0, 0, 255}, []byte{8},
+			),
+			indices:   "[0 1 2 2 1 3 1 0 3 2 3 0]",
+			positions: [][3]float32{{0, 0, 0}, {2, 0, 0}, {0, 2, 0}, {0, 0, 2}},
+		},
+		{
+			// triangle strip by E, E, S, merged vertex is replaced by last vertex
+			name: "split",
+			src: dracoTestLE(
+				[]byte("DRACO"), []byte{2, 2, 1, 1, 0, 0},
+				[]byte{0, 5, 3, 0, 3, 1, 0},
+				[]byte{2, 0x7f, 0}, dracoTestRABS(false),
+				[]byte{1, 0xff, 0, 0},
+				[]byte{1, 0, 9, 3, 0, 0, 0},
+				[]float32{1, 0, 0, 2, 0, 0, 0, 0, 0, 4, 0, 0, 3, 0, 0},
+			),
+			indices:   "[0 1 2 3 2 4 2 1 4]",
+			positions: [][3]float32{{0, 0, 0}, {1, 0, 0}, {2, 0, 0}, {3, 0, 0}, {4, 0, 0}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mesh, err := decodeDraco(c.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(mesh.indices) != c.indices {
+				t.Fatalf("indices %v, expected %s", mesh.indices, c.indices)
+			}
+			pos, err := mesh.attributes[0].slice(mesh.numPoints)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(pos) != fmt.Sprint(c.positions) {
+				t.Errorf("positions %v, expected %v", pos, c.positions)
+			}
+			// truncated data must fail, not panic
+			for i := range c.src {
+				decodeDraco(c.src[:i])
+			}
+		})
+	}
+}
+
+// dracoTestSeam is quad which is split by seam on diagonal edge, texture coordinate is per corner
+//
+// Position is parallelogram, normal is geometric normal, texture coordinate is portable texture coordinate prediction.
+// Attribute id 0 is position, 1 is texture coordinate, 2 is normal.
+func dracoTestSeam() []byte {
+	return dracoTestLE(
+		dracoTestQuad(0, 1),
+		[]byte{1, 0x2f}, dracoTestRABS(false), dracoTestRABS(true),
+		// position, normal use position connectivity, texture coordinate use attribute connectivity
+		[]byte{2, 0xff, 0, 0, 0, 1, 0},
+		[]byte{2, 0, 9, 3, 0, 0, 1, 9, 3, 0, 2, 2, 3},
+		[]byte{1, 3, 9, 2, 0, 1, 2},
+		// position
+		[]byte{1, 1, 0, 1, 4, 0, 0, 3, 4, 0, 0, 3, 0, 0, 0, 0},
+		[]int32{0, 2},
+		// normal, every correction is zero
+		[]byte{6, 2, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0},
+		[]uint32{255}, dracoTestRABS(false, false, false, false),
+		[]float32{0, 0, 0, 255}, []byte{8},
+		[]byte{8},
+		// texture coordinate
+		[]byte{5, 1, 0, 1, 4, 0, 3, 4, 0, 0, 12, 0, 0, 4, 0, 0},
+		[]int32{2}, dracoTestRABS(false, true), []int32{0, 6},
+		[]float32{0, 0, 255}, []byte{8},
+	)
+}
+
+func TestDecodeDracoSeam(t *testing.T) {
+	mesh, err := decodeDraco(dracoTestSeam())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(mesh.indices) != "[0 1 4 3 2 5]" || mesh.numPoints != 6 {
+		t.Fatalf("indices %v, %d points", mesh.indices, mesh.numPoints)
+	}
+	expected := map[uint32]interface{}{
+		0: [][3]float32{{0, 0, 0}, {2, 0, 0}, {2, 0, 0}, {0, 2, 0}, {0, 2, 0}, {2, 2, 0}},
+		1: [][2]float32{{0, 0}, {2, 0}, {6, 0}, {4, 2}, {0, 2}, {6, 2}},
+	}
+	for id, v := range expected {
+		got, err := mesh.attributes[id].slice(mesh.numPoints)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(v) {
+			t.Errorf("attribute %d %v, expected %v", id, got, v)
+		}
+	}
+	normals, err := mesh.attributes[2].slice(mesh.numPoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range normals.([][3]float32) {
+		if math.Abs(float64(v[0])) > 1e-6 || math.Abs(float64(v[1])) > 1e-6 || math.Abs(float64(v[2]-1)) > 1e-6 {
+			t.Errorf("normal %d %v, expected +z", i, v)
+		}
+	}
+}
+
+func TestDecodeDracoUnsupported(t *testing.T) {
+	standard := dracoTestLE([]byte{1, 0x2f}, dracoTestRABS(false))
+	for name, src := range map[string][]byte{
+		"deprecated-texcoords": dracoTestLE(dracoTestQuad(0, 0), standard, []byte{1, 0xff, 0, 0}, dracoTestPosition(3, nil)),
+		"traversal":            dracoTestLE(dracoTestQuad(1, 0), standard, []byte{1, 0xff, 0, 0}, dracoTestPosition(1, nil)),
+		"symbol":               dracoTestLE(dracoTestQuad(0, 0), []byte{1, 0x3e}, dracoTestRABS(false), []byte{1, 0xff, 0, 0}, dracoTestPosition(1, nil)),
+	} {
+		if _, err := decodeDraco(src); err == nil {
+			t.Errorf("%s must fail", name)
+		}
+	}
+}
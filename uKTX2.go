@@ -0,0 +1,492 @@
+package gltf2
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+	"image"
+	"image/color"
+	"io"
+	"io/ioutil"
+)
+
+// https://registry.khronos.org/KTX/specs/2.0/ktxspec.v2.html
+const (
+	ktx2Identifier   = "\xabKTX 20\xbb\r\n\x1a\n"
+	ktx2HeaderLength = 80
+	ktx2LevelLength  = 24
+)
+
+// vkFormat which can be read as RGBA without transcode
+const (
+	vkFormatR8G8B8Unorm   = 23
+	vkFormatR8G8B8Srgb    = 29
+	vkFormatR8G8B8A8Unorm = 37
+	vkFormatR8G8B8A8Srgb  = 43
+)
+
+// KHR_DF_MODEL, color model of data format descriptor
+const (
+	KTX2ModelRGBSDA = 1
+	KTX2ModelETC1S  = 163
+	KTX2ModelUASTC  = 166
+)
+
+// KHR_DF_TRANSFER, transfer function of data format descriptor
+const (
+	KTX2TransferLinear = 1
+	KTX2TransferSRGB   = 2
+)
+
+// KTX2Supercompression is supercompressionScheme of KTX2 header
+type KTX2Supercompression uint32
+
+const (
+	KTX2SupercompressionNone    KTX2Supercompression = 0
+	KTX2SupercompressionBasisLZ KTX2Supercompression = 1
+	KTX2SupercompressionZstd    KTX2Supercompression = 2
+	KTX2SupercompressionZLIB    KTX2Supercompression = 3
+)
+
+func (s KTX2Supercompression) String() string {
+	switch s {
+	case KTX2SupercompressionNone:
+		return "None"
+	case KTX2SupercompressionBasisLZ:
+		return "BasisLZ"
+	case KTX2SupercompressionZstd:
+		return "Zstandard"
+	case KTX2SupercompressionZLIB:
+		return "ZLIB"
+	}
+	return "nil"
+}
+
+// KTX2 is KTX2 texture container
+//
+// Levels are raw mip levels, they can be uploaded to GPU directly when VkFormat is defined,
+// ETC1S(BasisLZ), UASTC can be transcoded to RGBA by KTX2.RGBA
+type KTX2 struct {
+	VkFormat uint32
+	TypeSize uint32
+	// PixelHeight, PixelDepth is 0 for 1D, 2D texture
+	PixelWidth  int
+	PixelHeight int
+	PixelDepth  int
+	// LayerCount is 0 if it is not array texture
+	LayerCount int
+	// FaceCount is 6 for cubemap, otherwise 1
+	FaceCount              int
+	SupercompressionScheme KTX2Supercompression
+	DataFormat             KTX2DataFormat
+	KeyValue               map[string][]byte
+	// Levels[0] is base level
+	Levels []KTX2Level
+	// supercompressionGlobalData, BasisLZ codebooks
+	globalData []byte
+}
+
+type KTX2Level struct {
+	// Data is level as it is in file, supercompressed if KTX2.SupercompressionScheme is not None
+	Data                   []byte
+	UncompressedByteLength int
+}
+
+// KTX2DataFormat is basic data format descriptor block
+type KTX2DataFormat struct {
+	ColorModel       uint8
+	ColorPrimaries   uint8
+	TransferFunction uint8
+	Flags            uint8
+	// TexelBlockDimension is texel count of block, ex) 4, 4, 1, 1 for ETC1S
+	TexelBlockDimension [4]int
+	BytesPlane          [8]uint8
+	Samples             []KTX2Sample
+}
+
+type KTX2Sample struct {
+	BitOffset int
+	BitLength int
+	// low 4 bits is channel id, high 4 bits is qualifiers
+	ChannelType    uint8
+	SamplePosition [4]uint8
+	SampleLower    uint32
+	SampleUpper    uint32
+}
+
+// Channel is channel id of sample, ex) 15 is alpha(AAA) for ETC1S
+func (s KTX2Sample) Channel() uint8 {
+	return s.ChannelType & 0x0F
+}
+
+// IsSRGB report color is encoded by sRGB transfer function
+func (s *KTX2) IsSRGB() bool {
+	return s.DataFormat.TransferFunction == KTX2TransferSRGB
+}
+
+// DecodeKTX2 parse KTX2 container, level data is not decoded
+func DecodeKTX2(data []byte) (*KTX2, error) {
+	if len(data) < ktx2HeaderLength || string(data[:len(ktx2Identifier)]) != ktx2Identifier {
+		return nil, errors.WithMessage(ErrorKTX2, "Invalid identifier")
+	}
+	var (
+		header = data[len(ktx2Identifier):]
+		u32    = func(i int) uint32 { return binary.LittleEndian.Uint32(header[i*4:]) }
+		res    = &KTX2{
+			VkFormat:               u32(0),
+			TypeSize:               u32(1),
+			PixelWidth:             int(u32(2)),
+			PixelHeight:            int(u32(3)),
+			PixelDepth:             int(u32(4)),
+			LayerCount:             int(u32(5)),
+			FaceCount:              int(u32(6)),
+			SupercompressionScheme: KTX2Supercompression(u32(8)),
+		}
+		levelCount = int(u32(7))
+		dfdOffset  = u32(9)
+		dfdLength  = u32(10)
+		kvdOffset  = u32(11)
+		kvdLength  = u32(12)
+		sgdOffset  = binary.LittleEndian.Uint64(header[13*4:])
+		sgdLength  = binary.LittleEndian.Uint64(header[15*4:])
+	)
+	if res.PixelWidth == 0 {
+		return nil, errors.WithMessage(ErrorKTX2, "PixelWidth must be bigger than 0")
+	}
+	if res.FaceCount != 1 && res.FaceCount != 6 {
+		return nil, errors.WithMessage(ErrorKTX2, fmt.Sprintf("FaceCount must be 1 or 6, but got %d", res.FaceCount))
+	}
+	if res.SupercompressionScheme > KTX2SupercompressionZLIB {
+		return nil, errors.WithMessage(ErrorKTX2, fmt.Sprintf("SupercompressionScheme %d not supported", res.SupercompressionScheme))
+	}
+	// levelCount 0 means mip levels should be generated, there is only base level
+	if levelCount == 0 {
+		levelCount = 1
+	}
+	section := func(name string, offset, length uint64) ([]byte, error) {
+		if offset+length < offset || offset+length > uint64(len(data)) {
+			return nil, errors.WithMessage(ErrorKTX2, fmt.Sprintf("%s out of file", name))
+		}
+		return data[offset : offset+length], nil
+	}
+	index, err := section("Level index", ktx2HeaderLength, uint64(levelCount*ktx2LevelLength))
+	if err != nil {
+		return nil, err
+	}
+	res.Levels = make([]KTX2Level, levelCount)
+	for i := range res.Levels {
+		entry := index[i*ktx2LevelLength:]
+		level, err := section(fmt.Sprintf("Level %d", i), binary.LittleEndian.Uint64(entry), binary.LittleEndian.Uint64(entry[8:]))
+		if err != nil {
+			return nil, err
+		}
+		res.Levels[i] = KTX2Level{
+			Data:                   level,
+			UncompressedByteLength: int(binary.LittleEndian.Uint64(entry[16:])),
+		}
+	}
+	dfd, err := section("Data format descriptor", uint64(dfdOffset), uint64(dfdLength))
+	if err != nil {
+		return nil, err
+	}
+	if res.DataFormat, err = readKTX2DataFormat(dfd); err != nil {
+		return nil, err
+	}
+	kvd, err := section("Key/value data", uint64(kvdOffset), uint64(kvdLength))
+	if err != nil {
+		return nil, err
+	}
+	if res.KeyValue, err = readKTX2KeyValue(kvd); err != nil {
+		return nil, err
+	}
+	if res.globalData, err = section("Supercompression global data", sgdOffset, sgdLength); err != nil {
+		return nil, err
+	}
+	if res.SupercompressionScheme == KTX2SupercompressionBasisLZ && len(res.globalData) == 0 {
+		return nil, errors.WithMessage(ErrorKTX2, "BasisLZ need supercompression global data")
+	}
+	return res, nil
+}
+
+// readKTX2DataFormat read first descriptor block, it must be basic block
+func readKTX2DataFormat(dfd []byte) (res KTX2DataFormat, err error) {
+	const blockHeader = 24
+	if len(dfd) < 4+blockHeader {
+		return res, errors.WithMessage(ErrorKTX2, "Data format descriptor too short")
+	}
+	block := dfd[4:]
+	if vendor, kind := binary.LittleEndian.Uint32(block)&0x1FFFF, binary.LittleEndian.Uint32(block)>>17; vendor != 0 || kind != 0 {
+		return res, errors.WithMessage(ErrorKTX2, "First data format descriptor block must be basic")
+	}
+	size := int(binary.LittleEndian.Uint16(block[6:]))
+	if size < blockHeader || size > len(block) || (size-blockHeader)%16 != 0 {
+		return res, errors.WithMessage(ErrorKTX2, "Invalid data format descriptor block size")
+	}
+	res.ColorModel = block[8]
+	res.ColorPrimaries = block[9]
+	res.TransferFunction = block[10]
+	res.Flags = block[11]
+	for i := 0; i < 4; i++ {
+		res.TexelBlockDimension[i] = int(block[12+i]) + 1
+	}
+	copy(res.BytesPlane[:], block[16:24])
+	for sample := block[blockHeader:size]; len(sample) > 0; sample = sample[16:] {
+		s := KTX2Sample{
+			BitOffset:   int(binary.LittleEndian.Uint16(sample)),
+			BitLength:   int(sample[2]) + 1,
+			ChannelType: sample[3],
+			SampleLower: binary.LittleEndian.Uint32(sample[8:]),
+			SampleUpper: binary.LittleEndian.Uint32(sample[12:]),
+		}
+		copy(s.SamplePosition[:], sample[4:8])
+		res.Samples = append(res.Samples, s)
+	}
+	return res, nil
+}
+
+// readKTX2KeyValue read key/value data, value keep its NUL terminator if it has
+func readKTX2KeyValue(kvd []byte) (map[string][]byte, error) {
+	res := make(map[string][]byte)
+	for len(kvd) >= 4 {
+		length := int(binary.LittleEndian.Uint32(kvd))
+		if length > len(kvd)-4 {
+			return nil, errors.WithMessage(ErrorKTX2, "Truncated key/value data")
+		}
+		kv := kvd[4 : 4+length]
+		split := bytes.IndexByte(kv, 0)
+		if split < 0 {
+			return nil, errors.WithMessage(ErrorKTX2, "Key of key/value data must be NUL terminated")
+		}
+		res[string(kv[:split])] = kv[split+1:]
+		// each pair is aligned to 4 bytes
+		next := 4 + length + (4-length%4)%4
+		if next > len(kvd) {
+			break
+		}
+		kvd = kvd[next:]
+	}
+	return res, nil
+}
+
+// LevelSize is pixel size of level, 0 is 1 for height, depth
+func (s *KTX2) LevelSize(level int) (width, height, depth int) {
+	size := func(v int) int {
+		if v >>= uint(level); v < 1 {
+			return 1
+		}
+		return v
+	}
+	return size(s.PixelWidth), size(s.PixelHeight), size(s.PixelDepth)
+}
+
+// imageCount is image count of level, layers * faces * depth
+func (s *KTX2) imageCount(level int) int {
+	layers := s.LayerCount
+	if layers < 1 {
+		layers = 1
+	}
+	_, _, depth := s.LevelSize(level)
+	return layers * s.FaceCount * depth
+}
+
+// Level is mip level without Zstandard, ZLIB supercompression, for direct GPU upload
+//
+// Images of level are ordered by layer, face, z slice
+// BasisLZ level must be transcoded, so it occur error
+func (s *KTX2) Level(level int) ([]byte, error) {
+	if level < 0 || level >= len(s.Levels) {
+		return nil, errors.Errorf("KTX2 level %d not found, there is %d levels", level, len(s.Levels))
+	}
+	var (
+		src = s.Levels[level]
+		res []byte
+		err error
+	)
+	switch s.SupercompressionScheme {
+	case KTX2SupercompressionNone:
+		return src.Data, nil
+	case KTX2SupercompressionZstd:
+		var dec *zstd.Decoder
+		if dec, err = zstd.NewReader(nil); err != nil {
+			return nil, err
+		}
+		defer dec.Close()
+		res, err = dec.DecodeAll(src.Data, make([]byte, 0, src.UncompressedByteLength))
+	case KTX2SupercompressionZLIB:
+		var rd io.ReadCloser
+		if rd, err = zlib.NewReader(bytes.NewReader(src.Data)); err != nil {
+			return nil, err
+		}
+		defer rd.Close()
+		res, err = ioutil.ReadAll(rd)
+	default:
+		return nil, errors.Errorf("KTX2 %s level can't be used without transcode", s.SupercompressionScheme)
+	}
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("KTX2 %s level %d", s.SupercompressionScheme, level))
+	}
+	if len(res) != src.UncompressedByteLength {
+		return nil, errors.Errorf("KTX2 level %d uncompressed length must be %d, but got %d", level, src.UncompressedByteLength, len(res))
+	}
+	return res, nil
+}
+
+// RGBA decode image of level, layer, face to RGBA, z slice 0 is used for 3D texture
+//
+// ETC1S(BasisLZ), UASTC, R8G8B8(A8) formats are supported, KTX2.Validate report what can't be decoded
+func (s *KTX2) RGBA(level, layer, face int) (*image.RGBA, error) {
+	if level < 0 || level >= len(s.Levels) {
+		return nil, errors.Errorf("KTX2 level %d not found, there is %d levels", level, len(s.Levels))
+	}
+	if layer < 0 || layer >= s.LayerCount && layer > 0 || face < 0 || face >= s.FaceCount {
+		return nil, errors.Errorf("KTX2 layer %d, face %d not found", layer, face)
+	}
+	var (
+		width, height, depth = s.LevelSize(level)
+		index                = (layer*s.FaceCount + face) * depth
+	)
+	switch {
+	case s.SupercompressionScheme == KTX2SupercompressionBasisLZ:
+		if s.DataFormat.ColorModel != KTX2ModelETC1S {
+			return nil, errors.Errorf("KTX2 BasisLZ need ETC1S color model, but got %d", s.DataFormat.ColorModel)
+		}
+		for i := 0; i < level; i++ {
+			index += s.imageCount(i)
+		}
+		global, err := s.basisLZ()
+		if err != nil {
+			return nil, err
+		}
+		alpha := false
+		for _, v := range s.DataFormat.Samples {
+			alpha = alpha || v.Channel() == 15
+		}
+		return global.decode(s.Levels[level].Data, index, width, height, alpha)
+	case s.DataFormat.ColorModel == KTX2ModelUASTC:
+		data, err := s.Level(level)
+		if err != nil {
+			return nil, err
+		}
+		size := (width + 3) / 4 * ((height + 3) / 4) * uastcBlockLength
+		if len(data) < (index+1)*size {
+			return nil, errors.Errorf("KTX2 level %d too short", level)
+		}
+		return decodeUASTC(data[index*size:(index+1)*size], width, height)
+	}
+	var channels int
+	switch s.VkFormat {
+	case vkFormatR8G8B8Unorm, vkFormatR8G8B8Srgb:
+		channels = 3
+	case vkFormatR8G8B8A8Unorm, vkFormatR8G8B8A8Srgb:
+		channels = 4
+	default:
+		return nil, errors.Errorf("KTX2 vkFormat %d can't be decoded to RGBA", s.VkFormat)
+	}
+	data, err := s.Level(level)
+	if err != nil {
+		return nil, err
+	}
+	size := width * height * channels
+	if len(data) < (index+1)*size {
+		return nil, errors.Errorf("KTX2 level %d too short", level)
+	}
+	data = data[index*size:]
+	res := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i := 0; i < width*height; i++ {
+		copy(res.Pix[i*4:i*4+channels], data[i*channels:])
+		if channels == 3 {
+			res.Pix[i*4+3] = 0xFF
+		}
+	}
+	return res, nil
+}
+
+// basisLZ read supercompression global data, it has image descriptions of every level
+func (s *KTX2) basisLZ() (*basisLZGlobal, error) {
+	images := 0
+	for i := range s.Levels {
+		images += s.imageCount(i)
+	}
+	return readBasisLZGlobal(s.globalData, images)
+}
+
+// Validate report issues of KTX2 for KHR_texture_basisu, and data which KTX2.RGBA can't decode
+//
+// Issue.Scheme is SCHEME_IMAGE, Issue.Pointer is KTX2 field instead of JSON pointer
+func (s *KTX2) Validate() Issues {
+	var issues Issues
+	switch s.DataFormat.ColorModel {
+	case KTX2ModelETC1S:
+		if s.SupercompressionScheme != KTX2SupercompressionBasisLZ {
+			issues.add(LEVEL1, CODE_VALUE_NOT_IN_LIST, "/supercompressionScheme", "KTX2 ETC1S allow(BasisLZ), but got %s", s.SupercompressionScheme)
+			break
+		}
+		global, err := s.basisLZ()
+		if err != nil {
+			code := CODE_VALUE_NOT_IN_RANGE
+			if errors.Cause(err) == errBasisGlobalSelectors {
+				code = CODE_VALUE_NOT_IN_LIST
+			}
+			issues.add(LEVEL1, code, "/supercompressionGlobalData", "%v", err)
+			break
+		}
+		for i, v := range global.images {
+			if v.flags&basisLZFlagPFrame != 0 {
+				issues.add(LEVEL1, CODE_VALUE_NOT_IN_LIST, fmt.Sprintf("/supercompressionGlobalData/imageDescs/%d", i), "BasisLZ P-frame not supported")
+			}
+		}
+	case KTX2ModelUASTC:
+		if s.SupercompressionScheme != KTX2SupercompressionNone && s.SupercompressionScheme != KTX2SupercompressionZstd {
+			issues.add(LEVEL1, CODE_VALUE_NOT_IN_LIST, "/supercompressionScheme", "KTX2 UASTC allow(None, Zstandard), but got %s", s.SupercompressionScheme)
+		}
+	default:
+		issues.add(LEVEL2, CODE_VALUE_NOT_IN_LIST, "/dataFormatDescriptor/colorModel", "KHR_texture_basisu allow(ETC1S, UASTC), but got %d", s.DataFormat.ColorModel)
+		switch s.VkFormat {
+		case vkFormatR8G8B8Unorm, vkFormatR8G8B8Srgb, vkFormatR8G8B8A8Unorm, vkFormatR8G8B8A8Srgb:
+		default:
+			issues.add(LEVEL3, CODE_VALUE_NOT_IN_LIST, "/vkFormat", "KTX2 vkFormat %d can't be decoded to RGBA, only raw levels can be used", s.VkFormat)
+		}
+	}
+	for i := range issues {
+		issues[i].Scheme = SCHEME_IMAGE
+	}
+	return issues
+}
+
+// decodeKTX2Image is for image.Decode, it is base level of first layer, face
+func decodeKTX2Image(rd io.Reader) (image.Image, error) {
+	data, err := ioutil.ReadAll(rd)
+	if err != nil {
+		return nil, err
+	}
+	ktx2, err := DecodeKTX2(data)
+	if err != nil {
+		return nil, err
+	}
+	return ktx2.RGBA(0, 0, 0)
+}
+func decodeKTX2Config(rd io.Reader) (image.Config, error) {
+	header := make([]byte, ktx2HeaderLength)
+	if _, err := io.ReadFull(rd, header); err != nil {
+		return image.Config{}, errors.WithMessage(ErrorKTX2, err.Error())
+	}
+	if string(header[:len(ktx2Identifier)]) != ktx2Identifier {
+		return image.Config{}, errors.WithMessage(ErrorKTX2, "Invalid identifier")
+	}
+	height := int(binary.LittleEndian.Uint32(header[len(ktx2Identifier)+12:]))
+	if height == 0 {
+		height = 1
+	}
+	return image.Config{
+		ColorModel: color.RGBAModel,
+		Width:      int(binary.LittleEndian.Uint32(header[len(ktx2Identifier)+8:])),
+		Height:     height,
+	}, nil
+}
+
+func init() {
+	image.RegisterFormat("ktx2", ktx2Identifier, decodeKTX2Image, decodeKTX2Config)
+}
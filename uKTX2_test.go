@@ -0,0 +1,320 @@
+package gltf2
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ktx2TestBits is LSB first bit writer, same order as basisBits
+type ktx2TestBits struct {
+	out []byte
+	buf uint64
+	n   uint
+}
+
+func (s *ktx2TestBits) put(v int, n uint) {
+	s.buf |= uint64(v) & (1<<n - 1) << s.n
+	s.n += n
+	for s.n >= 8 {
+		s.out = append(s.out, byte(s.buf))
+		s.buf >>= 8
+		s.n -= 8
+	}
+}
+
+// code write v in MSB first, it is huffman code
+func (s *ktx2TestBits) code(v int, n uint) {
+	for i := int(n) - 1; i >= 0; i-- {
+		s.put(v>>uint(i)&1, 1)
+	}
+}
+
+func (s *ktx2TestBits) bytes() []byte {
+	if s.n > 0 {
+		s.put(0, 8-s.n)
+	}
+	return s.out
+}
+
+// ktx2TestFile is KTX2 of one 2D level, samples are {bitLength - 1, channelType} of data format descriptor
+func ktx2TestFile(vkFormat uint32, scheme KTX2Supercompression, model byte, samples [][2]byte, width, height int, level []byte, uncompressed int, sgd []byte) []byte {
+	block := make([]byte, 24+16*len(samples))
+	block[4], block[6] = 2, byte(len(block))
+	// color model, BT709 primaries, sRGB transfer, 4x4 texel block
+	block[8], block[9], block[10], block[12], block[13] = model, 1, KTX2TransferSRGB, 3, 3
+	for i, v := range samples {
+		block[24+16*i+2], block[24+16*i+3] = v[0], v[1]
+	}
+	dfd := meshoptTestLE(uint32(4+len(block)), block)
+	kv := []byte("KTXwriter\x00test\x00")
+	kvd := meshoptTestLE(uint32(len(kv)), kv, make([]byte, (4-len(kv)%4)%4))
+	var (
+		dfdOffset   = ktx2HeaderLength + ktx2LevelLength
+		kvdOffset   = dfdOffset + len(dfd)
+		sgdOffset   = kvdOffset + len(kvd)
+		levelOffset = sgdOffset + len(sgd)
+	)
+	return meshoptTestLE(
+		[]byte(ktx2Identifier),
+		[]uint32{vkFormat, 1, uint32(width), uint32(height), 0, 0, 1, 1, uint32(scheme)},
+		[]uint32{uint32(dfdOffset), uint32(len(dfd)), uint32(kvdOffset), uint32(len(kvd))},
+		[]uint64{uint64(sgdOffset), uint64(len(sgd))},
+		[]uint64{uint64(levelOffset), uint64(len(level)), uint64(uncompressed)},
+		dfd, kvd, sgd, level,
+	)
+}
+
+// ktx2TestPixels check pixels of img, key is x, y
+func ktx2TestPixels(t *testing.T, img *image.RGBA, expected map[[2]int][4]uint8) {
+	for p, c := range expected {
+		i := img.PixOffset(p[0], p[1])
+		if got := [4]uint8{img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3]}; got != c {
+			t.Errorf("pixel %v %v, expected %v", p, got, c)
+		}
+	}
+}
+
+// uastcTestBlock write fields of {value, bits} to UASTC block, first field is mode code
+func uastcTestBlock(fields ...[2]int) []byte {
+	var w ktx2TestBits
+	for _, v := range fields {
+		w.put(v[0], uint(v[1]))
+	}
+	res := w.bytes()
+	return append(res, make([]byte, uastcBlockLength-len(res))...)
+}
+
+// uastcTestWeights is weights of 4x4 texels, anchor texels have one less bit
+func uastcTestWeights(bits int, anchors map[int]bool, weight func(i int) int) (res [][2]int) {
+	for i := 0; i < 16; i++ {
+		n := bits
+		if anchors[i] {
+			n--
+		}
+		res = append(res, [2]int{weight(i), n})
+	}
+	return res
+}
+
+// uastcTestCases is UASTC blocks of modes, endpoints and expected colors are computed from ASTC unquantization by hand
+var uastcTestCases = []struct {
+	name     string
+	block    []byte
+	expected map[[2]int][4]uint8
+}{
+	{
+		name:  "solid",
+		block: uastcTestBlock([2]int{0x17, 5}, [2]int{10, 8}, [2]int{20, 8}, [2]int{30, 8}, [2]int{40, 8}),
+		expected: map[[2]int][4]uint8{
+			{0, 0}: {10, 20, 30, 40}, {3, 3}: {10, 20, 30, 40},
+		},
+	},
+	{
+		// 8 bits endpoints, red and green ramp
+		name: "mode1",
+		block: uastcTestBlock(append([][2]int{
+			{0x35, 6}, {0, 14},
+			{0, 8}, {255, 8}, {255, 8}, {0, 8}, {0, 8}, {0, 8},
+		}, uastcTestWeights(2, map[int]bool{0: true}, func(i int) int { return i % 4 })...)...),
+		expected: map[[2]int][4]uint8{
+			{0, 0}: {0, 255, 0, 255}, {1, 1}: {84, 171, 0, 255}, {2, 2}: {171, 84, 0, 255}, {3, 3}: {255, 0, 0, 255},
+		},
+	},
+	{
+		// pattern 0 split left and right, anchor of right subset is texel 2
+		name: "mode2",
+		block: uastcTestBlock(append([][2]int{
+			{0x1D, 5}, {0, 15}, {0, 5},
+			{0, 4}, {15, 4}, {0, 4}, {0, 4}, {0, 4}, {0, 4},
+			{0, 4}, {0, 4}, {0, 4}, {0, 4}, {0, 4}, {15, 4},
+		}, uastcTestWeights(3, map[int]bool{0: true, 2: true}, func(int) int { return 3 })...)...),
+		expected: map[[2]int][4]uint8{
+			{0, 0}: {108, 0, 0, 255}, {1, 3}: {108, 0, 0, 255}, {2, 0}: {0, 0, 108, 255}, {3, 3}: {0, 0, 108, 255},
+		},
+	},
+	{
+		// 3 subsets, upper half, lower left, lower right, trits are all 0
+		name: "mode3",
+		block: uastcTestBlock(append([][2]int{
+			{0x3, 5}, {0, 15}, {0, 4},
+			{0, 8}, {0, 8}, {0, 8}, {0, 5},
+			{1, 2}, {1, 2}, {0, 2}, {0, 2}, {0, 2}, {0, 2},
+			{0, 2}, {0, 2}, {1, 2}, {1, 2}, {0, 2}, {0, 2},
+			{0, 2}, {0, 2}, {0, 2}, {0, 2}, {1, 2}, {1, 2},
+		}, uastcTestWeights(2, map[int]bool{0: true, 8: true, 10: true}, func(int) int { return 0 })...)...),
+		expected: map[[2]int][4]uint8{
+			{0, 0}: {255, 0, 0, 255}, {3, 1}: {255, 0, 0, 255}, {1, 2}: {0, 255, 0, 255}, {2, 3}: {0, 0, 255, 255},
+		},
+	},
+	{
+		// BC7 3 subsets pattern as 2 subsets, second row is second subset, quints are all 0
+		name: "mode7",
+		block: uastcTestBlock(append([][2]int{
+			{0x7, 5}, {0, 15}, {0, 5},
+			{0, 7}, {0, 7}, {0, 7}, {0, 7},
+			{1, 3}, {1, 3}, {0, 3}, {0, 3}, {0, 3}, {0, 3},
+			{0, 3}, {0, 3}, {1, 3}, {1, 3}, {0, 3}, {0, 3},
+		}, uastcTestWeights(2, map[int]bool{0: true, 4: true}, func(int) int { return 0 })...)...),
+		expected: map[[2]int][4]uint8{
+			{0, 0}: {255, 0, 0, 255}, {3, 1}: {0, 255, 0, 255}, {2, 2}: {255, 0, 0, 255}, {1, 3}: {255, 0, 0, 255},
+		},
+	},
+	{
+		// trits of blue are 1, 2 and bits are 0, 6
+		name: "mode10",
+		block: uastcTestBlock(append([][2]int{
+			{0x2, 3}, {0, 13},
+			{81, 8}, {2, 5},
+			{0, 4}, {1, 4}, {1, 4}, {0, 4}, {0, 4}, {6, 4}, {1, 4}, {1, 4},
+		}, uastcTestWeights(4, map[int]bool{0: true}, func(i int) int { return i })...)...),
+		expected: map[[2]int][4]uint8{
+			{0, 0}: {0, 255, 5, 255}, {1, 1}: {84, 171, 22, 255}, {3, 3}: {255, 0, 59, 255},
+		},
+	},
+	{
+		// dual plane, blue use second plane
+		name: "mode6",
+		block: uastcTestBlock(append([][2]int{
+			{0x1B, 5}, {0, 10}, {2, 2},
+			{0, 7}, {0, 7},
+			{0, 5}, {1, 5}, {0, 5}, {1, 5}, {0, 5}, {1, 5},
+			{0, 1}, {0, 1},
+		}, func() (res [][2]int) {
+			for i := 1; i < 16; i++ {
+				res = append(res, [2]int{3, 2}, [2]int{1, 2})
+			}
+			return res
+		}()...)...),
+		expected: map[[2]int][4]uint8{
+			{0, 0}: {0, 0, 0, 255}, {1, 0}: {255, 255, 84, 255}, {3, 3}: {255, 255, 84, 255},
+		},
+	},
+	{
+		// luminance, alpha
+		name: "mode15",
+		block: uastcTestBlock(append([][2]int{
+			{0x5, 7}, {0, 13},
+			{0, 8}, {255, 8}, {255, 8}, {0, 8},
+		}, uastcTestWeights(4, map[int]bool{0: true}, func(i int) int {
+			if i == 0 {
+				return 0
+			}
+			return 15
+		})...)...),
+		expected: map[[2]int][4]uint8{
+			{0, 0}: {0, 0, 0, 255}, {2, 1}: {255, 255, 255, 0},
+		},
+	},
+}
+
+func TestDecodeUASTC(t *testing.T) {
+	for _, c := range uastcTestCases {
+		t.Run(c.name, func(t *testing.T) {
+			img, err := decodeUASTC(c.block, 4, 4)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ktx2TestPixels(t, img, c.expected)
+		})
+	}
+	// reserved mode
+	if _, err := decodeUASTC(uastcTestBlock([2]int{0x45, 7}), 4, 4); err == nil {
+		t.Error("reserved mode must fail")
+	}
+	if _, err := decodeUASTC(uastcTestCases[0].block, 8, 4); err == nil {
+		t.Error("missing block must fail")
+	}
+}
+
+// TestKTX2UASTC decode Zstandard supercompressed UASTC, blocks out of image are cropped
+func TestKTX2UASTC(t *testing.T) {
+	var (
+		blocks    = append(append([]byte{}, uastcTestCases[1].block...), uastcTestCases[0].block...)
+		enc, _    = zstd.NewWriter(nil)
+		file      = ktx2TestFile(0, KTX2SupercompressionZstd, KTX2ModelUASTC, [][2]byte{{127, 0}}, 6, 3, enc.EncodeAll(blocks, nil), len(blocks), nil)
+		ktx2, err = DecodeKTX2(file)
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issues := ktx2.Validate(); len(issues) != 0 {
+		t.Errorf("issues %v", issues)
+	}
+	level, err := ktx2.Level(0)
+	if err != nil || !bytes.Equal(level, blocks) {
+		t.Fatalf("level %v %v, expected raw blocks", level, err)
+	}
+	img, format, err := image.Decode(bytes.NewReader(file))
+	if err != nil || format != "ktx2" {
+		t.Fatal(err, format)
+	}
+	rgba := img.(*image.RGBA)
+	if rgba.Rect.Dx() != 6 || rgba.Rect.Dy() != 3 {
+		t.Fatalf("size %v", rgba.Rect)
+	}
+	ktx2TestPixels(t, rgba, map[[2]int][4]uint8{
+		{1, 0}: {84, 171, 0, 255}, {3, 2}: {255, 0, 0, 255}, {4, 0}: {10, 20, 30, 40}, {5, 2}: {10, 20, 30, 40},
+	})
+	// truncated data must fail, not panic
+	for i := range file {
+		if k, err := DecodeKTX2(file[:i]); err == nil {
+			k.RGBA(0, 0, 0)
+		}
+	}
+}
+
+func TestKTX2(t *testing.T) {
+	raw := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	for scheme, level := range map[KTX2Supercompression][]byte{
+		KTX2SupercompressionNone: raw,
+		KTX2SupercompressionZstd: func() []byte {
+			enc, _ := zstd.NewWriter(nil)
+			return enc.EncodeAll(raw, nil)
+		}(),
+	} {
+		t.Run(scheme.String(), func(t *testing.T) {
+			ktx2, err := DecodeKTX2(ktx2TestFile(vkFormatR8G8B8A8Srgb, scheme, KTX2ModelRGBSDA, nil, 2, 1, level, len(raw), nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(ktx2.KeyValue["KTXwriter"]) != "test\x00" || !ktx2.IsSRGB() || ktx2.DataFormat.TexelBlockDimension != [4]int{4, 4, 1, 1} {
+				t.Errorf("key/value %v, data format %v", ktx2.KeyValue, ktx2.DataFormat)
+			}
+			if data, err := ktx2.Level(0); err != nil || !bytes.Equal(data, raw) {
+				t.Errorf("level %v %v", data, err)
+			}
+			if img, err := ktx2.RGBA(0, 0, 0); err != nil || !bytes.Equal(img.Pix, raw) {
+				t.Errorf("RGBA %v %v", img, err)
+			}
+			// not basis universal, but it can be decoded
+			if issues := ktx2.Validate(); len(issues) != 1 || issues[0].Severity != SeverityWarning {
+				t.Errorf("issues %v", issues)
+			}
+		})
+	}
+	file := ktx2TestFile(vkFormatR8G8B8A8Srgb, KTX2SupercompressionNone, KTX2ModelRGBSDA, nil, 2, 1, raw, len(raw), nil)
+	for name, bad := range map[string][]byte{
+		"identifier": append([]byte("KTX"), file[3:]...),
+		"truncated":  file[:90],
+		"level":      file[:len(file)-1],
+	} {
+		if _, err := DecodeKTX2(bad); err == nil {
+			t.Errorf("%s must fail", name)
+		}
+	}
+	// vkFormat which can't be decoded is information, raw level is still usable
+	ktx2, err := DecodeKTX2(ktx2TestFile(131, KTX2SupercompressionNone, KTX2ModelRGBSDA, nil, 2, 1, raw, len(raw), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues := ktx2.Validate()
+	if len(issues) != 2 || len(issues.Filter(SeverityInformation)) != 1 {
+		t.Errorf("issues %v", issues)
+	}
+	if _, err := ktx2.RGBA(0, 0, 0); err == nil {
+		t.Errorf("vkFormat %d must fail", ktx2.VkFormat)
+	}
+}
@@ -0,0 +1,465 @@
+package gltf2
+
+import (
+	"encoding/binary"
+	"github.com/pkg/errors"
+	"math"
+)
+
+// meshoptimizer codec, https://github.com/KhronosGroup/glTF/tree/master/extensions/2.0/Vendor/EXT_meshopt_compression
+const (
+	meshoptVertexHeader   = 0xa0
+	meshoptIndexHeader    = 0xe0
+	meshoptSequenceHeader = 0xd0
+	//
+	meshoptByteGroupSize        = 16
+	meshoptByteGroupDecodeLimit = 24
+	meshoptVertexBlockSizeBytes = 8192
+	meshoptVertexBlockMaxSize   = 256
+	meshoptTailMaxSize          = 32
+)
+
+var errMeshoptTruncated = errors.New("meshopt data is truncated")
+
+// decodeMeshopt decode count elements of stride bytes, then apply filter
+func decodeMeshopt(src []byte, count, stride int, mode EXTMeshoptMode, filter EXTMeshoptFilter) ([]byte, error) {
+	if count < 0 || stride < 1 {
+		return nil, errors.Errorf("meshopt count %d, stride %d invalid", count, stride)
+	}
+	dst := make([]byte, count*stride)
+	var err error
+	switch mode {
+	case EXTMeshoptModeAttributes:
+		err = decodeMeshoptVertex(dst, count, stride, src)
+	case EXTMeshoptModeTriangles:
+		err = decodeMeshoptIndex(dst, count, stride, src)
+	case EXTMeshoptModeIndices:
+		err = decodeMeshoptSequence(dst, count, stride, src)
+	default:
+		err = errors.Errorf("meshopt mode %d unknown", mode)
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch filter {
+	case EXTMeshoptFilterNone:
+	case EXTMeshoptFilterOctahedral:
+		switch stride {
+		case 4:
+			meshoptOctFilter8(dst)
+		case 8:
+			meshoptOctFilter16(dst)
+		default:
+			return nil, errors.Errorf("meshopt OCTAHEDRAL filter need stride 4 or 8, but got %d", stride)
+		}
+	case EXTMeshoptFilterQuaternion:
+		if stride != 8 {
+			return nil, errors.Errorf("meshopt QUATERNION filter need stride 8, but got %d", stride)
+		}
+		meshoptQuatFilter(dst)
+	case EXTMeshoptFilterExponential:
+		if stride%4 != 0 {
+			return nil, errors.Errorf("meshopt EXPONENTIAL filter need stride multiple of 4, but got %d", stride)
+		}
+		meshoptExpFilter(dst)
+	default:
+		return nil, errors.Errorf("meshopt filter %d unknown", filter)
+	}
+	return dst, nil
+}
+
+func decodeMeshoptVertex(dst []byte, count, size int, src []byte) error {
+	if size < 1 || size > 256 || size%4 != 0 {
+		return errors.Errorf("meshopt ATTRIBUTES stride must be multiple of 4 up to 256, but got %d", size)
+	}
+	if len(src) < 1+size {
+		return errMeshoptTruncated
+	}
+	if src[0]&0xf0 != meshoptVertexHeader {
+		return errors.Errorf("meshopt vertex header 0x%02x invalid", src[0])
+	}
+	if version := src[0] & 0x0f; version > 0 {
+		return errors.Errorf("meshopt vertex codec version %d not supported", version)
+	}
+	// first vertex is stored at tail
+	last := make([]byte, size)
+	copy(last, src[len(src)-size:])
+	blockSize := meshoptVertexBlockSizeBytes / size &^ (meshoptByteGroupSize - 1)
+	if blockSize > meshoptVertexBlockMaxSize {
+		blockSize = meshoptVertexBlockMaxSize
+	}
+	var (
+		pos    = 1
+		groups = make([]byte, meshoptVertexBlockMaxSize)
+		err    error
+	)
+	for offset := 0; offset < count; offset += blockSize {
+		block := count - offset
+		if block > blockSize {
+			block = blockSize
+		}
+		aligned := (block + meshoptByteGroupSize - 1) &^ (meshoptByteGroupSize - 1)
+		for k := 0; k < size; k++ {
+			if pos, err = decodeMeshoptBytes(src, pos, groups[:aligned]); err != nil {
+				return err
+			}
+			p := last[k]
+			for i := 0; i < block; i++ {
+				// unzigzag
+				v := groups[i]
+				p += (0 - v&1) ^ v>>1
+				dst[(offset+i)*size+k] = p
+			}
+		}
+		copy(last, dst[(offset+block-1)*size:])
+	}
+	tail := size
+	if tail < meshoptTailMaxSize {
+		tail = meshoptTailMaxSize
+	}
+	if len(src)-pos != tail {
+		return errors.Errorf("meshopt vertex data has %d bytes after blocks, but tail is %d bytes", len(src)-pos, tail)
+	}
+	return nil
+}
+func decodeMeshoptBytes(src []byte, pos int, dst []byte) (int, error) {
+	// 2 bits header for each group
+	headerSize := (len(dst)/meshoptByteGroupSize + 3) / 4
+	if len(src)-pos < headerSize {
+		return 0, errMeshoptTruncated
+	}
+	header := src[pos : pos+headerSize]
+	pos += headerSize
+	for i := 0; i < len(dst); i += meshoptByteGroupSize {
+		if len(src)-pos < meshoptByteGroupDecodeLimit {
+			return 0, errMeshoptTruncated
+		}
+		g := i / meshoptByteGroupSize
+		group := dst[i : i+meshoptByteGroupSize]
+		switch bitslog2 := header[g/4] >> uint(g%4*2) & 3; bitslog2 {
+		case 0:
+			for j := range group {
+				group[j] = 0
+			}
+		case 1, 2:
+			// packed value of all bits set is escape, actual byte follows packed values
+			bits := uint(1) << bitslog2
+			escape := pos + meshoptByteGroupSize*int(bits)/8
+			for j := range group {
+				b := src[pos+j*int(bits)/8]
+				v := b >> (8 - bits - uint(j*int(bits)%8)) & (1<<bits - 1)
+				if v == 1<<bits-1 {
+					v = src[escape]
+					escape++
+				}
+				group[j] = v
+			}
+			pos = escape
+		case 3:
+			copy(group, src[pos:])
+			pos += meshoptByteGroupSize
+		}
+	}
+	return pos, nil
+}
+
+func decodeMeshoptIndex(dst []byte, count, size int, src []byte) error {
+	if count%3 != 0 {
+		return errors.Errorf("meshopt TRIANGLES count must be multiple of 3, but got %d", count)
+	}
+	if size != 2 && size != 4 {
+		return errors.Errorf("meshopt TRIANGLES stride must be 2 or 4, but got %d", size)
+	}
+	// header, 1 byte per triangle and 16 bytes codeaux table
+	if len(src) < 1+count/3+16 {
+		return errMeshoptTruncated
+	}
+	if src[0]&0xf0 != meshoptIndexHeader {
+		return errors.Errorf("meshopt index header 0x%02x invalid", src[0])
+	}
+	version := src[0] & 0x0f
+	if version > 1 {
+		return errors.Errorf("meshopt index codec version %d not supported", version)
+	}
+	var (
+		edges        [16][2]uint32
+		vertices     [16]uint32
+		edgeOffset   uint32
+		vertexOffset uint32
+		next, last   uint32
+		fecmax       = uint32(15)
+		code         = 1
+		data         = 1 + count/3
+		safeEnd      = len(src) - 16
+		codeaux      = src[safeEnd:]
+		pushEdge     = func(a, b uint32) { edges[edgeOffset] = [2]uint32{a, b}; edgeOffset = (edgeOffset + 1) & 15 }
+		pushVertex   = func(v uint32, cond bool) {
+			vertices[vertexOffset] = v
+			if cond {
+				vertexOffset = (vertexOffset + 1) & 15
+			}
+		}
+		write       = meshoptIndexWriter(dst, size)
+		decodeIndex = func() uint32 { v := meshoptVByte(src, &data); last += (v >> 1) ^ (0 - v&1); return last }
+	)
+	if version >= 1 {
+		fecmax = 13
+	}
+	for i := 0; i < count; i += 3 {
+		// each triangle read 16 bytes at most
+		if data > safeEnd {
+			return errMeshoptTruncated
+		}
+		codetri := src[code]
+		code++
+		if codetri < 0xf0 {
+			fe := uint32(codetri >> 4)
+			edge := edges[(edgeOffset-1-fe)&15]
+			a, b := edge[0], edge[1]
+			fec := uint32(codetri & 15)
+			var c uint32
+			if fec < fecmax {
+				if fec == 0 {
+					c = next
+					next++
+				} else {
+					c = vertices[(vertexOffset-1-fec)&15]
+				}
+				pushVertex(c, fec == 0)
+			} else {
+				if fec != 15 {
+					// 13, 14 is -1, 1
+					last += uint32(int32(fec) - int32(fec^3))
+					c = last
+				} else {
+					c = decodeIndex()
+				}
+				pushVertex(c, true)
+			}
+			write(i, a, b, c)
+			pushEdge(c, b)
+			pushEdge(a, c)
+			continue
+		}
+		var (
+			feb, fec, fea uint32
+			// slow path read codeaux and free indices from data
+			slow = codetri >= 0xfe
+		)
+		if !slow {
+			aux := codeaux[codetri&15]
+			feb, fec = uint32(aux>>4), uint32(aux&15)
+		} else {
+			aux := src[data]
+			data++
+			feb, fec = uint32(aux>>4), uint32(aux&15)
+			if codetri != 0xfe {
+				fea = 15
+			}
+			// reset
+			if aux == 0 {
+				next = 0
+			}
+		}
+		var a, b, c uint32
+		if fea == 0 {
+			a = next
+			next++
+		}
+		if feb == 0 {
+			b = next
+			next++
+		} else {
+			b = vertices[(vertexOffset-feb)&15]
+		}
+		if fec == 0 {
+			c = next
+			next++
+		} else {
+			c = vertices[(vertexOffset-fec)&15]
+		}
+		if slow {
+			if fea == 15 {
+				a = decodeIndex()
+			}
+			if feb == 15 {
+				b = decodeIndex()
+			}
+			if fec == 15 {
+				c = decodeIndex()
+			}
+		}
+		write(i, a, b, c)
+		pushVertex(a, true)
+		pushVertex(b, feb == 0 || slow && feb == 15)
+		pushVertex(c, fec == 0 || slow && fec == 15)
+		pushEdge(b, a)
+		pushEdge(c, b)
+		pushEdge(a, c)
+	}
+	if data != safeEnd {
+		return errors.Errorf("meshopt index data end at %d, but codeaux table start at %d", data, safeEnd)
+	}
+	return nil
+}
+func decodeMeshoptSequence(dst []byte, count, size int, src []byte) error {
+	if size != 2 && size != 4 {
+		return errors.Errorf("meshopt INDICES stride must be 2 or 4, but got %d", size)
+	}
+	// header, 1 byte per index and 4 bytes tail
+	if len(src) < 1+count+4 {
+		return errMeshoptTruncated
+	}
+	if src[0]&0xf0 != meshoptSequenceHeader {
+		return errors.Errorf("meshopt sequence header 0x%02x invalid", src[0])
+	}
+	if version := src[0] & 0x0f; version > 1 {
+		return errors.Errorf("meshopt sequence codec version %d not supported", version)
+	}
+	var (
+		data    = 1
+		safeEnd = len(src) - 4
+		last    [2]uint32
+	)
+	for i := 0; i < count; i++ {
+		// each index read 5 bytes at most
+		if data >= safeEnd {
+			return errMeshoptTruncated
+		}
+		v := meshoptVByte(src, &data)
+		// baseline is lowest bit, then zigzag delta
+		current := v & 1
+		v >>= 1
+		last[current] += (v >> 1) ^ (0 - v&1)
+		if size == 2 {
+			binary.LittleEndian.PutUint16(dst[i*2:], uint16(last[current]))
+		} else {
+			binary.LittleEndian.PutUint32(dst[i*4:], last[current])
+		}
+	}
+	if data != safeEnd {
+		return errors.Errorf("meshopt sequence data end at %d, but tail start at %d", data, safeEnd)
+	}
+	return nil
+}
+func meshoptIndexWriter(dst []byte, size int) func(i int, a, b, c uint32) {
+	if size == 2 {
+		return func(i int, a, b, c uint32) {
+			binary.LittleEndian.PutUint16(dst[i*2:], uint16(a))
+			binary.LittleEndian.PutUint16(dst[i*2+2:], uint16(b))
+			binary.LittleEndian.PutUint16(dst[i*2+4:], uint16(c))
+		}
+	}
+	return func(i int, a, b, c uint32) {
+		binary.LittleEndian.PutUint32(dst[i*4:], a)
+		binary.LittleEndian.PutUint32(dst[i*4+4:], b)
+		binary.LittleEndian.PutUint32(dst[i*4+8:], c)
+	}
+}
+
+// meshoptVByte read 7 bits per byte up to 5 bytes, caller guarantee 5 bytes are readable
+func meshoptVByte(src []byte, pos *int) uint32 {
+	lead := src[*pos]
+	*pos++
+	if lead < 128 {
+		return uint32(lead)
+	}
+	res, shift := uint32(lead&127), uint(7)
+	for i := 0; i < 4; i++ {
+		group := src[*pos]
+		*pos++
+		res |= uint32(group&127) << shift
+		shift += 7
+		if group < 128 {
+			break
+		}
+	}
+	return res
+}
+
+// meshoptRound is rounded signed float to int
+func meshoptRound(v float32) int32 {
+	if v >= 0 {
+		return int32(float32(v) + 0.5)
+	}
+	return int32(float32(v) - 0.5)
+}
+func meshoptOctFilter8(data []byte) {
+	for i := 0; i+4 <= len(data); i += 4 {
+		x, y, z := meshoptOct(float32(int8(data[i])), float32(int8(data[i+1])), float32(int8(data[i+2])), 127)
+		data[i], data[i+1], data[i+2] = byte(x), byte(y), byte(z)
+	}
+}
+func meshoptOctFilter16(data []byte) {
+	for i := 0; i+8 <= len(data); i += 8 {
+		x, y, z := meshoptOct(
+			float32(int16(binary.LittleEndian.Uint16(data[i:]))),
+			float32(int16(binary.LittleEndian.Uint16(data[i+2:]))),
+			float32(int16(binary.LittleEndian.Uint16(data[i+4:]))),
+			32767,
+		)
+		binary.LittleEndian.PutUint16(data[i:], uint16(x))
+		binary.LittleEndian.PutUint16(data[i+2:], uint16(y))
+		binary.LittleEndian.PutUint16(data[i+4:], uint16(z))
+	}
+}
+
+// meshoptOct reconstruct normal from octahedral x, y and z which encode 1.0
+func meshoptOct(x, y, one, max float32) (int32, int32, int32) {
+	z := one - float32(math.Abs(float64(x))) - float32(math.Abs(float64(y)))
+	// fixup octahedral coordinates for z < 0
+	t := z
+	if t > 0 {
+		t = 0
+	}
+	if x >= 0 {
+		x += t
+	} else {
+		x -= t
+	}
+	if y >= 0 {
+		y += t
+	} else {
+		y -= t
+	}
+	l := float32(math.Sqrt(float64(float32(x*x) + float32(y*y) + float32(z*z))))
+	s := max / l
+	return meshoptRound(x * s), meshoptRound(y * s), meshoptRound(z * s)
+}
+func meshoptQuatFilter(data []byte) {
+	scale := float32(1 / math.Sqrt(2))
+	for i := 0; i+8 <= len(data); i += 8 {
+		var q [4]int16
+		for j := range q {
+			q[j] = int16(binary.LittleEndian.Uint16(data[i+j*2:]))
+		}
+		// scale is high bits of 4th component, low 2 bits is index of max component
+		ss := scale / float32(q[3]|3)
+		x, y, z := float32(q[0])*ss, float32(q[1])*ss, float32(q[2])*ss
+		ww := 1 - float32(x*x) - float32(y*y) - float32(z*z)
+		if ww < 0 {
+			ww = 0
+		}
+		w := float32(math.Sqrt(float64(ww)))
+		qc := int(q[3] & 3)
+		// output order is dictated by max component index
+		var out [4]int32
+		out[(qc+1)&3] = meshoptRound(x * 32767)
+		out[(qc+2)&3] = meshoptRound(y * 32767)
+		out[(qc+3)&3] = meshoptRound(z * 32767)
+		out[qc] = int32(float32(w*32767) + 0.5)
+		for j, v := range out {
+			binary.LittleEndian.PutUint16(data[i+j*2:], uint16(v))
+		}
+	}
+}
+func meshoptExpFilter(data []byte) {
+	for i := 0; i+4 <= len(data); i += 4 {
+		v := binary.LittleEndian.Uint32(data[i:])
+		// 24 bits signed mantissa, 8 bits signed exponent
+		m := int32(v<<8) >> 8
+		e := int32(v) >> 24
+		f := math.Float32frombits(uint32(e+127)<<23) * float32(m)
+		binary.LittleEndian.PutUint32(data[i:], math.Float32bits(f))
+	}
+}
@@ -0,0 +1,215 @@
+package gltf2
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// meshoptTestVertex is 4 vertices of {px, py, pz uint16, nu, nv uint8, tx, ty uint16}, same as meshoptimizer test suite
+var meshoptTestVertex = meshoptTestLE(
+	[]uint16{0, 0, 0}, []uint8{0, 0}, []uint16{0, 0},
+	[]uint16{300, 0, 0}, []uint8{0, 0}, []uint16{500, 0},
+	[]uint16{0, 300, 0}, []uint8{0, 0}, []uint16{0, 500},
+	[]uint16{300, 300, 0}, []uint8{0, 0}, []uint16{500, 500},
+)
+
+// meshoptTestVertexV0 is meshoptTestVertex encoded in vertex codec version 0
+var meshoptTestVertexV0 = append([]byte{
+	0xa0,
+	0x01, 0x3f, 0x00, 0x00, 0x00, 0x58, 0x57, 0x58,
+	0x01, 0x26, 0x00, 0x00, 0x00,
+	0x01, 0x0c, 0x00, 0x00, 0x00, 0x58,
+	0x01, 0x08, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00,
+	0x01, 0x3f, 0x00, 0x00, 0x00, 0x17, 0x18, 0x17,
+	0x01, 0x26, 0x00, 0x00, 0x00,
+	0x01, 0x0c, 0x00, 0x00, 0x00, 0x17,
+	0x01, 0x08, 0x00, 0x00, 0x00,
+}, make([]byte, 32)...)
+
+// meshopt test vectors of index codec, index sequence codec and filters from meshoptimizer test suite
+var meshoptTestCases = []struct {
+	name          string
+	mode          EXTMeshoptMode
+	filter        EXTMeshoptFilter
+	count, stride int
+	src, expected []byte
+}{
+	{
+		name: "ATTRIBUTES", mode: EXTMeshoptModeAttributes, count: 4, stride: 12,
+		src: meshoptTestVertexV0, expected: meshoptTestVertex,
+	},
+	{
+		name: "TRIANGLES", mode: EXTMeshoptModeTriangles, count: 12, stride: 4,
+		src: []byte{
+			0xe0, 0xf0, 0x10, 0xfe, 0xff, 0xf0, 0x0c, 0xff, 0x02, 0x02, 0x02, 0x00, 0x76, 0x87, 0x56, 0x67,
+			0x78, 0xa9, 0x86, 0x65, 0x89, 0x68, 0x98, 0x01, 0x69, 0x00, 0x00,
+		},
+		expected: meshoptTestLE([]uint32{0, 1, 2, 2, 1, 3, 4, 6, 5, 7, 8, 9}),
+	},
+	{
+		name: "INDICES", mode: EXTMeshoptModeIndices, count: 6, stride: 4,
+		src:      []byte{0xd1, 0x00, 0x04, 0xcd, 0x01, 0x04, 0x07, 0x98, 0x1f, 0x00, 0x00, 0x00, 0x00},
+		expected: meshoptTestLE([]uint32{0, 1, 51, 2, 49, 1000}),
+	},
+	{
+		name: "OCTAHEDRAL8", mode: EXTMeshoptModeAttributes, filter: EXTMeshoptFilterOctahedral, count: 4, stride: 4,
+		src:      meshoptTestEncodeVertex([]byte{0, 1, 127, 0, 0, 187, 127, 1, 255, 1, 127, 0, 14, 130, 127, 1}, 4),
+		expected: []byte{0, 1, 127, 0, 0, 159, 82, 1, 255, 1, 127, 0, 1, 130, 241, 1},
+	},
+	{
+		name: "OCTAHEDRAL16", mode: EXTMeshoptModeAttributes, filter: EXTMeshoptFilterOctahedral, count: 4, stride: 8,
+		src: meshoptTestEncodeVertex(meshoptTestLE([]uint16{
+			0, 1, 2047, 0, 0, 1870, 2047, 1, 2017, 1, 2047, 0, 14, 1300, 2047, 1,
+		}), 8),
+		expected: meshoptTestLE([]uint16{
+			0, 16, 32767, 0, 0, 32621, 3088, 1, 32764, 16, 471, 0, 307, 28541, 16093, 1,
+		}),
+	},
+	{
+		name: "QUATERNION", mode: EXTMeshoptModeAttributes, filter: EXTMeshoptFilterQuaternion, count: 4, stride: 8,
+		src: meshoptTestEncodeVertex(meshoptTestLE([]uint16{
+			0, 1, 0, 0x7fc, 0, 1870, 0, 0x7fd, 2017, 1, 0, 0x7fe, 14, 1300, 0, 0x7ff,
+		}), 8),
+		expected: meshoptTestLE([]uint16{
+			32767, 0, 11, 0, 0, 25013, 0, 21166, 11, 0, 23504, 22830, 158, 14715, 0, 29277,
+		}),
+	},
+	{
+		name: "EXPONENTIAL", mode: EXTMeshoptModeAttributes, filter: EXTMeshoptFilterExponential, count: 4, stride: 4,
+		src:      meshoptTestEncodeVertex(meshoptTestLE([]uint32{0, 0xff000003, 0x02fffff7, 0xfe7fffff}), 4),
+		expected: meshoptTestLE([]uint32{0, 0x3fc00000, 0xc2100000, 0x49fffffe}),
+	},
+}
+
+func meshoptTestLE(data ...interface{}) []byte {
+	var b bytes.Buffer
+	for _, v := range data {
+		binary.Write(&b, binary.LittleEndian, v)
+	}
+	return b.Bytes()
+}
+
+// meshoptTestEncodeVertex encode data in vertex codec version 0, every group is stored as 8 bits
+//
+// It is for filter input, block size is limited to 16 vertices.
+func meshoptTestEncodeVertex(data []byte, stride int) []byte {
+	count := len(data) / stride
+	res := []byte{0xa0}
+	for k := 0; k < stride; k++ {
+		res = append(res, 0x03)
+		group := make([]byte, 16)
+		var last byte
+		for i := 0; i < count; i++ {
+			d := data[i*stride+k] - last
+			group[i] = d<<1 ^ byte(int8(d)>>7)
+			last = data[i*stride+k]
+		}
+		res = append(res, group...)
+	}
+	// tail is zero baseline, padded to 32 bytes
+	tail := stride
+	if tail < 32 {
+		tail = 32
+	}
+	return append(res, make([]byte, tail)...)
+}
+
+func TestDecodeMeshopt(t *testing.T) {
+	for _, c := range meshoptTestCases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := decodeMeshopt(c.src, c.count, c.stride, c.mode, c.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, c.expected) {
+				t.Errorf("decoded %v, expected %v", got, c.expected)
+			}
+			// truncated data must fail, not panic
+			if _, err := decodeMeshopt(c.src[:len(c.src)/2], c.count, c.stride, c.mode, c.filter); err == nil {
+				t.Error("truncated data must fail")
+			}
+		})
+	}
+}
+
+// meshoptTestDocuments is glTF of meshoptTestCases, one is compressed with fallback buffer and another is not compressed
+//
+// Each case is one bufferView in both.
+func meshoptTestDocuments() (compressedJSON, uncompressedJSON string) {
+	var (
+		compressed, uncompressed []byte
+		compressedViews          []string
+		uncompressedViews        []string
+	)
+	for _, c := range meshoptTestCases {
+		ext := fmt.Sprintf(`"EXT_meshopt_compression":{"buffer":0,"byteOffset":%d,"byteLength":%d,"byteStride":%d,"count":%d,"mode":"%s"`,
+			len(compressed), len(c.src), c.stride, c.count, c.mode)
+		if c.filter != EXTMeshoptFilterNone {
+			ext += fmt.Sprintf(`,"filter":"%s"`, c.filter)
+		}
+		view := fmt.Sprintf(`{"buffer":%%d,"byteOffset":%d,"byteLength":%d`, len(uncompressed), len(c.expected))
+		if c.mode == EXTMeshoptModeAttributes {
+			view += fmt.Sprintf(`,"byteStride":%d`, c.stride)
+		}
+		compressedViews = append(compressedViews, fmt.Sprintf(view, 1)+`,"extensions":{`+ext+`}}}`)
+		uncompressedViews = append(uncompressedViews, fmt.Sprintf(view, 0)+`}`)
+		compressed = append(compressed, c.src...)
+		for len(compressed)%4 != 0 {
+			compressed = append(compressed, 0)
+		}
+		uncompressed = append(uncompressed, c.expected...)
+	}
+	dataURI := func(b []byte) string {
+		return fmt.Sprintf(`{"byteLength":%d,"uri":"data:application/octet-stream;base64,%s"}`, len(b), base64.StdEncoding.EncodeToString(b))
+	}
+	compressedJSON = fmt.Sprintf(`{
+	"asset":{"version":"2.0"},
+	"extensionsUsed":["EXT_meshopt_compression"],
+	"extensionsRequired":["EXT_meshopt_compression"],
+	"buffers":[%s,{"byteLength":%d,"extensions":{"EXT_meshopt_compression":{"fallback":true}}}],
+	"bufferViews":[%s]
+}`, dataURI(compressed), len(uncompressed), strings.Join(compressedViews, ","))
+	uncompressedJSON = fmt.Sprintf(`{
+	"asset":{"version":"2.0"},
+	"buffers":[%s],
+	"bufferViews":[%s]
+}`, dataURI(uncompressed), strings.Join(uncompressedViews, ","))
+	return compressedJSON, uncompressedJSON
+}
+
+// meshoptTestCompare check every bufferView of g is decoded to uncompressed data
+func meshoptTestCompare(t *testing.T, g *GLTF) {
+	_, uncompressed := meshoptTestDocuments()
+	expected, err := Parser().Reader(strings.NewReader(uncompressed)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range meshoptTestCases {
+		got, err := g.BufferViews[i].Load()
+		if err != nil {
+			t.Fatal(c.name, err)
+		}
+		data, err := expected.BufferViews[i].Load()
+		if err != nil {
+			t.Fatal(c.name, err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Errorf("%s bufferView %v, expected %v", c.name, got, data)
+		}
+	}
+}
+
+// TestMeshoptFallback compare compressed glTF and uncompressed glTF, every bufferView must be same
+func TestMeshoptFallback(t *testing.T) {
+	compressed, _ := meshoptTestDocuments()
+	g, err := Parser().Reader(strings.NewReader(compressed)).Extensions(new(EXTMeshoptCompression)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	meshoptTestCompare(t, g)
+}
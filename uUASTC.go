@@ -0,0 +1,393 @@
+package gltf2
+
+import (
+	"github.com/pkg/errors"
+	"image"
+)
+
+// UASTC transcoder, https://github.com/BinomialLLC/basis_universal/wiki/UASTC-Texture-Specification
+//
+// It follows basis_universal transcoder, UASTC block is unpacked to ASTC endpoints, weights and decoded to RGBA directly.
+// ETC1, BC1 hints are skipped, they are only for transcoding to GPU formats
+const (
+	uastcBlockLength = 16
+	uastcModeSolid   = 8
+)
+
+// uastcMode is layout of UASTC mode, endpointRange is ASTC BISE range
+type uastcMode struct {
+	subsets, planes, comps int
+	weightBits             uint
+	endpointRange          int
+	bc1Hints               uint
+	etc1Bias               bool
+	// ASTC partition seeds of common patterns
+	patterns []int
+}
+
+// ASTC partition seeds of BC7 common patterns, 2 subsets, 3 subsets, BC7 3 subsets as ASTC 2 subsets
+var (
+	uastcPatterns2 = []int{
+		28, 20, 16, 29, 91, 9, 107, 72, 149, 204, 50, 114, 496, 17, 78,
+		39, 252, 828, 43, 156, 116, 210, 476, 273, 684, 359, 246, 195, 694, 524,
+	}
+	uastcPatterns3    = []int{260, 74, 32, 156, 183, 15, 745, 0, 335, 902, 254}
+	uastcPatterns3As2 = []int{36, 48, 61, 137, 161, 183, 226, 281, 302, 307, 479, 495, 593, 594, 605, 799, 812, 988, 993}
+	uastcModes        = [...]uastcMode{
+		{subsets: 1, planes: 1, comps: 3, weightBits: 4, endpointRange: 19, bc1Hints: 1, etc1Bias: true},
+		{subsets: 1, planes: 1, comps: 3, weightBits: 2, endpointRange: 20, bc1Hints: 1, etc1Bias: true},
+		{subsets: 2, planes: 1, comps: 3, weightBits: 3, endpointRange: 8, bc1Hints: 2, etc1Bias: true, patterns: uastcPatterns2},
+		{subsets: 3, planes: 1, comps: 3, weightBits: 2, endpointRange: 7, bc1Hints: 2, etc1Bias: true, patterns: uastcPatterns3},
+		{subsets: 2, planes: 1, comps: 3, weightBits: 2, endpointRange: 12, bc1Hints: 2, etc1Bias: true, patterns: uastcPatterns2},
+		{subsets: 1, planes: 1, comps: 3, weightBits: 3, endpointRange: 20, bc1Hints: 1, etc1Bias: true},
+		{subsets: 1, planes: 2, comps: 3, weightBits: 2, endpointRange: 18, bc1Hints: 2},
+		{subsets: 2, planes: 1, comps: 3, weightBits: 2, endpointRange: 12, bc1Hints: 2, etc1Bias: true, patterns: uastcPatterns3As2},
+		// solid color
+		{},
+		{subsets: 2, planes: 1, comps: 4, weightBits: 2, endpointRange: 8, etc1Bias: true, patterns: uastcPatterns2},
+		{subsets: 1, planes: 1, comps: 4, weightBits: 4, endpointRange: 13, etc1Bias: true},
+		{subsets: 1, planes: 2, comps: 4, weightBits: 2, endpointRange: 13},
+		{subsets: 1, planes: 1, comps: 4, weightBits: 3, endpointRange: 19, etc1Bias: true},
+		{subsets: 1, planes: 2, comps: 4, weightBits: 1, endpointRange: 20},
+		{subsets: 1, planes: 1, comps: 2, weightBits: 2, endpointRange: 20, etc1Bias: true},
+		{subsets: 1, planes: 1, comps: 2, weightBits: 4, endpointRange: 20, etc1Bias: true},
+		{subsets: 2, planes: 1, comps: 2, weightBits: 2, endpointRange: 20, etc1Bias: true, patterns: uastcPatterns2},
+		{subsets: 1, planes: 2, comps: 2, weightBits: 2, endpointRange: 20},
+		{subsets: 1, planes: 1, comps: 3, weightBits: 5, endpointRange: 11, bc1Hints: 2},
+	}
+)
+
+// uastcModeCodes is mode code, LSB first, and its length, code of reserved mode is not included
+var uastcModeCodes = [len(uastcModes)][2]uint8{
+	{0x1, 4}, {0x35, 6}, {0x1D, 5}, {0x3, 5}, {0x13, 5}, {0xB, 5}, {0x1B, 5}, {0x7, 5}, {0x17, 5}, {0xF, 5},
+	{0x2, 3}, {0x0, 2}, {0x6, 3}, {0x1F, 5}, {0xD, 5}, {0x5, 7}, {0x15, 6}, {0x25, 6}, {0x9, 4},
+}
+
+// uastcModeTable is mode of low 7 bits of block, -1 is reserved
+var uastcModeTable [128]int8
+
+// astcBISERanges is bits, trits, quints of ASTC BISE range
+var astcBISERanges = [21][3]uint{
+	{1, 0, 0}, {0, 1, 0}, {2, 0, 0}, {0, 0, 1}, {1, 1, 0}, {3, 0, 0}, {1, 0, 1}, {2, 1, 0}, {4, 0, 0}, {2, 0, 1}, {3, 1, 0},
+	{5, 0, 0}, {3, 0, 1}, {4, 1, 0}, {6, 0, 0}, {4, 0, 1}, {5, 1, 0}, {7, 0, 0}, {5, 0, 1}, {6, 1, 0}, {8, 0, 0},
+}
+
+// decodeUASTC decode UASTC blocks of one image to RGBA
+func decodeUASTC(data []byte, width, height int) (*image.RGBA, error) {
+	var (
+		blocksX = (width + 3) / 4
+		blocksY = (height + 3) / 4
+		res     = image.NewRGBA(image.Rect(0, 0, width, height))
+		pixels  [16][4]uint8
+	)
+	if len(data) < blocksX*blocksY*uastcBlockLength {
+		return nil, errors.Errorf("UASTC image need %d blocks, but got %d bytes", blocksX*blocksY, len(data))
+	}
+	for by := 0; by < blocksY; by++ {
+		for bx := 0; bx < blocksX; bx++ {
+			block := data[(by*blocksX+bx)*uastcBlockLength:]
+			if err := uastcBlock(block[:uastcBlockLength], &pixels); err != nil {
+				return nil, errors.WithMessage(err, "UASTC block")
+			}
+			for j := 0; j < 4 && by*4+j < height; j++ {
+				for i := 0; i < 4 && bx*4+i < width; i++ {
+					copy(res.Pix[res.PixOffset(bx*4+i, by*4+j):], pixels[j*4+i][:])
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+// uastcBlock decode 4x4 texels of block, row major
+func uastcBlock(block []byte, pixels *[16][4]uint8) error {
+	index := uastcModeTable[block[0]&0x7F]
+	if index < 0 {
+		return errors.New("reserved mode")
+	}
+	var (
+		mode = uastcModes[index]
+		bits = &basisBits{data: block}
+	)
+	bits.bits(uint(uastcModeCodes[index][1]))
+	if index == uastcModeSolid {
+		var c [4]uint8
+		for i := range c {
+			c[i] = uint8(bits.bits(8))
+		}
+		for i := range pixels {
+			pixels[i] = c
+		}
+		return nil
+	}
+	// hints, flip, diff, 2 intensity tables of ETC1
+	bits.bits(mode.bc1Hints + 8)
+	if mode.etc1Bias {
+		bits.bits(5)
+	}
+	ccs := -1
+	if mode.planes == 2 {
+		ccs = bits.bits(2)
+	}
+	var pattern [16]int
+	if mode.subsets > 1 {
+		patternBits := uint(5)
+		if mode.subsets == 3 {
+			patternBits = 4
+		}
+		i := bits.bits(patternBits)
+		if i >= len(mode.patterns) {
+			return errors.Errorf("pattern %d out of range", i)
+		}
+		for t := range pattern {
+			pattern[t] = astcPartition(mode.patterns[i], mode.subsets, t%4, t/4)
+		}
+	}
+	endpoints := uastcEndpoints(bits, mode.subsets*mode.comps*2, mode.endpointRange)
+	// first texel of each subset is anchor, its MSB of weight is omitted
+	var (
+		weights [32]int
+		anchor  [16]bool
+		found   [3]bool
+	)
+	for t, s := range pattern {
+		anchor[t], found[s] = !found[s], true
+	}
+	for i := 0; i < 16*mode.planes; i++ {
+		n := mode.weightBits
+		if anchor[i/mode.planes] && i%mode.planes == 0 || mode.planes == 2 && i == 1 {
+			n--
+		}
+		weights[i] = astcWeight(bits.bits(n), mode.weightBits)
+	}
+	// colors[subset][low, high], LA is replicated to RGB, missing alpha is 255
+	var colors [3][2][4]int
+	for s := 0; s < mode.subsets; s++ {
+		for c := 0; c < 4; c++ {
+			for e := 0; e < 2; e++ {
+				switch {
+				case mode.comps == 2 && c < 3:
+					colors[s][e][c] = endpoints[s*4+e]
+				case mode.comps == 2:
+					colors[s][e][c] = endpoints[s*4+2+e]
+				case c < mode.comps:
+					colors[s][e][c] = endpoints[(s*mode.comps+c)*2+e]
+				default:
+					colors[s][e][c] = 255
+				}
+			}
+		}
+	}
+	for t := range pixels {
+		ends := colors[pattern[t]]
+		for c := range pixels[t] {
+			w := weights[t*mode.planes]
+			if c == ccs {
+				w = weights[t*2+1]
+			}
+			pixels[t][c] = astcInterpolate(ends[0][c], ends[1][c], w)
+		}
+	}
+	return nil
+}
+
+// uastcEndpoints read count endpoints and unquantize them
+//
+// Unlike ASTC, trits and quints are packed as base 3, 5 integer of 5, 3 values and they come before bits of values
+func uastcEndpoints(bits *basisBits, count int, rng int) []int {
+	var (
+		b, trits, quints = astcBISERanges[rng][0], astcBISERanges[rng][1], astcBISERanges[rng][2]
+		bundle, base     int
+		packs            []int
+		res              = make([]int, count)
+	)
+	switch {
+	case trits > 0:
+		bundle, base = 5, 3
+	case quints > 0:
+		bundle, base = 3, 5
+	}
+	if bundle > 0 {
+		for i := 0; i < count; i += bundle {
+			remain := count - i
+			if remain > bundle {
+				remain = bundle
+			}
+			// bits of base^remain values
+			n := uint(0)
+			for v := 1; v < uastcPow(base, remain); v <<= 1 {
+				n++
+			}
+			packs = append(packs, bits.bits(n))
+		}
+	}
+	for i := range res {
+		m := bits.bits(b)
+		if bundle == 0 {
+			res[i] = astcUnquantEndpoint(m, 0, b, 0, 0)
+			continue
+		}
+		d := packs[i/bundle] / uastcPow(base, i%bundle) % base
+		res[i] = astcUnquantEndpoint(m, d, b, trits, quints)
+	}
+	return res
+}
+
+func uastcPow(base, exp int) int {
+	res := 1
+	for i := 0; i < exp; i++ {
+		res *= base
+	}
+	return res
+}
+
+// astcUnquantEndpoint unquantize endpoint of m bits and trit, quint d to 8 bits
+func astcUnquantEndpoint(m, d int, b uint, trits, quints uint) int {
+	if trits == 0 && quints == 0 {
+		// bit replication
+		res := 0
+		for shift := int(8 - b); shift > -int(b); shift -= int(b) {
+			if shift >= 0 {
+				res |= m << uint(shift)
+			} else {
+				res |= m >> uint(-shift)
+			}
+		}
+		return res & 0xFF
+	}
+	bit := func(i uint) int { return (m >> i) & 1 }
+	var B, C int
+	switch {
+	case trits > 0 && b == 1:
+		C = 204
+	case trits > 0 && b == 2:
+		B, C = bit(1)*0x116, 93
+	case trits > 0 && b == 3:
+		B, C = bit(2)*0x10A+bit(1)*0x85, 44
+	case trits > 0 && b == 4:
+		B, C = bit(3)*0x104+bit(2)*0x82+bit(1)*0x41, 22
+	case trits > 0 && b == 5:
+		B, C = bit(4)*0x102+bit(3)*0x81+bit(2)*0x40+bit(1)*0x20, 11
+	case trits > 0:
+		B, C = bit(5)*0x101+bit(4)*0x80+bit(3)*0x40+bit(2)*0x20+bit(1)*0x10, 5
+	case b == 1:
+		C = 113
+	case b == 2:
+		B, C = bit(1)*0x10C, 54
+	case b == 3:
+		B, C = bit(2)*0x105+bit(1)*0x82, 26
+	case b == 4:
+		B, C = bit(3)*0x102+bit(2)*0x81+bit(1)*0x40, 13
+	default:
+		B, C = bit(4)*0x101+bit(3)*0x80+bit(2)*0x40+bit(1)*0x20, 6
+	}
+	A := bit(0) * 0x1FF
+	T := (d*C + B) ^ A
+	return A&0x80 | T>>2
+}
+
+// astcWeight unquantize weight of b bits to 0~64
+func astcWeight(v int, b uint) int {
+	res := 0
+	for shift := int(6 - b); shift > -int(b); shift -= int(b) {
+		if shift >= 0 {
+			res |= v << uint(shift)
+		} else {
+			res |= v >> uint(-shift)
+		}
+	}
+	if res &= 0x3F; res > 32 {
+		res++
+	}
+	return res
+}
+
+// astcInterpolate is LDR interpolation of ASTC, endpoints are expanded to 16 bits
+func astcInterpolate(l, h, w int) uint8 {
+	l, h = l<<8|l, h<<8|h
+	return uint8((l*(64-w) + h*w + 32) >> 6 >> 8)
+}
+
+// astcPartition is ASTC partition of texel x, y in 4x4 block
+func astcPartition(seed, partitions, x, y int) int {
+	// small block, texel count is less than 31
+	x, y = x<<1, y<<1
+	seed += (partitions - 1) * 1024
+	r := uint32(seed)
+	r ^= r >> 15
+	r -= r << 17
+	r += r << 7
+	r += r << 4
+	r ^= r >> 5
+	r += r << 16
+	r ^= r >> 7
+	r ^= r >> 3
+	r ^= r << 6
+	r ^= r >> 17
+	var s [12]uint32
+	for i := range s[:8] {
+		s[i] = r >> uint(i*4) & 0xF
+	}
+	s[8], s[9], s[10], s[11] = r>>18&0xF, r>>22&0xF, r>>26&0xF, (r>>30|r<<2)&0xF
+	for i := range s {
+		s[i] *= s[i]
+	}
+	var sh1, sh2 uint = 5, 5
+	if seed&1 != 0 {
+		if seed&2 != 0 {
+			sh1 = 4
+		}
+		if partitions == 3 {
+			sh2 = 6
+		}
+	} else {
+		if partitions == 3 {
+			sh1 = 6
+		}
+		if seed&2 != 0 {
+			sh2 = 4
+		}
+	}
+	sh3 := sh2
+	if seed&0x10 != 0 {
+		sh3 = sh1
+	}
+	for i := range s {
+		switch {
+		case i >= 8:
+			s[i] >>= sh3
+		case i%2 == 0:
+			s[i] >>= sh1
+		default:
+			s[i] >>= sh2
+		}
+	}
+	// z is 0
+	var (
+		X, Y = uint32(x), uint32(y)
+		a    = (s[0]*X + s[1]*Y + r>>14) & 0x3F
+		b    = (s[2]*X + s[3]*Y + r>>10) & 0x3F
+		c    = (s[4]*X + s[5]*Y + r>>6) & 0x3F
+	)
+	if partitions < 3 {
+		c = 0
+	}
+	switch {
+	case a >= b && a >= c:
+		return 0
+	case b >= c:
+		return 1
+	}
+	return 2
+}
+
+func init() {
+	for i := range uastcModeTable {
+		uastcModeTable[i] = -1
+	}
+	for mode, code := range uastcModeCodes {
+		for i := 0; i < 128; i += 1 << code[1] {
+			uastcModeTable[i|int(code[0])] = int8(mode)
+		}
+	}
+}
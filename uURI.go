@@ -1,9 +1,12 @@
 package gltf2
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"github.com/pkg/errors"
 	"net/url"
+	"strings"
 )
 
 type URI url.URL
@@ -12,6 +15,9 @@ func (s *URI) String() string {
 	return s.Data().String()
 }
 
+func (s *URI) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
 func (s *URI) UnmarshalJSON(data []byte) error {
 	var v string
 	if err := json.Unmarshal(data, &v); err != nil {
@@ -30,3 +36,82 @@ func (s *URI) Data() *url.URL {
 	*res = url.URL(*s)
 	return res
 }
+
+// IsDataURI report URI is data URI(RFC 2397), it can be decoded without any network or filesystem access
+func (s *URI) IsDataURI() bool {
+	return s.Scheme == "data"
+}
+
+// DataURI decode data URI(RFC 2397)
+//
+// ex) data:application/octet-stream;base64,AAABAAIAAAA=
+//     mime : "application/octet-stream"
+func (s *URI) DataURI() (mime string, data []byte, err error) {
+	if !s.IsDataURI() {
+		return "", nil, errors.Errorf("URI scheme '%s' is not data", s.Scheme)
+	}
+	sep := strings.IndexByte(s.Opaque, ',')
+	if sep < 0 {
+		return "", nil, errors.New("data URI has no ','")
+	}
+	var (
+		meta    = s.Opaque[:sep]
+		payload = s.Opaque[sep+1:]
+		encoded = false
+	)
+	if strings.HasSuffix(meta, ";base64") {
+		meta = strings.TrimSuffix(meta, ";base64")
+		encoded = true
+	}
+	// drop parameter, ex) charset
+	if param := strings.IndexByte(meta, ';'); param >= 0 {
+		meta = meta[:param]
+	}
+	mime = strings.ToLower(meta)
+	if len(mime) == 0 {
+		mime = "text/plain"
+	}
+	if encoded {
+		data, err = base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			// some exporter omit padding
+			data, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(payload, "="))
+		}
+	} else {
+		var unescaped string
+		unescaped, err = url.PathUnescape(payload)
+		data = []byte(unescaped)
+	}
+	if err != nil {
+		return "", nil, errors.WithMessage(err, "data URI payload")
+	}
+	return mime, data, nil
+}
+
+// newDataURI encode data as base64 data URI(RFC 2397)
+func newDataURI(mime string, data []byte) *URI {
+	return &URI{
+		Scheme: "data",
+		Opaque: mime + ";base64," + base64.StdEncoding.EncodeToString(data),
+	}
+}
+
+// isRemoteURI report URI is not relative path, file or data URI, ex) http, https
+func isRemoteURI(uri *URI) bool {
+	switch uri.Scheme {
+	case "", "file", "data":
+		return false
+	}
+	return true
+}
+
+// short description of URI for logging, data URI can be very long
+func uriLabel(uri *URI) string {
+	if uri.IsDataURI() {
+		if sep := strings.IndexByte(uri.Opaque, ','); sep >= 0 {
+			return fmt.Sprintf("data:%s,...(%d characters)", uri.Opaque[:sep], len(uri.Opaque)-sep-1)
+		}
+	}
+	uesc, _ := url.PathUnescape(uri.Data().String())
+	return uesc
+}